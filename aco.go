@@ -0,0 +1,187 @@
+package color
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"unicode/utf16"
+)
+
+// ACO color space ids
+const (
+	acoRGB       uint16 = 0
+	acoHSB       uint16 = 1
+	acoCMYK      uint16 = 2
+	acoLab       uint16 = 7
+	acoGrayscale uint16 = 8
+)
+
+// AcoSwatch is a color of a Photoshop .aco swatch file
+// Color holds an RGB, HSV, CMYK or LAB value; grayscale entries are read
+// as RGB. Names are only present in version 2 files.
+type AcoSwatch struct {
+	Name  string
+	Color any
+}
+
+// ReadAco reads a Photoshop color swatch (.aco) file
+// Version 1 files are read as is; when a version 2 section follows, its
+// named colors are returned instead.
+// Parameters:
+//   r: reader with the binary ACO content
+// Returns:
+//   []AcoSwatch: swatches in file order
+//   error: read error, malformed file or unsupported color space
+// Example:
+//   f, _ := os.Open("swatches.aco")
+//   swatches, err := ReadAco(f)
+func ReadAco(r io.Reader) ([]AcoSwatch, error) {
+	swatches, err := readAcoSection(r, 1)
+	if err != nil {
+		return nil, err
+	}
+	v2, err := readAcoSection(r, 2)
+	if errors.Is(err, io.EOF) {
+		return swatches, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v2, nil
+}
+
+// readAcoSection reads one version section, returning io.EOF if the input
+// ends before the section header
+func readAcoSection(r io.Reader, version uint16) ([]AcoSwatch, error) {
+	var header [2]uint16
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, fmt.Errorf("invalid aco header: %w", err)
+	}
+	if header[0] != version {
+		return nil, fmt.Errorf("unexpected aco version: %d", header[0])
+	}
+	swatches := make([]AcoSwatch, 0, header[1])
+	for i := 0; i < int(header[1]); i++ {
+		var rec [5]uint16
+		if err := binary.Read(r, binary.BigEndian, &rec); err != nil {
+			return nil, fmt.Errorf("invalid aco color: %w", err)
+		}
+		c, err := decodeAcoColor(rec[0], rec[1], rec[2], rec[3], rec[4])
+		if err != nil {
+			return nil, err
+		}
+		sw := AcoSwatch{Color: c}
+		if version == 2 {
+			var n uint32
+			if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+				return nil, fmt.Errorf("invalid aco name: %w", err)
+			}
+			data, err := readBlock(r, 2*int64(n))
+			if err != nil {
+				return nil, fmt.Errorf("invalid aco name: %w", err)
+			}
+			units := make([]uint16, n)
+			binary.Read(bytes.NewReader(data), binary.BigEndian, units)
+			if n > 0 && units[n-1] == 0 {
+				units = units[:n-1]
+			}
+			sw.Name = string(utf16.Decode(units))
+		}
+		swatches = append(swatches, sw)
+	}
+	return swatches, nil
+}
+
+// decodeAcoColor converts the four 16-bit channel values of a color space
+func decodeAcoColor(space, w, x, y, z uint16) (any, error) {
+	to8 := func(v uint16) uint8 { return uint8((uint32(v)*255 + 32767) / 65535) }
+	scale := func(v uint16, max float64) uint32 { return uint32(math.Round(float64(v) / 65535 * max)) }
+	// CMYK channels store 0 for 100% ink
+	ink := func(v uint16) uint8 { return uint8(math.Round(float64(65535-v) / 65535 * 100)) }
+	switch space {
+	case acoRGB:
+		return RGB{to8(w), to8(x), to8(y)}, nil
+	case acoHSB:
+		return HSV{scale(w, 360) % 360, scale(x, 100), scale(y, 100)}, nil
+	case acoCMYK:
+		return CMYK{ink(w), ink(x), ink(y), ink(z)}, nil
+	case acoLab:
+		return LAB{L: float64(w) / 100, A: float64(int16(x)) / 100, B: float64(int16(y)) / 100}, nil
+	case acoGrayscale:
+		g := unitToUint8(1 - float64(w)/10000)
+		return RGB{g, g, g}, nil
+	}
+	return nil, fmt.Errorf("unsupported aco color space: %d", space)
+}
+
+// encodeAcoColor converts a swatch color to its color space and 16-bit channels
+func encodeAcoColor(c any) ([5]uint16, error) {
+	to16 := func(v uint8) uint16 { return uint16(v) * 257 }
+	unit := func(v float64, max float64) uint16 {
+		return uint16(math.Round(math.Max(0, math.Min(1, v/max)) * 65535))
+	}
+	switch c := c.(type) {
+	case RGB:
+		return [5]uint16{acoRGB, to16(c.R), to16(c.G), to16(c.B), 0}, nil
+	case HSV:
+		return [5]uint16{acoHSB, unit(float64(c.H%360), 360), unit(float64(c.S), 100), unit(float64(c.V), 100), 0}, nil
+	case CMYK:
+		ink := func(v uint8) uint16 { return 65535 - unit(float64(v), 100) }
+		return [5]uint16{acoCMYK, ink(c.C), ink(c.M), ink(c.Y), ink(c.K)}, nil
+	case LAB:
+		return [5]uint16{
+			acoLab,
+			uint16(math.Round(math.Max(0, math.Min(100, c.L)) * 100)),
+			uint16(int16(math.Round(math.Max(-128, math.Min(127, c.A)) * 100))),
+			uint16(int16(math.Round(math.Max(-128, math.Min(127, c.B)) * 100))),
+			0,
+		}, nil
+	case ToRgb:
+		return encodeAcoColor(c.ToRgb())
+	}
+	return [5]uint16{}, fmt.Errorf("unsupported aco swatch color: %T", c)
+}
+
+// WriteAco writes a Photoshop color swatch (.aco) file with a version 1
+// section followed by a version 2 section carrying the names
+// Parameters:
+//   w: destination for the binary ACO content
+//   swatches: swatches to write; colors other than RGB, HSV, CMYK and LAB
+//             are converted to RGB through their ToRgb method
+// Returns:
+//   error: write error, unsupported swatch color or more than 65535 swatches
+// Example:
+//   err := WriteAco(out, []AcoSwatch{{Name: "Red", Color: RGB{255,0,0}}})
+func WriteAco(w io.Writer, swatches []AcoSwatch) error {
+	if len(swatches) > math.MaxUint16 {
+		return fmt.Errorf("aco file has %d swatches, at most %d allowed", len(swatches), math.MaxUint16)
+	}
+	recs := make([][5]uint16, len(swatches))
+	for i, sw := range swatches {
+		rec, err := encodeAcoColor(sw.Color)
+		if err != nil {
+			return err
+		}
+		recs[i] = rec
+	}
+	var b bytes.Buffer
+	binary.Write(&b, binary.BigEndian, [2]uint16{1, uint16(len(swatches))})
+	for _, rec := range recs {
+		binary.Write(&b, binary.BigEndian, rec)
+	}
+	binary.Write(&b, binary.BigEndian, [2]uint16{2, uint16(len(swatches))})
+	for i, rec := range recs {
+		binary.Write(&b, binary.BigEndian, rec)
+		units := append(utf16.Encode([]rune(swatches[i].Name)), 0)
+		binary.Write(&b, binary.BigEndian, uint32(len(units)))
+		binary.Write(&b, binary.BigEndian, units)
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,84 @@
+package color
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// ActTable is a Photoshop color table (.act)
+// Transparent is the index of the transparent color, -1 if there is none.
+type ActTable struct {
+	Colors      []RGB
+	Transparent int
+}
+
+// ReadAct reads a Photoshop color table (.act) file
+// The 768-byte table of 256 RGB triples may be followed by a 4-byte
+// trailer with the number of used colors and the transparent index.
+// Parameters:
+//   r: reader with the binary ACT content
+// Returns:
+//   *ActTable: used colors and transparent index
+//   error: read error or short file
+// Example:
+//   f, _ := os.Open("web.act")
+//   table, err := ReadAct(f)
+func ReadAct(r io.Reader) (*ActTable, error) {
+	var data [772]byte
+	n, err := io.ReadFull(r, data[:])
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("invalid act file: %w", err)
+	}
+	if n != 768 && n != 772 {
+		return nil, fmt.Errorf("invalid act file size: %d", n)
+	}
+	t := &ActTable{Colors: make([]RGB, 256), Transparent: -1}
+	for i := range t.Colors {
+		t.Colors[i] = RGB{data[i*3], data[i*3+1], data[i*3+2]}
+	}
+	if n == 772 {
+		count := int(binary.BigEndian.Uint16(data[768:]))
+		if count > 0 && count <= 256 {
+			t.Colors = t.Colors[:count]
+		}
+		if idx := binary.BigEndian.Uint16(data[770:]); idx < 256 {
+			t.Transparent = int(idx)
+		}
+	}
+	return t, nil
+}
+
+// WriteAct writes a Photoshop color table (.act) file
+// The count and transparent index trailer is omitted only for a full
+// 256-color table without transparency.
+// Parameters:
+//   w: destination for the binary ACT content
+//   t: table with 1 to 256 colors
+// Returns:
+//   error: write error, empty table or too many colors
+// Example:
+//   err := WriteAct(out, &ActTable{Colors: []RGB{{0,0,0},{255,255,255}}, Transparent: -1})
+func WriteAct(w io.Writer, t *ActTable) error {
+	if len(t.Colors) > 256 {
+		return fmt.Errorf("act table has %d colors, at most 256 allowed", len(t.Colors))
+	}
+	// a zero count in the trailer means all 256 colors are used
+	if len(t.Colors) == 0 {
+		return fmt.Errorf("act table has no colors")
+	}
+	data := make([]byte, 768, 772)
+	for i, c := range t.Colors {
+		data[i*3], data[i*3+1], data[i*3+2] = c.R, c.G, c.B
+	}
+	if len(t.Colors) < 256 || t.Transparent >= 0 {
+		transparent := uint16(0xffff)
+		if t.Transparent >= 0 {
+			transparent = uint16(t.Transparent)
+		}
+		data = binary.BigEndian.AppendUint16(data, uint16(len(t.Colors)))
+		data = binary.BigEndian.AppendUint16(data, transparent)
+	}
+	_, err := w.Write(data)
+	return err
+}
@@ -0,0 +1,158 @@
+package color
+
+import "fmt"
+
+// AdaptationMethod selects the cone response space used by a
+// chromatic adaptation transform
+type AdaptationMethod int
+
+const (
+	XYZScaling AdaptationMethod = iota
+	VonKries
+	Bradford
+	CAT02
+	CAT16
+)
+
+// Standard illuminant white points (CIE 1931 2° observer, Y=1.0)
+var (
+	IlluminantA   = XYZ{1.09850, 1.0, 0.35585}
+	IlluminantC   = XYZ{0.98074, 1.0, 1.18232}
+	IlluminantD50 = XYZ{0.96422, 1.0, 0.82521}
+	IlluminantD55 = XYZ{0.95682, 1.0, 0.92149}
+	IlluminantD65 = XYZ{0.95047, 1.0, 1.08883}
+	IlluminantD75 = XYZ{0.94972, 1.0, 1.22638}
+	IlluminantF2  = XYZ{0.99187, 1.0, 0.67395}
+	IlluminantF7  = XYZ{0.95044, 1.0, 1.08755}
+	IlluminantF11 = XYZ{1.00966, 1.0, 0.64370}
+)
+
+var coneResponseMatrices = map[AdaptationMethod][3][3]float64{
+	XYZScaling: {
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	},
+	VonKries: {
+		{0.40024, 0.70760, -0.08081},
+		{-0.22630, 1.16532, 0.04570},
+		{0.0, 0.0, 0.91822},
+	},
+	Bradford: {
+		{0.8951, 0.2664, -0.1614},
+		{-0.7502, 1.7135, 0.0367},
+		{0.0389, -0.0685, 1.0296},
+	},
+	CAT02: {
+		{0.7328, 0.4296, -0.1624},
+		{-0.7036, 1.6975, 0.0061},
+		{0.0030, 0.0136, 0.9834},
+	},
+	CAT16: {
+		{0.401288, 0.650173, -0.051461},
+		{-0.250268, 1.204414, 0.045854},
+		{-0.002079, 0.048952, 0.953127},
+	},
+}
+
+// String returns the conventional name of the adaptation method
+func (m AdaptationMethod) String() string {
+	switch m {
+	case XYZScaling:
+		return "XYZ scaling"
+	case VonKries:
+		return "von Kries"
+	case Bradford:
+		return "Bradford"
+	case CAT02:
+		return "CAT02"
+	case CAT16:
+		return "CAT16"
+	}
+	return fmt.Sprintf("AdaptationMethod(%d)", int(m))
+}
+
+// IlluminantByName looks up a standard illuminant white point
+// Parameters:
+//   name: one of "A", "C", "D50", "D55", "D65", "D75", "F2", "F7", "F11"
+// Returns:
+//   XYZ: white point normalized to Y=1.0
+//   error: error if the name is unknown
+// Example:
+//   wp, err := IlluminantByName("D50") // returns IlluminantD50
+func IlluminantByName(name string) (XYZ, error) {
+	switch name {
+	case "A":
+		return IlluminantA, nil
+	case "C":
+		return IlluminantC, nil
+	case "D50":
+		return IlluminantD50, nil
+	case "D55":
+		return IlluminantD55, nil
+	case "D65":
+		return IlluminantD65, nil
+	case "D75":
+		return IlluminantD75, nil
+	case "F2":
+		return IlluminantF2, nil
+	case "F7":
+		return IlluminantF7, nil
+	case "F11":
+		return IlluminantF11, nil
+	}
+	return XYZ{}, fmt.Errorf("unknown illuminant: %s", name)
+}
+
+// WhitePointFromXy builds a custom white point from xy chromaticity coordinates
+// Parameters:
+//   x, y: CIE 1931 chromaticity coordinates of the white
+// Returns:
+//   XYZ: white point normalized to Y=1.0
+// Example:
+//   wp := WhitePointFromXy(0.3127, 0.3290) // approximately D65
+func WhitePointFromXy(x, y float64) XYZ {
+	return XYZ{X: x / y, Y: 1.0, Z: (1 - x - y) / y}
+}
+
+// AdaptationMatrix computes the 3x3 matrix that maps XYZ values seen under
+// the source white to corresponding colors under the destination white
+// Parameters:
+//   src: white point of the source illuminant
+//   dst: white point of the destination illuminant
+//   method: cone response space to adapt in
+// Returns:
+//   [3][3]float64: row-major matrix to apply to XYZ column vectors
+// Example:
+//   m := AdaptationMatrix(IlluminantD65, IlluminantD50, Bradford)
+//   // m[0] is approximately {1.0478, 0.0229, -0.0501}
+func AdaptationMatrix(src, dst XYZ, method AdaptationMethod) [3][3]float64 {
+	ma, ok := coneResponseMatrices[method]
+	if !ok {
+		ma = coneResponseMatrices[Bradford]
+	}
+	sr, sg, sb := mulMat3Vec(ma, src.X, src.Y, src.Z)
+	dr, dg, db := mulMat3Vec(ma, dst.X, dst.Y, dst.Z)
+	scale := [3][3]float64{
+		{dr / sr, 0, 0},
+		{0, dg / sg, 0},
+		{0, 0, db / sb},
+	}
+	return mulMat3(invertMat3(ma), mulMat3(scale, ma))
+}
+
+// Adapt applies a chromatic adaptation transform to the XYZ value
+// Parameters:
+//   src: white point the value was measured under
+//   dst: white point to adapt the value to
+//   method: cone response space to adapt in
+// Returns:
+//   XYZ: corresponding color under the destination white
+// Example:
+//   c := XYZ{0.96422, 1.0, 0.82521} // D50 white
+//   d65 := c.Adapt(IlluminantD50, IlluminantD65, Bradford) // returns IlluminantD65
+func (c *XYZ) Adapt(src, dst XYZ, method AdaptationMethod) XYZ {
+	m := AdaptationMatrix(src, dst, method)
+	x, y, z := mulMat3Vec(m, c.X, c.Y, c.Z)
+	return XYZ{X: x, Y: y, Z: z}
+}
@@ -0,0 +1,165 @@
+package color
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColorMode is the color capability of a terminal
+type ColorMode int
+
+const (
+	ColorModeNone ColorMode = iota
+	ColorMode16
+	ColorMode256
+	ColorModeTrueColor
+)
+
+// AnsiReset is the SGR sequence that restores default attributes
+const AnsiReset = "\x1b[0m"
+
+// ansi16 holds the xterm default colors of the 16 basic ANSI entries
+var ansi16 = [16]RGB{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// xterm256Lab caches the L*a*b* value of every xterm-256 entry for matching
+var xterm256Lab = func() [256][3]float64 {
+	var labs [256][3]float64
+	for i := range labs {
+		c := AnsiIndexToRgb(uint8(i))
+		labs[i][0], labs[i][1], labs[i][2] = rgbToLab(c.R, c.G, c.B)
+	}
+	return labs
+}()
+
+// DetectColorMode determines the terminal color capability from the environment
+// NO_COLOR disables colors, COLORTERM=truecolor/24bit enables 24-bit colors and
+// TERM decides between 256 and 16 colors.
+// Parameters:
+//   getenv: environment lookup, usually os.Getenv
+// Returns:
+//   ColorMode: detected color capability
+// Example:
+//   mode := DetectColorMode(os.Getenv)
+func DetectColorMode(getenv func(string) string) ColorMode {
+	if getenv("NO_COLOR") != "" {
+		return ColorModeNone
+	}
+	switch strings.ToLower(getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorModeTrueColor
+	}
+	term := strings.ToLower(getenv("TERM"))
+	switch {
+	case term == "" || term == "dumb":
+		return ColorModeNone
+	case strings.HasSuffix(term, "-direct"):
+		return ColorModeTrueColor
+	case strings.Contains(term, "256color"):
+		return ColorMode256
+	}
+	return ColorMode16
+}
+
+// AnsiIndexToRgb returns the xterm default color of a 256-color palette index
+// Parameters:
+//   i: palette index (0-15 basic, 16-231 color cube, 232-255 grayscale)
+// Returns:
+//   RGB: color of the palette entry
+// Example:
+//   c := AnsiIndexToRgb(196) // returns RGB{255,0,0}
+func AnsiIndexToRgb(i uint8) RGB {
+	switch {
+	case i < 16:
+		return ansi16[i]
+	case i < 232:
+		levels := [6]uint8{0, 95, 135, 175, 215, 255}
+		n := i - 16
+		return RGB{levels[n/36], levels[n/6%6], levels[n%6]}
+	default:
+		v := 8 + 10*(i-232)
+		return RGB{v, v, v}
+	}
+}
+
+// ToAnsi256 finds the perceptually nearest xterm-256 color cube or grayscale entry
+// The 16 basic entries are skipped because terminals theme them freely.
+// Returns:
+//   uint8: palette index in 16-255
+// Example:
+//   c := RGB{255,0,0}
+//   idx := c.ToAnsi256() // returns 196
+func (c *RGB) ToAnsi256() uint8 {
+	return nearestAnsi(c, 16, 256)
+}
+
+// ToAnsi16 finds the perceptually nearest of the 16 basic ANSI colors
+// Returns:
+//   uint8: palette index in 0-15
+// Example:
+//   c := RGB{250,10,10}
+//   idx := c.ToAnsi16() // returns 9 (bright red)
+func (c *RGB) ToAnsi16() uint8 {
+	return nearestAnsi(c, 0, 16)
+}
+
+// AnsiFg builds the SGR sequence that sets the foreground to the color
+// Parameters:
+//   mode: terminal color capability, the color is downsampled to fit it
+// Returns:
+//   string: escape sequence, empty for ColorModeNone
+// Example:
+//   c := RGB{255,128,0}
+//   seq := c.AnsiFg(ColorModeTrueColor) // returns "\x1b[38;2;255;128;0m"
+func (c *RGB) AnsiFg(mode ColorMode) string {
+	return c.ansiSgr(mode, 30)
+}
+
+// AnsiBg builds the SGR sequence that sets the background to the color
+// Parameters:
+//   mode: terminal color capability, the color is downsampled to fit it
+// Returns:
+//   string: escape sequence, empty for ColorModeNone
+// Example:
+//   c := RGB{0,0,255}
+//   seq := c.AnsiBg(ColorMode256) // returns "\x1b[48;5;21m"
+func (c *RGB) AnsiBg(mode ColorMode) string {
+	return c.ansiSgr(mode, 40)
+}
+
+// ansiSgr emits the sequence for a foreground (base 30) or background (base 40)
+func (c *RGB) ansiSgr(mode ColorMode, base int) string {
+	switch mode {
+	case ColorModeTrueColor:
+		return fmt.Sprintf("\x1b[%d;2;%d;%d;%dm", base+8, c.R, c.G, c.B)
+	case ColorMode256:
+		return fmt.Sprintf("\x1b[%d;5;%dm", base+8, c.ToAnsi256())
+	case ColorMode16:
+		i := int(c.ToAnsi16())
+		if i >= 8 {
+			return fmt.Sprintf("\x1b[%dm", base+60+i-8)
+		}
+		return fmt.Sprintf("\x1b[%dm", base+i)
+	}
+	return ""
+}
+
+// nearestAnsi returns the palette index in [from, to) closest to c in CIE76 ΔE
+func nearestAnsi(c *RGB, from, to int) uint8 {
+	l, a, b := rgbToLab(c.R, c.G, c.B)
+	best := from
+	bestDist := -1.0
+	for i := from; i < to; i++ {
+		lab := xterm256Lab[i]
+		dl, da, db := l-lab[0], a-lab[1], b-lab[2]
+		d := dl*dl + da*da + db*db
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return uint8(best)
+}
@@ -0,0 +1,505 @@
+package color
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// AnsiColorKind tells how an AnsiColor selects its color
+type AnsiColorKind int
+
+const (
+	AnsiColorDefault AnsiColorKind = iota
+	AnsiColorIndexed
+	AnsiColorRGB
+)
+
+// AnsiColor is a terminal color as written in an SGR sequence, before it is
+// resolved through a theme
+type AnsiColor struct {
+	Kind  AnsiColorKind
+	Index uint8
+	RGB   RGB
+}
+
+// AnsiStyle is the set of SGR attributes in effect for a run of text
+type AnsiStyle struct {
+	Fg        AnsiColor
+	Bg        AnsiColor
+	Bold      bool
+	Faint     bool
+	Italic    bool
+	Underline bool
+	Blink     bool
+	Inverse   bool
+	Strike    bool
+}
+
+// AnsiSegment is a run of text printed with a single style
+type AnsiSegment struct {
+	Text  string
+	Style AnsiStyle
+}
+
+// AnsiTheme resolves the 16 basic ANSI colors and the default colors to RGB
+type AnsiTheme struct {
+	Palette    [16]RGB
+	Foreground RGB
+	Background RGB
+}
+
+// DefaultAnsiTheme uses the xterm default colors on a black background
+var DefaultAnsiTheme = AnsiTheme{
+	Palette:    ansi16,
+	Foreground: RGB{229, 229, 229},
+	Background: RGB{0, 0, 0},
+}
+
+// ApplySgr updates the style with the parameters of an SGR sequence
+// Parameters:
+//   params: parameter string between "\x1b[" and "m", e.g. "1;38;5;208"
+// Example:
+//   var s AnsiStyle
+//   s.ApplySgr("1;31") // bold red foreground
+func (s *AnsiStyle) ApplySgr(params string) {
+	if params == "" {
+		*s = AnsiStyle{}
+		return
+	}
+	parts := strings.Split(params, ";")
+	for i := 0; i < len(parts); i++ {
+		if strings.Contains(parts[i], ":") {
+			s.applyExtendedColor(strings.Split(parts[i], ":"))
+			continue
+		}
+		code, err := strconv.Atoi(parts[i])
+		if err != nil {
+			if parts[i] != "" {
+				continue
+			}
+			code = 0
+		}
+		switch {
+		case code == 0:
+			*s = AnsiStyle{}
+		case code == 1:
+			s.Bold = true
+		case code == 2:
+			s.Faint = true
+		case code == 3:
+			s.Italic = true
+		case code == 4:
+			s.Underline = true
+		case code == 5:
+			s.Blink = true
+		case code == 7:
+			s.Inverse = true
+		case code == 9:
+			s.Strike = true
+		case code == 22:
+			s.Bold, s.Faint = false, false
+		case code == 23:
+			s.Italic = false
+		case code == 24:
+			s.Underline = false
+		case code == 25:
+			s.Blink = false
+		case code == 27:
+			s.Inverse = false
+		case code == 29:
+			s.Strike = false
+		case code >= 30 && code <= 37:
+			s.Fg = AnsiColor{Kind: AnsiColorIndexed, Index: uint8(code - 30)}
+		case code == 39:
+			s.Fg = AnsiColor{}
+		case code >= 40 && code <= 47:
+			s.Bg = AnsiColor{Kind: AnsiColorIndexed, Index: uint8(code - 40)}
+		case code == 49:
+			s.Bg = AnsiColor{}
+		case code >= 90 && code <= 97:
+			s.Fg = AnsiColor{Kind: AnsiColorIndexed, Index: uint8(code - 90 + 8)}
+		case code >= 100 && code <= 107:
+			s.Bg = AnsiColor{Kind: AnsiColorIndexed, Index: uint8(code - 100 + 8)}
+		case code == 38 || code == 48:
+			c, n := parseSgrColor(parts[i+1:])
+			i += n
+			if n == 0 {
+				continue
+			}
+			if code == 38 {
+				s.Fg = c
+			} else {
+				s.Bg = c
+			}
+		}
+	}
+}
+
+// Sgr formats the style as SGR parameters, the inverse of ApplySgr
+// Returns:
+//   string: parameter string such as "1;38;5;208", empty for the default style
+// Example:
+//   s := AnsiStyle{Fg: AnsiColor{Kind: AnsiColorIndexed, Index: 4}, Bold: true}
+//   params := s.Sgr() // returns "1;34"
+func (s *AnsiStyle) Sgr() string {
+	var codes []string
+	for _, attr := range []struct {
+		on   bool
+		code string
+	}{
+		{s.Bold, "1"}, {s.Faint, "2"}, {s.Italic, "3"}, {s.Underline, "4"},
+		{s.Blink, "5"}, {s.Inverse, "7"}, {s.Strike, "9"},
+	} {
+		if attr.on {
+			codes = append(codes, attr.code)
+		}
+	}
+	if c := s.Fg.sgr(30); c != "" {
+		codes = append(codes, c)
+	}
+	if c := s.Bg.sgr(40); c != "" {
+		codes = append(codes, c)
+	}
+	return strings.Join(codes, ";")
+}
+
+// sgr formats the color as a foreground (base 30) or background (base 40) parameter
+func (c AnsiColor) sgr(base int) string {
+	switch c.Kind {
+	case AnsiColorIndexed:
+		switch {
+		case c.Index < 8:
+			return strconv.Itoa(base + int(c.Index))
+		case c.Index < 16:
+			return strconv.Itoa(base + 60 + int(c.Index) - 8)
+		}
+		return fmt.Sprintf("%d;5;%d", base+8, c.Index)
+	case AnsiColorRGB:
+		return fmt.Sprintf("%d;2;%d;%d;%d", base+8, c.RGB.R, c.RGB.G, c.RGB.B)
+	}
+	return ""
+}
+
+// applyExtendedColor handles the colon form "38:5:n" and "38:2:[id]:r:g:b"
+func (s *AnsiStyle) applyExtendedColor(subs []string) {
+	if len(subs) < 2 || (subs[0] != "38" && subs[0] != "48") {
+		return
+	}
+	if subs[1] == "2" && len(subs) > 5 {
+		// drop the optional color space id
+		subs = append(subs[:2], subs[len(subs)-3:]...)
+	}
+	c, n := parseSgrColor(subs[1:])
+	if n == 0 {
+		return
+	}
+	if subs[0] == "38" {
+		s.Fg = c
+	} else {
+		s.Bg = c
+	}
+}
+
+// parseSgrColor parses the "5;n" or "2;r;g;b" tail of an extended color and
+// returns the number of parameters it consumed, 0 if malformed
+func parseSgrColor(parts []string) (AnsiColor, int) {
+	if len(parts) == 0 {
+		return AnsiColor{}, 0
+	}
+	nums := make([]uint8, 0, 3)
+	for _, p := range parts[1:] {
+		if len(nums) == 3 {
+			break
+		}
+		v, err := strconv.ParseUint(p, 10, 8)
+		if err != nil {
+			break
+		}
+		nums = append(nums, uint8(v))
+	}
+	switch parts[0] {
+	case "5":
+		if len(nums) >= 1 {
+			return AnsiColor{Kind: AnsiColorIndexed, Index: nums[0]}, 2
+		}
+	case "2":
+		if len(nums) == 3 {
+			return AnsiColor{Kind: AnsiColorRGB, RGB: RGB{nums[0], nums[1], nums[2]}}, 4
+		}
+	}
+	return AnsiColor{}, 0
+}
+
+// Resolve converts an AnsiColor to RGB through the theme
+// Parameters:
+//   c: color to resolve
+//   fallback: color used when c is the terminal default
+// Returns:
+//   RGB: resolved color
+// Example:
+//   rgb := DefaultAnsiTheme.Resolve(AnsiColor{Kind: AnsiColorIndexed, Index: 1}, RGB{}) // returns RGB{205,0,0}
+func (t *AnsiTheme) Resolve(c AnsiColor, fallback RGB) RGB {
+	switch c.Kind {
+	case AnsiColorIndexed:
+		if c.Index < 16 {
+			return t.Palette[c.Index]
+		}
+		return AnsiIndexToRgb(c.Index)
+	case AnsiColorRGB:
+		return c.RGB
+	}
+	return fallback
+}
+
+// colors returns the foreground and background of a style after applying inverse
+func (t *AnsiTheme) colors(s AnsiStyle) (RGB, RGB) {
+	fg := t.Resolve(s.Fg, t.Foreground)
+	bg := t.Resolve(s.Bg, t.Background)
+	if s.Inverse {
+		fg, bg = bg, fg
+	}
+	return fg, bg
+}
+
+// ParseAnsi splits text containing escape sequences into styled segments
+// SGR sequences update the style; other CSI and OSC sequences are dropped.
+// Parameters:
+//   r: reader with the raw terminal output
+// Returns:
+//   []AnsiSegment: text runs with their style
+//   error: read error from r
+// Example:
+//   segs, err := ParseAnsi(strings.NewReader("\x1b[31merror\x1b[0m ok"))
+func ParseAnsi(r io.Reader) ([]AnsiSegment, error) {
+	br := bufio.NewReader(r)
+	var segs []AnsiSegment
+	var style AnsiStyle
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			segs = append(segs, AnsiSegment{Text: text.String(), Style: style})
+			text.Reset()
+		}
+	}
+	for {
+		ch, _, err := br.ReadRune()
+		if errors.Is(err, io.EOF) {
+			flush()
+			return segs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch ch {
+		case '\r':
+			continue
+		case '\x1b':
+		default:
+			text.WriteRune(ch)
+			continue
+		}
+		next, err := br.ReadByte()
+		if err != nil {
+			continue
+		}
+		switch next {
+		case '[':
+			params, final, err := readCsi(br)
+			if err != nil {
+				continue
+			}
+			if final == 'm' {
+				flush()
+				style.ApplySgr(params)
+			}
+		case ']':
+			skipOsc(br)
+		}
+	}
+}
+
+// readCsi reads a CSI sequence up to and including its final byte
+func readCsi(br *bufio.Reader) (string, byte, error) {
+	var params strings.Builder
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return "", 0, err
+		}
+		if b >= 0x40 && b <= 0x7e {
+			return params.String(), b, nil
+		}
+		params.WriteByte(b)
+	}
+}
+
+// skipOsc discards an OSC sequence terminated by BEL or ST
+func skipOsc(br *bufio.Reader) {
+	for {
+		b, err := br.ReadByte()
+		if err != nil || b == '\a' {
+			return
+		}
+		if b == '\x1b' {
+			br.ReadByte()
+			return
+		}
+	}
+}
+
+// AnsiToHTML renders terminal output as HTML span elements with inline styles
+// Text in the default style is written without a span, so the output is
+// meant to be embedded in a <pre> element.
+// Parameters:
+//   w: destination for the HTML fragment
+//   r: reader with the raw terminal output
+//   theme: color theme, nil for DefaultAnsiTheme
+// Returns:
+//   error: read or write error
+// Example:
+//   err := AnsiToHTML(&buf, strings.NewReader("\x1b[1;32mok\x1b[0m"), nil)
+//   // writes <span style="color:#00cd00;font-weight:bold">ok</span>
+func AnsiToHTML(w io.Writer, r io.Reader, theme *AnsiTheme) error {
+	if theme == nil {
+		theme = &DefaultAnsiTheme
+	}
+	segs, err := ParseAnsi(r)
+	if err != nil {
+		return err
+	}
+	for _, seg := range segs {
+		text := html.EscapeString(seg.Text)
+		if seg.Style == (AnsiStyle{}) {
+			if _, err := io.WriteString(w, text); err != nil {
+				return err
+			}
+			continue
+		}
+		var css []string
+		fg, bg := theme.colors(seg.Style)
+		if seg.Style.Fg.Kind != AnsiColorDefault || seg.Style.Inverse {
+			css = append(css, "color:"+fg.ToHex())
+		}
+		if seg.Style.Bg.Kind != AnsiColorDefault || seg.Style.Inverse {
+			css = append(css, "background-color:"+bg.ToHex())
+		}
+		if seg.Style.Bold {
+			css = append(css, "font-weight:bold")
+		}
+		if seg.Style.Faint {
+			css = append(css, "opacity:0.5")
+		}
+		if seg.Style.Italic {
+			css = append(css, "font-style:italic")
+		}
+		if seg.Style.Underline || seg.Style.Strike {
+			var deco []string
+			if seg.Style.Underline {
+				deco = append(deco, "underline")
+			}
+			if seg.Style.Strike {
+				deco = append(deco, "line-through")
+			}
+			css = append(css, "text-decoration:"+strings.Join(deco, " "))
+		}
+		if _, err := fmt.Fprintf(w, `<span style="%s">%s</span>`, strings.Join(css, ";"), text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AnsiToSVG renders terminal output as a standalone SVG document
+// Each character cell is 0.6em wide and each line 1.2em high in a monospace font.
+// Parameters:
+//   w: destination for the SVG document
+//   r: reader with the raw terminal output
+//   theme: color theme, nil for DefaultAnsiTheme
+//   fontSize: font size in pixels
+// Returns:
+//   error: read or write error
+// Example:
+//   err := AnsiToSVG(&buf, strings.NewReader("\x1b[31mfail\x1b[0m"), nil, 14)
+func AnsiToSVG(w io.Writer, r io.Reader, theme *AnsiTheme, fontSize float64) error {
+	if theme == nil {
+		theme = &DefaultAnsiTheme
+	}
+	segs, err := ParseAnsi(r)
+	if err != nil {
+		return err
+	}
+
+	// split segments into lines
+	lines := [][]AnsiSegment{nil}
+	for _, seg := range segs {
+		for i, part := range strings.Split(seg.Text, "\n") {
+			if i > 0 {
+				lines = append(lines, nil)
+			}
+			if part != "" {
+				lines[len(lines)-1] = append(lines[len(lines)-1], AnsiSegment{part, seg.Style})
+			}
+		}
+	}
+	if len(lines) > 1 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	cols := 0
+	for _, line := range lines {
+		n := 0
+		for _, seg := range line {
+			n += len([]rune(seg.Text))
+		}
+		cols = max(cols, n)
+	}
+
+	cw := fontSize * 0.6
+	lh := fontSize * 1.2
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" font-family="monospace" font-size="%g">`+"\n",
+		float64(cols)*cw, float64(len(lines))*lh, fontSize)
+	fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", theme.Background.ToHex())
+	for row, line := range lines {
+		col := 0
+		y := float64(row) * lh
+		for _, seg := range line {
+			n := len([]rune(seg.Text))
+			if _, bg := theme.colors(seg.Style); seg.Style.Bg.Kind != AnsiColorDefault || seg.Style.Inverse {
+				fmt.Fprintf(&b, `<rect x="%g" y="%g" width="%g" height="%g" fill="%s"/>`+"\n",
+					float64(col)*cw, y, float64(n)*cw, lh, bg.ToHex())
+			}
+			col += n
+		}
+		fmt.Fprintf(&b, `<text y="%g" xml:space="preserve">`, y+fontSize)
+		col = 0
+		for _, seg := range line {
+			fg, _ := theme.colors(seg.Style)
+			fmt.Fprintf(&b, `<tspan x="%g" fill="%s"`, float64(col)*cw, fg.ToHex())
+			if seg.Style.Bold {
+				b.WriteString(` font-weight="bold"`)
+			}
+			if seg.Style.Faint {
+				b.WriteString(` fill-opacity="0.5"`)
+			}
+			if seg.Style.Italic {
+				b.WriteString(` font-style="italic"`)
+			}
+			if seg.Style.Underline {
+				b.WriteString(` text-decoration="underline"`)
+			} else if seg.Style.Strike {
+				b.WriteString(` text-decoration="line-through"`)
+			}
+			fmt.Fprintf(&b, `>%s</tspan>`, html.EscapeString(seg.Text))
+			col += len([]rune(seg.Text))
+		}
+		b.WriteString("</text>\n")
+	}
+	b.WriteString("</svg>\n")
+	_, err = io.WriteString(w, b.String())
+	return err
+}
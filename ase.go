@@ -0,0 +1,309 @@
+package color
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"unicode/utf16"
+)
+
+// AseColorType is the swatch type stored with each ASE color entry
+type AseColorType uint16
+
+const (
+	AseGlobal  AseColorType = 0
+	AseSpot    AseColorType = 1
+	AseProcess AseColorType = 2
+)
+
+// ASE block types
+const (
+	aseGroupStart uint16 = 0xc001
+	aseGroupEnd   uint16 = 0xc002
+	aseColorEntry uint16 = 0x0001
+)
+
+// AseGray is the value of a Gray swatch, 0 for black and 1 for white
+type AseGray float64
+
+// ToRgb converts the gray level to RGB
+// Returns:
+//   RGB: neutral color with equal channels
+// Example:
+//   g := AseGray(0.5)
+//   rgb := g.ToRgb() // returns RGB{128,128,128}
+func (g AseGray) ToRgb() RGB {
+	v := unitToUint8(float64(g))
+	return RGB{v, v, v}
+}
+
+// AseSwatch is a named color of an Adobe Swatch Exchange file
+// Color holds an RGB, CMYK, LAB or AseGray value.
+type AseSwatch struct {
+	Name  string
+	Type  AseColorType
+	Color any
+}
+
+// AseGroup is a named group of swatches
+type AseGroup struct {
+	Name     string
+	Swatches []AseSwatch
+}
+
+// AseFile is the content of an .ase file: swatches outside any group
+// followed by the groups
+type AseFile struct {
+	Swatches []AseSwatch
+	Groups   []AseGroup
+}
+
+// ToRgb converts the swatch color to RGB
+// Returns:
+//   RGB: swatch color, black if Color holds an unsupported type
+func (s *AseSwatch) ToRgb() RGB {
+	switch c := s.Color.(type) {
+	case RGB:
+		return c
+	case CMYK:
+		return c.ToRgb()
+	case LAB:
+		return c.ToRgb()
+	case AseGray:
+		return c.ToRgb()
+	}
+	return RGB{}
+}
+
+// ReadAse reads an Adobe Swatch Exchange (.ase) file
+// Parameters:
+//   r: reader with the binary ASE content
+// Returns:
+//   *AseFile: swatches and groups in file order
+//   error: read error or malformed file
+// Example:
+//   f, _ := os.Open("brand.ase")
+//   ase, err := ReadAse(f)
+func ReadAse(r io.Reader) (*AseFile, error) {
+	var header struct {
+		Magic  [4]byte
+		Major  uint16
+		Minor  uint16
+		Blocks uint32
+	}
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		return nil, fmt.Errorf("invalid ase header: %w", err)
+	}
+	if string(header.Magic[:]) != "ASEF" {
+		return nil, fmt.Errorf("invalid ase signature: %q", header.Magic[:])
+	}
+	if header.Major != 1 {
+		return nil, fmt.Errorf("unsupported ase version: %d.%d", header.Major, header.Minor)
+	}
+
+	f := &AseFile{}
+	var group *AseGroup
+	for i := uint32(0); i < header.Blocks; i++ {
+		var block struct {
+			Type   uint16
+			Length uint32
+		}
+		if err := binary.Read(r, binary.BigEndian, &block); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("invalid ase block: %w", err)
+		}
+		data, err := readBlock(r, int64(block.Length))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ase block: %w", err)
+		}
+		br := bytes.NewReader(data)
+		switch block.Type {
+		case aseGroupStart:
+			name, err := readAseString(br)
+			if err != nil {
+				return nil, err
+			}
+			f.Groups = append(f.Groups, AseGroup{Name: name})
+			group = &f.Groups[len(f.Groups)-1]
+		case aseGroupEnd:
+			group = nil
+		case aseColorEntry:
+			sw, err := readAseSwatch(br)
+			if err != nil {
+				return nil, err
+			}
+			if group != nil {
+				group.Swatches = append(group.Swatches, sw)
+			} else {
+				f.Swatches = append(f.Swatches, sw)
+			}
+		}
+	}
+	return f, nil
+}
+
+// readBlock reads n bytes, growing the buffer as data arrives so that a
+// corrupt length field cannot force a large allocation
+func readBlock(r io.Reader, n int64) ([]byte, error) {
+	var b bytes.Buffer
+	if _, err := io.CopyN(&b, r, n); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// readAseString reads a length prefixed, NUL terminated UTF-16BE string
+func readAseString(r io.Reader) (string, error) {
+	var n uint16
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return "", fmt.Errorf("invalid ase name: %w", err)
+	}
+	units := make([]uint16, n)
+	if err := binary.Read(r, binary.BigEndian, units); err != nil {
+		return "", fmt.Errorf("invalid ase name: %w", err)
+	}
+	if n > 0 && units[n-1] == 0 {
+		units = units[:n-1]
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+func readAseSwatch(r io.Reader) (AseSwatch, error) {
+	name, err := readAseString(r)
+	if err != nil {
+		return AseSwatch{}, err
+	}
+	var model [4]byte
+	if _, err := io.ReadFull(r, model[:]); err != nil {
+		return AseSwatch{}, fmt.Errorf("invalid ase color model: %w", err)
+	}
+	counts := map[string]int{"RGB ": 3, "CMYK": 4, "LAB ": 3, "Gray": 1}
+	n, ok := counts[string(model[:])]
+	if !ok {
+		return AseSwatch{}, fmt.Errorf("unsupported ase color model: %q", model[:])
+	}
+	v := make([]float32, n)
+	if err := binary.Read(r, binary.BigEndian, v); err != nil {
+		return AseSwatch{}, fmt.Errorf("invalid ase color values: %w", err)
+	}
+	var typ uint16
+	if err := binary.Read(r, binary.BigEndian, &typ); err != nil {
+		return AseSwatch{}, fmt.Errorf("invalid ase color type: %w", err)
+	}
+
+	sw := AseSwatch{Name: name, Type: AseColorType(typ)}
+	percent := func(f float32) uint8 {
+		return uint8(math.Round(math.Max(0, math.Min(1, float64(f))) * 100))
+	}
+	switch string(model[:]) {
+	case "RGB ":
+		sw.Color = RGB{unitToUint8(float64(v[0])), unitToUint8(float64(v[1])), unitToUint8(float64(v[2]))}
+	case "CMYK":
+		sw.Color = CMYK{percent(v[0]), percent(v[1]), percent(v[2]), percent(v[3])}
+	case "LAB ":
+		sw.Color = LAB{L: float64(v[0]) * 100, A: float64(v[1]), B: float64(v[2])}
+	case "Gray":
+		sw.Color = AseGray(v[0])
+	}
+	return sw, nil
+}
+
+// WriteAse writes an Adobe Swatch Exchange (.ase) file
+// Swatch colors other than RGB, CMYK, LAB and AseGray are converted to RGB through
+// their ToRgb method.
+// Parameters:
+//   w: destination for the binary ASE content
+//   f: swatches and groups to write
+// Returns:
+//   error: write error or swatch color without a ToRgb method
+// Example:
+//   err := WriteAse(out, &AseFile{Swatches: []AseSwatch{{Name: "Red", Type: AseGlobal, Color: RGB{255,0,0}}}})
+func WriteAse(w io.Writer, f *AseFile) error {
+	var body bytes.Buffer
+	blocks := uint32(0)
+	writeBlock := func(typ uint16, data []byte) {
+		binary.Write(&body, binary.BigEndian, typ)
+		binary.Write(&body, binary.BigEndian, uint32(len(data)))
+		body.Write(data)
+		blocks++
+	}
+	writeSwatches := func(swatches []AseSwatch) error {
+		for _, sw := range swatches {
+			data, err := encodeAseSwatch(sw)
+			if err != nil {
+				return err
+			}
+			writeBlock(aseColorEntry, data)
+		}
+		return nil
+	}
+
+	if err := writeSwatches(f.Swatches); err != nil {
+		return err
+	}
+	for _, g := range f.Groups {
+		var name bytes.Buffer
+		writeAseString(&name, g.Name)
+		writeBlock(aseGroupStart, name.Bytes())
+		if err := writeSwatches(g.Swatches); err != nil {
+			return err
+		}
+		writeBlock(aseGroupEnd, nil)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("ASEF")
+	binary.Write(&out, binary.BigEndian, [2]uint16{1, 0})
+	binary.Write(&out, binary.BigEndian, blocks)
+	out.Write(body.Bytes())
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+func writeAseString(b *bytes.Buffer, s string) {
+	units := append(utf16.Encode([]rune(s)), 0)
+	binary.Write(b, binary.BigEndian, uint16(len(units)))
+	binary.Write(b, binary.BigEndian, units)
+}
+
+func encodeAseSwatch(sw AseSwatch) ([]byte, error) {
+	var b bytes.Buffer
+	writeAseString(&b, sw.Name)
+	switch c := sw.Color.(type) {
+	case CMYK:
+		b.WriteString("CMYK")
+		binary.Write(&b, binary.BigEndian, [4]float32{
+			float32(c.C) / 100, float32(c.M) / 100, float32(c.Y) / 100, float32(c.K) / 100,
+		})
+	case LAB:
+		b.WriteString("LAB ")
+		binary.Write(&b, binary.BigEndian, [3]float32{float32(c.L / 100), float32(c.A), float32(c.B)})
+	case AseGray:
+		b.WriteString("Gray")
+		binary.Write(&b, binary.BigEndian, float32(c))
+	default:
+		var rgb RGB
+		switch c := sw.Color.(type) {
+		case RGB:
+			rgb = c
+		case ToRgb:
+			rgb = c.ToRgb()
+		default:
+			return nil, fmt.Errorf("unsupported ase swatch color: %T", sw.Color)
+		}
+		b.WriteString("RGB ")
+		binary.Write(&b, binary.BigEndian, [3]float32{
+			float32(rgb.R) / 255, float32(rgb.G) / 255, float32(rgb.B) / 255,
+		})
+	}
+	binary.Write(&b, binary.BigEndian, uint16(sw.Type))
+	return b.Bytes(), nil
+}
@@ -0,0 +1,302 @@
+package color
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Base16Scheme is a base16 or base24 color scheme
+// Colors[0x00] to Colors[0x0F] hold base00-base0F; base24 schemes also
+// fill Colors[0x10] to Colors[0x17] with base10-base17.
+type Base16Scheme struct {
+	System  string // "base16" or "base24"
+	Name    string
+	Author  string
+	Slug    string
+	Variant string // "dark" or "light", may be empty
+	Colors  [24]RGB
+}
+
+// ReadBase16Scheme reads a base16 or base24 YAML scheme file
+// Both the legacy flat layout ("scheme:", "base00: ...") and the current
+// layout with "system:" and a nested "palette:" map are accepted.
+// Parameters:
+//   r: reader with the YAML content
+// Returns:
+//   *Base16Scheme: parsed scheme
+//   error: read error, invalid color or missing base00-base0F entry
+// Example:
+//   f, _ := os.Open("tomorrow-night.yaml")
+//   scheme, err := ReadBase16Scheme(f)
+func ReadBase16Scheme(r io.Reader) (*Base16Scheme, error) {
+	s := &Base16Scheme{}
+	var seen [24]bool
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || line == "---" {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		switch key {
+		case "system":
+			s.System = value
+		case "scheme", "name":
+			s.Name = value
+		case "author":
+			s.Author = value
+		case "slug":
+			s.Slug = value
+		case "variant":
+			s.Variant = value
+		default:
+			if len(key) != 6 || !strings.HasPrefix(key, "base") {
+				continue
+			}
+			i, err := strconv.ParseUint(key[4:], 16, 8)
+			if err != nil || i >= 24 {
+				continue
+			}
+			r, g, b, _, err := hexToRGBA(value)
+			if err != nil || len(strings.TrimPrefix(value, "#")) != 6 {
+				return nil, fmt.Errorf("invalid %s color: %s", key, value)
+			}
+			s.Colors[i] = RGB{r, g, b}
+			seen[i] = true
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	for i := 0; i < 16; i++ {
+		if !seen[i] {
+			return nil, fmt.Errorf("missing base%02X color", i)
+		}
+	}
+	if s.System == "" {
+		s.System = "base16"
+		if seen[0x10] {
+			s.System = "base24"
+		}
+	}
+	if s.Slug == "" {
+		s.Slug = slugify(s.Name)
+	}
+	return s, nil
+}
+
+// WriteBase16Scheme writes the scheme in the current YAML layout
+// Parameters:
+//   w: destination for the YAML content
+//   s: scheme to write
+// Returns:
+//   error: write error
+func WriteBase16Scheme(w io.Writer, s *Base16Scheme) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "system: %q\nname: %q\nauthor: %q\n", s.system(), s.Name, s.Author)
+	if s.Slug != "" {
+		fmt.Fprintf(&b, "slug: %q\n", s.Slug)
+	}
+	if s.Variant != "" {
+		fmt.Fprintf(&b, "variant: %q\n", s.Variant)
+	}
+	b.WriteString("palette:\n")
+	for i := 0; i < s.size(); i++ {
+		fmt.Fprintf(&b, "  base%02X: %q\n", i, s.Colors[i].ToHex())
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// system returns the scheme system, defaulting to base16
+func (s *Base16Scheme) system() string {
+	if s.System == "" {
+		return "base16"
+	}
+	return s.System
+}
+
+// size returns the number of colors of the scheme system
+func (s *Base16Scheme) size() int {
+	if s.system() == "base24" {
+		return 24
+	}
+	return 16
+}
+
+// TemplateVars returns the standard base16 template variables of the scheme
+// Every color baseXX provides baseXX-hex, baseXX-hex-r/g/b, baseXX-hex-bgr,
+// baseXX-rgb-r/g/b (0-255), baseXX-rgb16-r/g/b (0-65535) and
+// baseXX-dec-r/g/b (0-1).
+// Returns:
+//   map[string]string: variable name to value
+// Example:
+//   vars := scheme.TemplateVars()
+//   vars["base00-hex"] // "1d1f21"
+func (s *Base16Scheme) TemplateVars() map[string]string {
+	vars := map[string]string{
+		"scheme-name":             s.Name,
+		"scheme-author":           s.Author,
+		"scheme-slug":             s.Slug,
+		"scheme-slug-underscored": strings.ReplaceAll(s.Slug, "-", "_"),
+		"scheme-system":           s.system(),
+		"scheme-variant":          s.Variant,
+	}
+	if s.Variant == "light" {
+		vars["scheme-is-light-variant"] = "true"
+	}
+	if s.Variant == "dark" {
+		vars["scheme-is-dark-variant"] = "true"
+	}
+	for i := 0; i < s.size(); i++ {
+		c := s.Colors[i]
+		p := fmt.Sprintf("base%02X-", i)
+		vars[p+"hex"] = fmt.Sprintf("%02x%02x%02x", c.R, c.G, c.B)
+		vars[p+"hex-bgr"] = fmt.Sprintf("%02x%02x%02x", c.B, c.G, c.R)
+		for j, ch := range [3]uint8{c.R, c.G, c.B} {
+			n := string("rgb"[j])
+			vars[p+"hex-"+n] = fmt.Sprintf("%02x", ch)
+			vars[p+"rgb-"+n] = strconv.Itoa(int(ch))
+			vars[p+"rgb16-"+n] = strconv.Itoa(int(ch) * 257)
+			vars[p+"dec-"+n] = strconv.FormatFloat(float64(ch)/255, 'f', 8, 64)
+		}
+	}
+	return vars
+}
+
+// RenderBase16Template renders a mustache-style base16 template for the scheme
+// Supported tags are {{var}} (HTML escaped), {{{var}}} and {{&var}} (raw),
+// sections {{#var}}...{{/var}}, inverted sections {{^var}}...{{/var}} and
+// comments {{!...}}. A section is shown when its variable is non-empty.
+// Parameters:
+//   w: destination for the rendered output
+//   tmpl: template text
+//   s: scheme providing the variables
+// Returns:
+//   error: template syntax error or write error
+// Example:
+//   err := RenderBase16Template(os.Stdout, "bg = #{{base00-hex}}\n", scheme)
+func RenderBase16Template(w io.Writer, tmpl string, s *Base16Scheme) error {
+	out, err := renderMustache(tmpl, s.TemplateVars())
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, out)
+	return err
+}
+
+// renderMustache expands the mustache subset used by base16 templates
+func renderMustache(tmpl string, vars map[string]string) (string, error) {
+	var b strings.Builder
+	for {
+		start := strings.Index(tmpl, "{{")
+		if start < 0 {
+			b.WriteString(tmpl)
+			return b.String(), nil
+		}
+		b.WriteString(tmpl[:start])
+		tmpl = tmpl[start+2:]
+
+		closer := "}}"
+		if strings.HasPrefix(tmpl, "{") {
+			closer = "}}}"
+		}
+		end := strings.Index(tmpl, closer)
+		if end < 0 {
+			return "", fmt.Errorf("unclosed template tag")
+		}
+		tag := tmpl[:end]
+		tmpl = tmpl[end+len(closer):]
+
+		switch {
+		case closer == "}}}":
+			b.WriteString(vars[strings.TrimSpace(tag[1:])])
+		case strings.HasPrefix(tag, "!"):
+		case strings.HasPrefix(tag, "&"):
+			b.WriteString(vars[strings.TrimSpace(tag[1:])])
+		case strings.HasPrefix(tag, "#"), strings.HasPrefix(tag, "^"):
+			name := strings.TrimSpace(tag[1:])
+			body, rest, err := splitMustacheSection(tmpl, name)
+			if err != nil {
+				return "", err
+			}
+			tmpl = rest
+			value := vars[name]
+			show := value != "" && value != "false"
+			if tag[0] == '^' {
+				show = !show
+			}
+			if show {
+				inner, err := renderMustache(body, vars)
+				if err != nil {
+					return "", err
+				}
+				b.WriteString(inner)
+			}
+		case strings.HasPrefix(tag, "/"):
+			return "", fmt.Errorf("unexpected section end: %s", tag)
+		default:
+			b.WriteString(html.EscapeString(vars[strings.TrimSpace(tag)]))
+		}
+	}
+}
+
+// splitMustacheSection finds the matching {{/name}} of a section, allowing
+// nested sections of the same name
+func splitMustacheSection(tmpl, name string) (string, string, error) {
+	depth := 1
+	pos := 0
+	for {
+		i := strings.Index(tmpl[pos:], "{{")
+		if i < 0 {
+			return "", "", fmt.Errorf("unclosed section: %s", name)
+		}
+		i += pos
+		j := strings.Index(tmpl[i:], "}}")
+		if j < 0 {
+			return "", "", fmt.Errorf("unclosed template tag")
+		}
+		tag := strings.TrimSpace(tmpl[i+2 : i+j])
+		switch tag {
+		case "#" + name, "^" + name:
+			depth++
+		case "/" + name:
+			depth--
+			if depth == 0 {
+				return tmpl[:i], tmpl[i+j+2:], nil
+			}
+		}
+		pos = i + j + 2
+	}
+}
+
+// slugify lowercases a name and joins its alphanumeric words with dashes
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
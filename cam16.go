@@ -0,0 +1,304 @@
+package color
+
+import (
+	"fmt"
+	"math"
+)
+
+// ViewingConditions describes the environment a color is seen in, as used
+// by the CAM16 color appearance model. Create it with NewViewingConditions.
+type ViewingConditions struct {
+	n, aw, nbb, ncb, c, nc float64
+	rgbD                   [3]float64
+	fl, flRoot, z          float64
+}
+
+// Surround values for NewViewingConditions; intermediate values blend
+// between them
+const (
+	SurroundDark    = 0.0 // e.g. a projector in a dark room
+	SurroundDim     = 1.0 // e.g. a screen in a dim room
+	SurroundAverage = 2.0 // e.g. a print or a screen in daylight
+)
+
+// XYZ (D65, Y=100) to the CAM16 sharpened cone space
+var (
+	xyzToCam16Matrix = [3][3]float64{
+		{0.401288, 0.650173, -0.051461},
+		{-0.250268, 1.204414, 0.045854},
+		{-0.002079, 0.048952, 0.953127},
+	}
+	cam16ToXyzMatrix = invertMat3(xyzToCam16Matrix)
+)
+
+// DefaultViewingConditions matches sRGB viewing: a D65 white, an adapting
+// luminance of 200/π·Y(L*=50)/100 cd/m², a mid gray background and an
+// average surround. HCT is defined under these conditions.
+var DefaultViewingConditions = NewViewingConditions(IlluminantD65, 200/math.Pi*yFromLstar(50)/100, 50, SurroundAverage, false)
+
+// NewViewingConditions derives the CAM16 parameters of a viewing environment
+// Parameters:
+//   white: adopted white point, scaled so that Y=1
+//   adaptingLuminance: luminance of the adapting field in cd/m², often 20% of the white's luminance
+//   backgroundLstar: CIE L* of the background, 50 for mid gray
+//   surround: SurroundDark, SurroundDim, SurroundAverage or a value in between
+//   discounting: true when the illuminant is fully discounted, as for surface colors under a known light
+// Returns:
+//   *ViewingConditions: conditions for CAM16 conversions
+// Example:
+//   vc := NewViewingConditions(IlluminantD65, 64/math.Pi/5, 20, SurroundDim, false)
+func NewViewingConditions(white XYZ, adaptingLuminance, backgroundLstar, surround float64, discounting bool) *ViewingConditions {
+	backgroundLstar = math.Max(0.1, backgroundLstar)
+	rW, gW, bW := mulMat3Vec(xyzToCam16Matrix, white.X*100, white.Y*100, white.Z*100)
+	f := 0.8 + surround/10
+	var c float64
+	if f >= 0.9 {
+		c = 0.59 + (0.69-0.59)*(f-0.9)*10
+	} else {
+		c = 0.525 + (0.59-0.525)*(f-0.8)*10
+	}
+	d := 1.0
+	if !discounting {
+		d = f * (1 - 1/3.6*math.Exp((-adaptingLuminance-42)/92))
+	}
+	d = math.Max(0, math.Min(1, d))
+	rgbD := [3]float64{d*100/rW + 1 - d, d*100/gW + 1 - d, d*100/bW + 1 - d}
+	k := 1 / (5*adaptingLuminance + 1)
+	k4 := k * k * k * k
+	k4F := 1 - k4
+	fl := k4*adaptingLuminance + 0.1*k4F*k4F*math.Cbrt(5*adaptingLuminance)
+	n := yFromLstar(backgroundLstar) / (white.Y * 100)
+	z := 1.48 + math.Sqrt(n)
+	nbb := 0.725 / math.Pow(n, 0.2)
+	var rgbA [3]float64
+	for i, w := range [3]float64{rW, gW, bW} {
+		af := math.Pow(fl*rgbD[i]*w/100, 0.42)
+		rgbA[i] = 400 * af / (af + 27.13)
+	}
+	aw := (2*rgbA[0] + rgbA[1] + 0.05*rgbA[2]) * nbb
+	return &ViewingConditions{
+		n: n, aw: aw, nbb: nbb, ncb: nbb, c: c, nc: f,
+		rgbD: rgbD, fl: fl, flRoot: math.Pow(fl, 0.25), z: z,
+	}
+}
+
+// CAM16 holds the appearance correlates of a color under some viewing
+// conditions: lightness J, chroma C, hue angle H in degrees, colorfulness
+// M, saturation S and brightness Q
+type CAM16 struct {
+	J, C, H, M, S, Q float64
+}
+
+// CAM16UCS is a color in the CAM16 uniform color space, where Euclidean
+// distance approximates perceived difference
+type CAM16UCS struct {
+	J, A, B float64
+}
+
+// String converts CAM16 object to cam16() format string
+// Returns:
+//   string: "cam16(J,C,h)" formatted string, the correlates used by ToXyz
+// Example:
+//   c := CAM16{J: 46.45, C: 113.36, H: 27.41}
+//   fmt.Println(c.String()) // outputs "cam16(46.45,113.36,27.41)"
+func (c *CAM16) String() string {
+	return fmt.Sprintf("cam16(%.2f,%.2f,%.2f)", c.J, c.C, c.H)
+}
+
+// StrToCam16 converts a cam16() format string to CAM16 object; only J, C
+// and h are set, which is enough for ToXyz and ToRgb
+// Parameters:
+//   str: string in "cam16(J,C,h)" format
+// Returns:
+//   *CAM16: pointer to CAM16 object
+//   error: parsing error if format is invalid
+// Example:
+//   c, err := StrToCam16("cam16(46.45,113.36,27.41)") // red
+func StrToCam16(str string) (*CAM16, error) {
+	var j, ch, h float64
+	_, err := fmt.Sscanf(RemoveSpace(str), "cam16(%f,%f,%f)", &j, &ch, &h)
+	if err != nil {
+		return nil, err
+	}
+	return &CAM16{J: j, C: ch, H: h}, nil
+}
+
+// ToCam16 computes the CAM16 appearance of an XYZ color
+// Parameters:
+//   vc: viewing conditions, DefaultViewingConditions when nil
+// Returns:
+//   CAM16: appearance correlates
+// Example:
+//   c := XYZ{0.4124, 0.2126, 0.0193} // red
+//   cam := c.ToCam16(nil) // returns CAM16{J:46.45,C:113.39,H:27.41,...}
+func (c *XYZ) ToCam16(vc *ViewingConditions) CAM16 {
+	if vc == nil {
+		vc = DefaultViewingConditions
+	}
+	rC, gC, bC := mulMat3Vec(xyzToCam16Matrix, c.X*100, c.Y*100, c.Z*100)
+	var rgbA [3]float64
+	for i, v := range [3]float64{rC, gC, bC} {
+		d := vc.rgbD[i] * v
+		af := math.Pow(vc.fl*math.Abs(d)/100, 0.42)
+		rgbA[i] = math.Copysign(400*af/(af+27.13), d)
+	}
+	rA, gA, bA := rgbA[0], rgbA[1], rgbA[2]
+	a := (11*rA - 12*gA + bA) / 11
+	b := (rA + gA - 2*bA) / 9
+	u := (20*rA + 20*gA + 21*bA) / 20
+	p2 := (40*rA + 20*gA + bA) / 20
+	hue := math.Mod(math.Atan2(b, a)*180/math.Pi+360, 360)
+
+	ac := p2 * vc.nbb
+	j := 100 * math.Pow(ac/vc.aw, vc.c*vc.z)
+	q := 4 / vc.c * math.Sqrt(j/100) * (vc.aw + 4) * vc.flRoot
+	huePrime := hue
+	if huePrime < 20.14 {
+		huePrime += 360
+	}
+	eHue := 0.25 * (math.Cos(huePrime*math.Pi/180+2) + 3.8)
+	p1 := 50000.0 / 13 * eHue * vc.nc * vc.ncb
+	t := p1 * math.Hypot(a, b) / (u + 0.305)
+	alpha := math.Pow(t, 0.9) * math.Pow(1.64-math.Pow(0.29, vc.n), 0.73)
+	ch := alpha * math.Sqrt(j/100)
+	return CAM16{
+		J: j, C: ch, H: hue, M: ch * vc.flRoot, Q: q,
+		S: 50 * math.Sqrt(alpha*vc.c/(vc.aw+4)),
+	}
+}
+
+// ToCam16 computes the CAM16 appearance of an RGB color
+// Parameters:
+//   vc: viewing conditions, DefaultViewingConditions when nil
+// Returns:
+//   CAM16: appearance correlates
+// Example:
+//   c := RGB{255,0,0} // red
+//   cam := c.ToCam16(nil) // returns CAM16{J:46.45,C:113.36,H:27.41,...}
+func (c *RGB) ToCam16(vc *ViewingConditions) CAM16 {
+	xyz := c.ToXyz()
+	return xyz.ToCam16(vc)
+}
+
+// ToXyz inverts CAM16 from its lightness J, chroma C and hue H
+// Parameters:
+//   vc: viewing conditions, DefaultViewingConditions when nil
+// Returns:
+//   XYZ: corresponding XYZ color object, possibly outside the sRGB gamut
+// Example:
+//   c := CAM16{J: 46.45, C: 113.36, H: 27.41} // red
+//   xyz := c.ToXyz(nil) // returns XYZ{0.4124,0.2126,0.0193}
+func (c *CAM16) ToXyz(vc *ViewingConditions) XYZ {
+	if vc == nil {
+		vc = DefaultViewingConditions
+	}
+	alpha := 0.0
+	if c.C != 0 && c.J != 0 {
+		alpha = c.C / math.Sqrt(c.J/100)
+	}
+	t := math.Pow(alpha/math.Pow(1.64-math.Pow(0.29, vc.n), 0.73), 1/0.9)
+	hRad := c.H * math.Pi / 180
+	eHue := 0.25 * (math.Cos(hRad+2) + 3.8)
+	ac := vc.aw * math.Pow(c.J/100, 1/vc.c/vc.z)
+	p1 := eHue * (50000.0 / 13) * vc.nc * vc.ncb
+	p2 := ac / vc.nbb
+	hSin, hCos := math.Sincos(hRad)
+	gamma := 23 * (p2 + 0.305) * t / (23*p1 + 11*t*hCos + 108*t*hSin)
+	a, b := gamma*hCos, gamma*hSin
+	rA := (460*p2 + 451*a + 288*b) / 1403
+	gA := (460*p2 - 891*a - 261*b) / 1403
+	bA := (460*p2 - 220*a - 6300*b) / 1403
+	var rgb [3]float64
+	for i, v := range [3]float64{rA, gA, bA} {
+		base := math.Max(0, 27.13*math.Abs(v)/(400-math.Abs(v)))
+		rgb[i] = math.Copysign(100/vc.fl*math.Pow(base, 1/0.42), v) / vc.rgbD[i]
+	}
+	x, y, z := mulMat3Vec(cam16ToXyzMatrix, rgb[0], rgb[1], rgb[2])
+	return XYZ{x / 100, y / 100, z / 100}
+}
+
+// ToRgb inverts CAM16 to sRGB, clipping out-of-gamut values
+// Parameters:
+//   vc: viewing conditions, DefaultViewingConditions when nil
+// Returns:
+//   RGB: corresponding RGB color object
+// Example:
+//   c := CAM16{J: 46.45, C: 113.36, H: 27.41} // red
+//   rgb := c.ToRgb(nil) // returns RGB{255,0,0}
+func (c *CAM16) ToRgb(vc *ViewingConditions) RGB {
+	xyz := c.ToXyz(vc)
+	return xyz.ToRgb()
+}
+
+// ToUcs converts CAM16 to CAM16-UCS coordinates
+// Returns:
+//   CAM16UCS: J' lightness and a', b' from colorfulness M and hue
+// Example:
+//   c := RGB{255,0,0}
+//   cam := c.ToCam16(nil)
+//   ucs := cam.ToUcs() // returns CAM16UCS{J:59.59,A:43.30,B:22.45}
+func (c *CAM16) ToUcs() CAM16UCS {
+	mstar := math.Log1p(0.0228*c.M) / 0.0228
+	hSin, hCos := math.Sincos(c.H * math.Pi / 180)
+	return CAM16UCS{
+		J: 1.7 * c.J / (1 + 0.007*c.J),
+		A: mstar * hCos,
+		B: mstar * hSin,
+	}
+}
+
+// ToCam16 converts CAM16-UCS coordinates back to CAM16 J, C and H; the
+// other correlates are derived under the same viewing conditions
+// Parameters:
+//   vc: viewing conditions the coordinates were computed in, DefaultViewingConditions when nil
+// Returns:
+//   CAM16: appearance correlates
+func (c *CAM16UCS) ToCam16(vc *ViewingConditions) CAM16 {
+	if vc == nil {
+		vc = DefaultViewingConditions
+	}
+	m := math.Expm1(math.Hypot(c.A, c.B)*0.0228) / 0.0228
+	cam := CAM16{
+		J: c.J / (1.7 - 0.007*c.J),
+		C: m / vc.flRoot,
+		H: math.Mod(math.Atan2(c.B, c.A)*180/math.Pi+360, 360),
+	}
+	xyz := cam.ToXyz(vc)
+	return xyz.ToCam16(vc)
+}
+
+// DeltaE returns the CAM16-UCS color difference ΔE' to another color
+// Parameters:
+//   o: color to compare with
+// Returns:
+//   float64: Euclidean distance in J'a'b', 0 for equal colors
+// Example:
+//   a, b := RGB{255,0,0}, RGB{250,10,5}
+//   ca, cb := a.ToCam16(nil), b.ToCam16(nil)
+//   ua := ca.ToUcs()
+//   d := ua.DeltaE(cb.ToUcs())
+func (c *CAM16UCS) DeltaE(o CAM16UCS) float64 {
+	dj, da, db := c.J-o.J, c.A-o.A, c.B-o.B
+	return math.Sqrt(dj*dj + da*da + db*db)
+}
+
+// DeltaECorrected returns the CAM16-UCS color difference with the power
+// correction 1.41·ΔE'^0.63 recommended by Li et al. (2017), which fits
+// the visual scale of small differences better than the plain ΔE'
+// Parameters:
+//   o: color to compare with
+// Returns:
+//   float64: corrected distance, 0 for equal colors
+func (c *CAM16UCS) DeltaECorrected(o CAM16UCS) float64 {
+	return 1.41 * math.Pow(c.DeltaE(o), 0.63)
+}
+
+// yFromLstar returns the relative luminance in 0-100 of a CIE L* tone
+func yFromLstar(l float64) float64 {
+	return 100 * labFInv((l+16)/116)
+}
+
+// lstarFromY returns the CIE L* tone of a relative luminance in 0-100
+func lstarFromY(y float64) float64 {
+	return 116*labF(y/100) - 16
+}
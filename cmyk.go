@@ -97,4 +97,81 @@ func (c *CMYK) ToHsla() HSLA {
 func (c *CMYK) ToHsv() HSV {
 	rgb := c.ToRgb()
 	return rgb.ToHsv()
+}
+
+// ToXyz converts CMYK to CIE XYZ (D65) representation
+// Returns:
+//   XYZ: corresponding XYZ color object
+// Example:
+//   c := CMYK{0,0,0,0} // white
+//   xyz := c.ToXyz() // returns XYZ{0.9505,1.0000,1.0888}
+func (c *CMYK) ToXyz() XYZ {
+	rgb := c.ToRgb()
+	return rgb.ToXyz()
+}
+
+// ToLab converts CMYK to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c := CMYK{0,0,0,0} // white
+//   lab := c.ToLab() // returns LAB{100,0,0}
+func (c *CMYK) ToLab() LAB {
+	rgb := c.ToRgb()
+	return rgb.ToLab()
+}
+
+// ToOklab converts CMYK to Oklab representation
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c := CMYK{0,0,0,0} // white
+//   lab := c.ToOklab() // returns OKLAB{1.0000,0.0000,0.0000}
+func (c *CMYK) ToOklab() OKLAB {
+	rgb := c.ToRgb()
+	return rgb.ToOklab()
+}
+
+// ToOklch converts CMYK to OKLCH representation
+// Returns:
+//   OKLCH: corresponding OKLCH color object
+// Example:
+//   c := CMYK{0,100,100,0} // red
+//   lch := c.ToOklch() // returns OKLCH{0.6280,0.2577,29.23}
+func (c *CMYK) ToOklch() OKLCH {
+	rgb := c.ToRgb()
+	return rgb.ToOklch()
+}
+
+// ToHct converts CMYK to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c := CMYK{0,0,0,0} // white
+//   hct := c.ToHct() // returns HCT{209.49,2.87,100.00}
+func (c *CMYK) ToHct() HCT {
+	rgb := c.ToRgb()
+	return rgb.ToHct()
+}
+
+// ToHsluv converts CMYK to HSLuv representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c := CMYK{0,0,0,0} // white
+//   hsl := c.ToHsluv() // returns HSLUV{0.00,0.00,100.00}
+func (c *CMYK) ToHsluv() HSLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHsluv()
+}
+
+// ToHpluv converts CMYK to HPLuv representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c := CMYK{0,0,0,0} // white
+//   hpl := c.ToHpluv() // returns HPLUV{0.00,0.00,100.00}
+func (c *CMYK) ToHpluv() HPLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHpluv()
 }
\ No newline at end of file
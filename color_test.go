@@ -1,6 +1,13 @@
 package color
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/fs"
+	"math"
+	"strings"
 	"testing"
 )
 
@@ -546,4 +553,1598 @@ func TestHsv(t *testing.T) {
 			t.Errorf("cyan conversion failed: %v", cyanCmyk)
 		}
 	})
-}
\ No newline at end of file
+}
+
+func TestXyz(t *testing.T) {
+	near := func(a, b float64) bool { return math.Abs(a-b) < 1e-3 }
+
+	t.Run("string to xyz", func(t *testing.T) {
+		xyz, err := StrToXyz("xyz(0.4124, 0.2126, 0.0193)")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := XYZ{0.4124, 0.2126, 0.0193}
+		if *xyz != expected {
+			t.Errorf("expected %v, got %v", expected, *xyz)
+		}
+	})
+
+	t.Run("rgb to xyz", func(t *testing.T) {
+		c := RGB{255, 255, 255}
+		xyz := c.ToXyz()
+		if !near(xyz.X, IlluminantD65.X) || !near(xyz.Y, 1.0) || !near(xyz.Z, IlluminantD65.Z) {
+			t.Errorf("expected %v, got %v", IlluminantD65, xyz)
+		}
+	})
+
+	t.Run("xyz to rgb", func(t *testing.T) {
+		for _, c := range []RGB{{255, 0, 0}, {12, 200, 77}, {128, 128, 128}, {0, 0, 0}} {
+			xyz := c.ToXyz()
+			if rgb := xyz.ToRgb(); rgb != c {
+				t.Errorf("expected %v, got %v", c, rgb)
+			}
+		}
+	})
+
+	t.Run("bradford matrix", func(t *testing.T) {
+		m := AdaptationMatrix(IlluminantD65, IlluminantD50, Bradford)
+		expected := [3]float64{1.0478112, 0.0228866, -0.0501270}
+		for i, v := range expected {
+			if !near(m[0][i], v) {
+				t.Errorf("expected %v, got %v", expected, m[0])
+				break
+			}
+		}
+	})
+
+	t.Run("adapt white point", func(t *testing.T) {
+		for _, method := range []AdaptationMethod{XYZScaling, VonKries, Bradford, CAT02, CAT16} {
+			c := IlluminantD50
+			got := c.Adapt(IlluminantD50, IlluminantD65, method)
+			if !near(got.X, IlluminantD65.X) || !near(got.Y, IlluminantD65.Y) || !near(got.Z, IlluminantD65.Z) {
+				t.Errorf("%v: expected %v, got %v", method, IlluminantD65, got)
+			}
+		}
+	})
+
+	t.Run("adapt round trip", func(t *testing.T) {
+		c := XYZ{0.3, 0.4, 0.2}
+		a := c.Adapt(IlluminantA, IlluminantF11, CAT02)
+		back := a.Adapt(IlluminantF11, IlluminantA, CAT02)
+		if !near(back.X, c.X) || !near(back.Y, c.Y) || !near(back.Z, c.Z) {
+			t.Errorf("expected %v, got %v", c, back)
+		}
+	})
+}
+
+func TestTemperature(t *testing.T) {
+	t.Run("kelvin to rgb", func(t *testing.T) {
+		c := FromKelvin(6504)
+		expected := RGB{255, 255, 255}
+		if c != expected {
+			t.Errorf("expected %v, got %v", expected, c)
+		}
+		warm := FromKelvin(2700)
+		if warm.R != 255 || warm.G <= warm.B {
+			t.Errorf("expected warm white, got %v", warm)
+		}
+		cool := FromKelvin(10000)
+		if cool.B != 255 || cool.R >= cool.G {
+			t.Errorf("expected cool white, got %v", cool)
+		}
+	})
+
+	t.Run("cct of illuminant", func(t *testing.T) {
+		c := IlluminantD65
+		cct, duv := c.CCT()
+		if math.Abs(cct-6504) > 10 || math.Abs(duv-0.0032) > 0.0005 {
+			t.Errorf("expected 6504K duv 0.0032, got %vK duv %v", cct, duv)
+		}
+	})
+
+	t.Run("kelvin round trip", func(t *testing.T) {
+		for _, k := range []float64{1800, 2700, 3500, 5000, 8000} {
+			c := FromKelvin(k)
+			cct, duv := c.CCT()
+			if math.Abs(cct-k)/k > 0.01 || math.Abs(duv) > 0.005 {
+				t.Errorf("%vK: got %vK duv %v", k, cct, duv)
+			}
+		}
+	})
+
+	t.Run("hsv cct", func(t *testing.T) {
+		c := HSV{0, 0, 100}
+		cct, _ := c.CCT()
+		if math.Abs(cct-6504) > 10 {
+			t.Errorf("expected 6504K, got %vK", cct)
+		}
+	})
+}
+
+func TestAnsi(t *testing.T) {
+	env := func(vars map[string]string) func(string) string {
+		return func(key string) string { return vars[key] }
+	}
+
+	t.Run("detect color mode", func(t *testing.T) {
+		cases := []struct {
+			vars     map[string]string
+			expected ColorMode
+		}{
+			{map[string]string{"TERM": "xterm-256color", "COLORTERM": "truecolor"}, ColorModeTrueColor},
+			{map[string]string{"TERM": "xterm-256color", "NO_COLOR": "1", "COLORTERM": "truecolor"}, ColorModeNone},
+			{map[string]string{"TERM": "screen-256color"}, ColorMode256},
+			{map[string]string{"TERM": "xterm"}, ColorMode16},
+			{map[string]string{"TERM": "dumb"}, ColorModeNone},
+			{map[string]string{}, ColorModeNone},
+		}
+		for _, tc := range cases {
+			if mode := DetectColorMode(env(tc.vars)); mode != tc.expected {
+				t.Errorf("%v: expected %v, got %v", tc.vars, tc.expected, mode)
+			}
+		}
+	})
+
+	t.Run("index to rgb", func(t *testing.T) {
+		cases := map[uint8]RGB{1: {205, 0, 0}, 16: {0, 0, 0}, 196: {255, 0, 0}, 231: {255, 255, 255}, 244: {128, 128, 128}}
+		for i, expected := range cases {
+			if c := AnsiIndexToRgb(i); c != expected {
+				t.Errorf("%d: expected %v, got %v", i, expected, c)
+			}
+		}
+	})
+
+	t.Run("downsample", func(t *testing.T) {
+		c := RGB{255, 0, 0}
+		if i := c.ToAnsi256(); i != 196 {
+			t.Errorf("expected 196, got %d", i)
+		}
+		g := RGB{127, 127, 127}
+		if i := g.ToAnsi256(); i != 244 {
+			t.Errorf("expected 244, got %d", i)
+		}
+		if i := c.ToAnsi16(); i != 9 {
+			t.Errorf("expected 9, got %d", i)
+		}
+	})
+
+	t.Run("sgr sequences", func(t *testing.T) {
+		c := RGB{255, 0, 0}
+		cases := []struct {
+			got, expected string
+		}{
+			{c.AnsiFg(ColorModeTrueColor), "\x1b[38;2;255;0;0m"},
+			{c.AnsiBg(ColorMode256), "\x1b[48;5;196m"},
+			{c.AnsiFg(ColorMode16), "\x1b[91m"},
+			{c.AnsiBg(ColorMode16), "\x1b[101m"},
+			{c.AnsiFg(ColorModeNone), ""},
+		}
+		for _, tc := range cases {
+			if tc.got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, tc.got)
+			}
+		}
+	})
+}
+
+func TestAnsiConv(t *testing.T) {
+	t.Run("apply sgr", func(t *testing.T) {
+		var s AnsiStyle
+		s.ApplySgr("1;38;5;208;48;2;1;2;3")
+		expected := AnsiStyle{
+			Fg:   AnsiColor{Kind: AnsiColorIndexed, Index: 208},
+			Bg:   AnsiColor{Kind: AnsiColorRGB, RGB: RGB{1, 2, 3}},
+			Bold: true,
+		}
+		if s != expected {
+			t.Errorf("expected %+v, got %+v", expected, s)
+		}
+		s.ApplySgr("38:2::10:20:30;22;7")
+		if s.Fg.RGB != (RGB{10, 20, 30}) || s.Bold || !s.Inverse {
+			t.Errorf("unexpected style %+v", s)
+		}
+		s.ApplySgr("")
+		if s != (AnsiStyle{}) {
+			t.Errorf("expected reset, got %+v", s)
+		}
+	})
+
+	t.Run("parse segments", func(t *testing.T) {
+		segs, err := ParseAnsi(strings.NewReader("a\x1b[31mb\x1b]0;title\x07c\x1b[2Kd\x1b[0me"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		red := AnsiStyle{Fg: AnsiColor{Kind: AnsiColorIndexed, Index: 1}}
+		expected := []AnsiSegment{{"a", AnsiStyle{}}, {"bcd", red}, {"e", AnsiStyle{}}}
+		if len(segs) != len(expected) {
+			t.Fatalf("expected %v, got %v", expected, segs)
+		}
+		for i := range segs {
+			if segs[i] != expected[i] {
+				t.Errorf("expected %v, got %v", expected[i], segs[i])
+			}
+		}
+	})
+
+	t.Run("ansi to html", func(t *testing.T) {
+		var b strings.Builder
+		err := AnsiToHTML(&b, strings.NewReader("\x1b[1;32mok\x1b[0m <x>"), nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := `<span style="color:#00cd00;font-weight:bold">ok</span> &lt;x&gt;`
+		if b.String() != expected {
+			t.Errorf("expected %s, got %s", expected, b.String())
+		}
+	})
+
+	t.Run("ansi to svg", func(t *testing.T) {
+		theme := DefaultAnsiTheme
+		theme.Palette[1] = RGB{255, 85, 85}
+		var b strings.Builder
+		err := AnsiToSVG(&b, strings.NewReader("\x1b[31;7mfail\x1b[0m\nok\n"), &theme, 10)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		svg := b.String()
+		for _, want := range []string{`width="24" height="24"`, `fill="#ff5555"/>`, `<tspan x="0" fill="#000000">fail</tspan>`, `>ok</tspan>`} {
+			if !strings.Contains(svg, want) {
+				t.Errorf("expected %q in %s", want, svg)
+			}
+		}
+	})
+}
+
+func TestOsc(t *testing.T) {
+	t.Run("build sequences", func(t *testing.T) {
+		cases := []struct {
+			got, expected string
+		}{
+			{OscSetColor(OscBackground, RGB{0, 43, 54}), "\x1b]11;rgb:00/2b/36\x1b\\"},
+			{OscQueryColor(OscForeground), "\x1b]10;?\x1b\\"},
+			{OscSetPaletteColor(1, RGB{255, 0, 0}), "\x1b]4;1;rgb:ff/00/00\x1b\\"},
+			{OscQueryPaletteColor(200), "\x1b]4;200;?\x1b\\"},
+		}
+		for _, tc := range cases {
+			if tc.got != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, tc.got)
+			}
+		}
+	})
+
+	t.Run("parse reply", func(t *testing.T) {
+		cases := []struct {
+			reply    string
+			expected OscReply
+		}{
+			{"\x1b]11;rgb:ffff/8080/0000\x07", OscReply{OscBackground, 0, RGB{255, 128, 0}}},
+			{"\x1b]10;rgb:f/8/0\x1b\\", OscReply{OscForeground, 0, RGB{255, 136, 0}}},
+			{"\x1b]4;3;rgb:cdc/cdc/000\x1b\\", OscReply{OscPalette, 3, RGB{205, 205, 0}}},
+			{"12;rgba:00/2b/36/ff", OscReply{OscCursor, 0, RGB{0, 43, 54}}},
+		}
+		for _, tc := range cases {
+			r, err := ParseOscReply(tc.reply)
+			if err != nil {
+				t.Errorf("%q: unexpected error: %v", tc.reply, err)
+				continue
+			}
+			if r != tc.expected {
+				t.Errorf("%q: expected %v, got %v", tc.reply, tc.expected, r)
+			}
+		}
+		if _, err := ParseOscReply("\x1b]11;rgb:12345/0/0\x07"); err == nil {
+			t.Error("expected error for 5 digit channel")
+		}
+	})
+
+	t.Run("query terminal", func(t *testing.T) {
+		var w strings.Builder
+		r := strings.NewReader("\x1b]11;rgb:1c1c/1c1c/1c1c\x1b\\rest")
+		c, err := QueryOscColor(&w, r, OscBackground)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c != (RGB{28, 28, 28}) || w.String() != "\x1b]11;?\x1b\\" {
+			t.Errorf("got %v after writing %q", c, w.String())
+		}
+		if r.Len() != len("rest") {
+			t.Errorf("expected reader to stop at terminator, %d bytes left", r.Len())
+		}
+		if _, err := QueryOscPaletteColor(&w, strings.NewReader("\x1b]4;2;rgb:00/cd/00\a"), 1); err == nil {
+			t.Error("expected error for reply to another index")
+		}
+	})
+}
+
+func TestX11(t *testing.T) {
+	t.Run("parse specs", func(t *testing.T) {
+		cases := map[string]RGB{
+			"#f80":                    {240, 128, 0},
+			"#ff8000":                 {255, 128, 0},
+			"#fff808000":              {255, 128, 0},
+			"#ffff80800000":           {255, 128, 0},
+			"rgb:ff/80/00":            {255, 128, 0},
+			"rgb:f/8/0":               {255, 136, 0},
+			"rgbi:1.0/0.5/0":          {255, 128, 0},
+			"CIEXYZ:0.9505/1/1.0888":  {255, 255, 255},
+			"CIExyY:0.3127/0.329/1":   {255, 255, 255},
+			"CIELab:53.24/80.09/67.2": {255, 0, 0},
+			"DarkSlateGray4":          {82, 139, 139},
+			"dark slate gray":         {47, 79, 79},
+		}
+		for spec, expected := range cases {
+			c, err := ParseX11Color(spec)
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", spec, err)
+				continue
+			}
+			if c != expected {
+				t.Errorf("%s: expected %v, got %v", spec, expected, c)
+			}
+		}
+		for _, spec := range []string{"#ff00", "rgbi:2/0/0", "NoSuchColor", "rgb:fffff/0/0"} {
+			if _, err := ParseX11Color(spec); err == nil {
+				t.Errorf("%s: expected error", spec)
+			}
+		}
+	})
+
+	t.Run("format round trip", func(t *testing.T) {
+		c := RGB{12, 200, 77}
+		for n := X11Hex; n <= X11TekHVC; n++ {
+			spec := c.ToX11(n)
+			got, err := ParseX11Color(spec)
+			if err != nil || got != c {
+				t.Errorf("%s: expected %v, got %v (%v)", spec, c, got, err)
+			}
+		}
+		orange := RGB{255, 128, 0}
+		if s := orange.ToX11(X11Hex12); s != "#fff808000" {
+			t.Errorf("expected #fff808000, got %s", s)
+		}
+	})
+
+	t.Run("load rgb.txt", func(t *testing.T) {
+		names, err := LoadRgbTxt(strings.NewReader("! comment\n  0  82 204\t\tBrand Blue\n255 255 255\twhite\n"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c, ok := names.Lookup("brandblue"); !ok || c != (RGB{0, 82, 204}) {
+			t.Errorf("expected brand blue, got %v %v", c, ok)
+		}
+		if name, ok := names.Name(RGB{255, 255, 255}); !ok || name != "white" {
+			t.Errorf("expected white, got %s", name)
+		}
+		if c, err := names.Parse("Brand Blue"); err != nil || c != (RGB{0, 82, 204}) {
+			t.Errorf("expected brand blue, got %v %v", c, err)
+		}
+		if _, err := LoadRgbTxt(strings.NewReader("1 2\tbroken\n")); err == nil {
+			t.Error("expected error for malformed line")
+		}
+	})
+}
+
+func TestTerminalTheme(t *testing.T) {
+	theme := TerminalTheme{
+		Foreground: RGB{197, 200, 198},
+		Background: RGB{29, 31, 33},
+		Cursor:     RGB{255, 255, 255},
+		Selection:  RGBA{RGB{55, 59, 65}, 1.0},
+	}
+	for i := range theme.Ansi {
+		theme.Ansi[i] = RGB{uint8(i * 16), uint8(255 - i*16), uint8(i * 7)}
+	}
+
+	formats := []struct {
+		name  string
+		write func(io.Writer, *TerminalTheme) error
+		read  func(io.Reader) (*TerminalTheme, error)
+	}{
+		{"alacritty", WriteAlacrittyTheme, ReadAlacrittyTheme},
+		{"kitty", WriteKittyTheme, ReadKittyTheme},
+		{"windows terminal", WriteWindowsTerminalScheme, ReadWindowsTerminalScheme},
+		{"iterm2", WriteITerm2Colors, ReadITerm2Colors},
+		{"xresources", WriteXresources, ReadXresources},
+	}
+	for _, f := range formats {
+		t.Run(f.name+" round trip", func(t *testing.T) {
+			var b strings.Builder
+			if err := f.write(&b, &theme); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := f.read(strings.NewReader(b.String()))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if *got != theme {
+				t.Errorf("expected %+v, got %+v", theme, *got)
+			}
+		})
+	}
+
+	t.Run("read alacritty", func(t *testing.T) {
+		src := "[colors.primary]\nbackground = '0x1d1f21' # dark\nforeground = '#c5c8c6' # 'light'\n\n" +
+			"[colors.bright]\nred = \"#f00\" # \"bright\" red\nblue = '#81a2be'\n"
+		got, err := ReadAlacrittyTheme(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Background != (RGB{29, 31, 33}) || got.Foreground != (RGB{197, 200, 198}) ||
+			got.Ansi[9] != (RGB{255, 0, 0}) || got.Ansi[12] != (RGB{129, 162, 190}) {
+			t.Errorf("unexpected theme %+v", got)
+		}
+	})
+
+	t.Run("read alacritty theme keys", func(t *testing.T) {
+		// Dracula from the alacritty-theme repository, shortened
+		src := `[colors.primary]
+background = '#282a36'
+foreground = '#f8f8f2'
+bright_foreground = '#ffffff'
+
+[colors.cursor]
+text = 'CellBackground'
+cursor = 'CellForeground'
+
+[colors.vi_mode_cursor]
+text = 'CellBackground'
+cursor = 'CellForeground'
+
+[colors.search.matches]
+foreground = '#44475a'
+background = '#50fa7b'
+
+[colors.hints.start]
+foreground = '#282a36'
+background = '#f1fa8c'
+
+[colors.selection]
+text = 'CellForeground'
+background = '#44475a'
+
+[colors.normal]
+black = '#21222c'
+red = '#ff5555'
+
+[colors.bright]
+black = '#6272a4'
+red = '#ff6e6e'
+
+[[colors.indexed_colors]]
+index = 16
+color = '#ffb86c'
+`
+		got, err := ReadAlacrittyTheme(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Background != (RGB{40, 42, 54}) || got.Foreground != (RGB{248, 248, 242}) ||
+			got.Cursor != (RGB{}) || got.Selection != (RGBA{RGB{68, 71, 90}, 1.0}) ||
+			got.Ansi[1] != (RGB{255, 85, 85}) || got.Ansi[9] != (RGB{255, 110, 110}) {
+			t.Errorf("unexpected theme %+v", got)
+		}
+		if _, err := ReadAlacrittyTheme(strings.NewReader("[colors.normal]\nred = 'nonsense'\n")); err == nil {
+			t.Error("expected error for invalid color")
+		}
+	})
+
+	t.Run("read kitty none", func(t *testing.T) {
+		src := "background #1d1f21\ncursor none\nselection_background none\ncolor1 #cc6666\n"
+		got, err := ReadKittyTheme(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Background != (RGB{29, 31, 33}) || got.Ansi[1] != (RGB{204, 102, 102}) ||
+			got.Cursor != (RGB{}) || got.Selection != (RGBA{}) {
+			t.Errorf("unexpected theme %+v", got)
+		}
+	})
+
+	t.Run("read xresources defines", func(t *testing.T) {
+		src := "! base16\n#define base00 #1d1f21\nURxvt*background: base00\nXTerm.vt100.color1: rgb:cc/66/66\n*color15: white\n"
+		got, err := ReadXresources(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Background != (RGB{29, 31, 33}) || got.Ansi[1] != (RGB{204, 102, 102}) || got.Ansi[15] != (RGB{255, 255, 255}) {
+			t.Errorf("unexpected theme %+v", got)
+		}
+	})
+}
+
+func TestBase16(t *testing.T) {
+	legacy := `scheme: "Tomorrow Night"
+author: "Chris Kempson (http://chriskempson.com)"
+base00: "1d1f21"
+base01: "282a2e"
+base02: "373b41"
+base03: "969896"
+base04: "b4b7b4"
+base05: "c5c8c6"
+base06: "e0e0e0"
+base07: "ffffff"
+base08: "cc6666"
+base09: "de935f"
+base0A: "f0c674"
+base0B: "b5bd68"
+base0C: "8abeb7"
+base0D: "81a2be"
+base0E: "b294bb"
+base0F: "a3685a"
+`
+
+	t.Run("read legacy scheme", func(t *testing.T) {
+		s, err := ReadBase16Scheme(strings.NewReader(legacy))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s.Name != "Tomorrow Night" || s.Slug != "tomorrow-night" || s.System != "base16" {
+			t.Errorf("unexpected scheme %+v", s)
+		}
+		if s.Colors[0x0A] != (RGB{240, 198, 116}) {
+			t.Errorf("expected base0A {240 198 116}, got %v", s.Colors[0x0A])
+		}
+		if _, err := ReadBase16Scheme(strings.NewReader("scheme: x\nbase00: 000000\n")); err == nil {
+			t.Error("expected error for missing colors")
+		}
+	})
+
+	t.Run("base24 round trip", func(t *testing.T) {
+		s := Base16Scheme{System: "base24", Name: "Test", Author: "me", Slug: "test", Variant: "dark"}
+		for i := range s.Colors {
+			s.Colors[i] = RGB{uint8(i), uint8(i * 10), 255}
+		}
+		var b strings.Builder
+		if err := WriteBase16Scheme(&b, &s); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, err := ReadBase16Scheme(strings.NewReader(b.String()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if *got != s {
+			t.Errorf("expected %+v, got %+v", s, *got)
+		}
+	})
+
+	t.Run("render template", func(t *testing.T) {
+		s, _ := ReadBase16Scheme(strings.NewReader(legacy + "variant: dark\n"))
+		tmpl := "{{! comment }}# {{scheme-name}} by {{{scheme-author}}}\n" +
+			"bg=#{{base00-hex}} r={{base08-rgb-r}} d={{base07-dec-g}} bgr={{base08-hex-bgr}}\n" +
+			"{{#scheme-is-dark-variant}}dark{{/scheme-is-dark-variant}}{{^scheme-is-dark-variant}}light{{/scheme-is-dark-variant}}\n"
+		var b strings.Builder
+		if err := RenderBase16Template(&b, tmpl, s); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "# Tomorrow Night by Chris Kempson (http://chriskempson.com)\n" +
+			"bg=#1d1f21 r=204 d=1.00000000 bgr=6666cc\n" +
+			"dark\n"
+		if b.String() != expected {
+			t.Errorf("expected %q, got %q", expected, b.String())
+		}
+		if err := RenderBase16Template(&b, "{{#a}}x", s); err == nil {
+			t.Error("expected error for unclosed section")
+		}
+	})
+}
+
+func TestLsColorsAndGit(t *testing.T) {
+	t.Run("parse ls colors", func(t *testing.T) {
+		lc, err := ParseLsColors("di=01;34:ln=01;36:ex=01;32:*.tar=38;5;9:*.TAR.GZ=4;38;2;255;10;179:fi=0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		di, _ := lc.Lookup("di")
+		if di != (AnsiStyle{Fg: AnsiColor{Kind: AnsiColorIndexed, Index: 4}, Bold: true}) {
+			t.Errorf("unexpected di style %+v", di)
+		}
+		tar, _ := lc.StyleFor("backup.tar", 0644)
+		if tar.Fg != (AnsiColor{Kind: AnsiColorIndexed, Index: 9}) {
+			t.Errorf("unexpected tar style %+v", tar)
+		}
+		gz, _ := lc.StyleFor("backup.tar.gz", 0644)
+		if !gz.Underline || gz.Fg.RGB != (RGB{255, 10, 179}) {
+			t.Errorf("unexpected tar.gz style %+v", gz)
+		}
+		if s, _ := lc.StyleFor("run.sh", 0755); !s.Bold || s.Fg.Index != 2 {
+			t.Errorf("unexpected executable style %+v", s)
+		}
+		if s, ok := lc.StyleFor("notes.txt", 0644); !ok || s != (AnsiStyle{}) {
+			t.Errorf("unexpected file style %+v %v", s, ok)
+		}
+		expected := "di=1;34:ln=1;36:ex=1;32:*.tar=91:*.TAR.GZ=4;38;2;255;10;179:fi="
+		if lc.String() != expected {
+			t.Errorf("expected %s, got %s", expected, lc.String())
+		}
+		if _, err := ParseLsColors("di"); err == nil {
+			t.Error("expected error for entry without =")
+		}
+	})
+
+	t.Run("ln target", func(t *testing.T) {
+		lc, err := ParseLsColors("di=01;34:ln=target:*.tar=31")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if lc.String() != "di=1;34:ln=target:*.tar=31" {
+			t.Errorf("unexpected round trip %s", lc.String())
+		}
+		if s, ok := lc.StyleFor("link.tar", fs.ModeSymlink|0777); ok {
+			t.Errorf("expected no style for a target link, got %+v", s)
+		}
+		lc.Set("ln", AnsiStyle{Bold: true})
+		if lc.String() != "di=1;34:ln=1:*.tar=31" {
+			t.Errorf("unexpected entries after Set %s", lc.String())
+		}
+	})
+
+	t.Run("parse git color", func(t *testing.T) {
+		s, err := ParseGitColor("bold red ul #ff0ab3")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := AnsiStyle{
+			Fg:        AnsiColor{Kind: AnsiColorIndexed, Index: 1},
+			Bg:        AnsiColor{Kind: AnsiColorRGB, RGB: RGB{255, 10, 179}},
+			Bold:      true,
+			Underline: true,
+		}
+		if s != expected {
+			t.Errorf("expected %+v, got %+v", expected, s)
+		}
+		s, _ = ParseGitColor("normal brightblue nobold no-italic reverse")
+		if s.Fg.Kind != AnsiColorDefault || s.Bg.Index != 12 || !s.Inverse {
+			t.Errorf("unexpected style %+v", s)
+		}
+		for _, bad := range []string{"red blue green", "sparkly", "300", "#12345"} {
+			if _, err := ParseGitColor(bad); err == nil {
+				t.Errorf("%s: expected error", bad)
+			}
+		}
+	})
+
+	t.Run("format git color", func(t *testing.T) {
+		cases := map[string]string{
+			"bold red ul #ff0ab3": "red #ff0ab3 bold ul",
+			"normal 208":          "normal 208",
+			"brightgreen dim":     "brightgreen dim",
+			"":                    "normal",
+		}
+		for in, expected := range cases {
+			s, err := ParseGitColor(in)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := FormatGitColor(s); got != expected {
+				t.Errorf("%q: expected %q, got %q", in, expected, got)
+			}
+		}
+	})
+}
+
+func TestLab(t *testing.T) {
+	t.Run("string to lab", func(t *testing.T) {
+		lab, err := StrToLab("lab(54.29, 80.81, 69.89)")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := LAB{54.29, 80.81, 69.89}
+		if *lab != expected {
+			t.Errorf("expected %v, got %v", expected, *lab)
+		}
+		if s := lab.String(); s != "lab(54.29,80.81,69.89)" {
+			t.Errorf("expected lab(54.29,80.81,69.89), got %s", s)
+		}
+	})
+
+	t.Run("rgb to lab", func(t *testing.T) {
+		c := RGB{255, 0, 0}
+		lab := c.ToLab()
+		if math.Abs(lab.L-54.29) > 0.01 || math.Abs(lab.A-80.81) > 0.01 || math.Abs(lab.B-69.89) > 0.01 {
+			t.Errorf("expected {54.29 80.81 69.89}, got %v", lab)
+		}
+	})
+
+	t.Run("lab to rgb", func(t *testing.T) {
+		for _, c := range []RGB{{255, 0, 0}, {12, 200, 77}, {255, 255, 255}, {0, 0, 0}} {
+			lab := c.ToLab()
+			if rgb := lab.ToRgb(); rgb != c {
+				t.Errorf("expected %v, got %v", c, rgb)
+			}
+		}
+	})
+}
+
+func TestAse(t *testing.T) {
+	file := AseFile{
+		Swatches: []AseSwatch{
+			{Name: "Brand Red", Type: AseGlobal, Color: RGB{230, 30, 40}},
+		},
+		Groups: []AseGroup{
+			{Name: "Print", Swatches: []AseSwatch{
+				{Name: "Pantone 286 C", Type: AseSpot, Color: CMYK{100, 66, 0, 2}},
+				{Name: "Sky ☁", Type: AseProcess, Color: LAB{75, -10, -30}},
+				{Name: "Shadow", Type: AseProcess, Color: AseGray(0.25)},
+			}},
+			{Name: "Empty"},
+		},
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := WriteAse(&b, &file); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.HasPrefix(b.Bytes(), []byte("ASEF\x00\x01\x00\x00\x00\x00\x00\x08")) {
+			t.Errorf("unexpected header % x", b.Bytes()[:12])
+		}
+		got, err := ReadAse(&b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got.Swatches) != 1 || got.Swatches[0] != file.Swatches[0] {
+			t.Errorf("expected %v, got %v", file.Swatches, got.Swatches)
+		}
+		if len(got.Groups) != 2 || got.Groups[0].Name != "Print" || len(got.Groups[1].Swatches) != 0 {
+			t.Fatalf("unexpected groups %+v", got.Groups)
+		}
+		for i, sw := range got.Groups[0].Swatches {
+			if sw != file.Groups[0].Swatches[i] {
+				t.Errorf("expected %v, got %v", file.Groups[0].Swatches[i], sw)
+			}
+		}
+	})
+
+	t.Run("read gray", func(t *testing.T) {
+		data := []byte("ASEF\x00\x01\x00\x00\x00\x00\x00\x01" +
+			"\x00\x01\x00\x00\x00\x10" + // color entry, 16 bytes
+			"\x00\x02\x00K\x00\x00" + // name "K"
+			"Gray\x3f\x00\x00\x00" + // 0.5
+			"\x00\x02")
+		got, err := ReadAse(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := AseSwatch{Name: "K", Type: AseProcess, Color: AseGray(0.5)}
+		if len(got.Swatches) != 1 || got.Swatches[0] != expected {
+			t.Fatalf("expected %v, got %v", expected, got.Swatches)
+		}
+		if rgb := got.Swatches[0].ToRgb(); rgb != (RGB{128, 128, 128}) {
+			t.Errorf("expected gray RGB, got %v", rgb)
+		}
+		var b bytes.Buffer
+		if err := WriteAse(&b, got); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(b.Bytes(), data) {
+			t.Errorf("expected % x, got % x", data, b.Bytes())
+		}
+		if _, err := ReadAse(strings.NewReader("ACO1")); err == nil {
+			t.Error("expected error for bad signature")
+		}
+	})
+
+	t.Run("truncated block", func(t *testing.T) {
+		// a 4 GB block length in an 18 byte file must fail without allocating it
+		data := "ASEF\x00\x01\x00\x00\x00\x00\x00\x01\x00\x01\xff\xff\xff\xf0"
+		if _, err := ReadAse(strings.NewReader(data)); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("expected unexpected EOF, got %v", err)
+		}
+	})
+}
+
+func TestAcoAct(t *testing.T) {
+	t.Run("aco round trip", func(t *testing.T) {
+		swatches := []AcoSwatch{
+			{Name: "Red", Color: RGB{255, 0, 0}},
+			{Name: "Teal", Color: HSV{180, 50, 60}},
+			{Name: "Ink", Color: CMYK{100, 66, 0, 2}},
+			{Name: "Sky", Color: LAB{75.5, -10.25, -30}},
+		}
+		var b bytes.Buffer
+		if err := WriteAco(&b, swatches); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got, err := ReadAco(&b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != len(swatches) {
+			t.Fatalf("expected %v, got %v", swatches, got)
+		}
+		for i := range got {
+			if got[i] != swatches[i] {
+				t.Errorf("expected %v, got %v", swatches[i], got[i])
+			}
+		}
+	})
+
+	t.Run("aco version 1", func(t *testing.T) {
+		data := []byte{
+			0, 1, 0, 2,
+			0, 0, 0xff, 0xff, 0x80, 0x80, 0, 0, 0, 0, // RGB 255,128,0
+			0, 8, 0x13, 0x88, 0, 0, 0, 0, 0, 0, // grayscale 50%
+		}
+		got, err := ReadAco(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []AcoSwatch{{Color: RGB{255, 128, 0}}, {Color: RGB{128, 128, 128}}}
+		if len(got) != 2 || got[0] != expected[0] || got[1] != expected[1] {
+			t.Errorf("expected %v, got %v", expected, got)
+		}
+		if _, err := ReadAco(bytes.NewReader([]byte{0, 1, 0, 1, 0, 3, 0, 0, 0, 0, 0, 0, 0, 0})); err == nil {
+			t.Error("expected error for pantone color space")
+		}
+	})
+
+	t.Run("act round trip", func(t *testing.T) {
+		table := ActTable{Colors: []RGB{{0, 0, 0}, {255, 255, 255}, {255, 0, 0}}, Transparent: 1}
+		var b bytes.Buffer
+		if err := WriteAct(&b, &table); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if b.Len() != 772 {
+			t.Errorf("expected 772 bytes, got %d", b.Len())
+		}
+		got, err := ReadAct(&b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Transparent != 1 || len(got.Colors) != 3 || got.Colors[2] != (RGB{255, 0, 0}) {
+			t.Errorf("expected %v, got %v", table, got)
+		}
+	})
+
+	t.Run("write limits", func(t *testing.T) {
+		if err := WriteAct(&bytes.Buffer{}, &ActTable{Transparent: -1}); err == nil {
+			t.Error("expected error for empty act table")
+		}
+		if err := WriteAco(&bytes.Buffer{}, make([]AcoSwatch, math.MaxUint16+1)); err == nil {
+			t.Error("expected error for more than 65535 swatches")
+		}
+	})
+
+	t.Run("act without trailer", func(t *testing.T) {
+		data := make([]byte, 768)
+		data[767] = 9
+		got, err := ReadAct(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got.Colors) != 256 || got.Transparent != -1 || got.Colors[255] != (RGB{0, 0, 9}) {
+			t.Errorf("unexpected table %v", got)
+		}
+		if _, err := ReadAct(bytes.NewReader(data[:700])); err == nil {
+			t.Error("expected error for short file")
+		}
+	})
+
+	t.Run("truncated aco name", func(t *testing.T) {
+		// one black RGB swatch per section, the name claims 4G UTF-16 units
+		data := "\x00\x01\x00\x01" + strings.Repeat("\x00", 10) +
+			"\x00\x02\x00\x01" + strings.Repeat("\x00", 10) + "\xff\xff\xff\xff\x00A"
+		if _, err := ReadAco(strings.NewReader(data)); !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("expected unexpected EOF, got %v", err)
+		}
+	})
+}
+
+func TestOklab(t *testing.T) {
+	t.Run("string to oklab", func(t *testing.T) {
+		lab, err := StrToOklab("oklab(0.628, 0.2249, 0.1258)")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s := lab.String(); s != "oklab(0.6280,0.2249,0.1258)" {
+			t.Errorf("expected oklab(0.6280,0.2249,0.1258), got %s", s)
+		}
+		lch, err := StrToOklch("oklch(0.628, 0.2577, 29.23)")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if s := lch.String(); s != "oklch(0.6280,0.2577,29.23)" {
+			t.Errorf("expected oklch(0.6280,0.2577,29.23), got %s", s)
+		}
+	})
+
+	t.Run("rgb to oklch", func(t *testing.T) {
+		cases := []struct {
+			rgb      RGB
+			expected OKLCH
+		}{
+			{RGB{255, 0, 0}, OKLCH{0.6280, 0.2577, 29.23}},
+			{RGB{0, 255, 0}, OKLCH{0.8664, 0.2948, 142.50}},
+			{RGB{0, 0, 255}, OKLCH{0.4520, 0.3132, 264.05}},
+			{RGB{255, 255, 255}, OKLCH{1, 0, 0}},
+		}
+		for _, c := range cases {
+			got := c.rgb.ToOklch()
+			if math.Abs(got.L-c.expected.L) > 1e-4 || math.Abs(got.C-c.expected.C) > 1e-4 || math.Abs(got.H-c.expected.H) > 0.01 {
+				t.Errorf("%v: expected %v, got %v", c.rgb, c.expected, got)
+			}
+		}
+	})
+
+	t.Run("oklab round trip", func(t *testing.T) {
+		for _, c := range []RGB{{255, 0, 0}, {12, 200, 77}, {128, 0, 128}, {0, 0, 0}} {
+			lch := c.ToOklch()
+			if rgb := lch.ToRgb(); rgb != c {
+				t.Errorf("expected %v, got %v", c, rgb)
+			}
+		}
+	})
+}
+
+func TestDesignTokens(t *testing.T) {
+	src := `{
+  "color": {
+    "$type": "color",
+    "brand": {
+      "500": {
+        "$value": {"colorSpace": "srgb", "components": [1, 0.5, 0], "hex": "#ff8000"},
+        "$description": "Brand orange"
+      },
+      "accent": {"$value": {"colorSpace": "oklch", "components": [0.7, 0.1, "none"], "alpha": 0.5}}
+    },
+    "wide": {"$value": {"colorSpace": "display-p3", "components": [1, 0, 0]}},
+    "text": {"$value": "{color.link}"},
+    "link": {"$value": "{color.brand.500}"}
+  },
+  "space": {"small": {"$type": "dimension", "$value": {"value": 4, "unit": "px"}}}
+}`
+
+	t.Run("read tokens", func(t *testing.T) {
+		tokens, err := ReadDesignTokens(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(tokens.Tokens) != 5 {
+			t.Fatalf("expected 5 color tokens, got %v", tokens.Tokens)
+		}
+		brand, _ := tokens.Lookup("color.brand.500")
+		if brand.Color != (RGB{255, 128, 0}) || brand.Alpha != nil || brand.Hex != "#ff8000" || brand.Description != "Brand orange" {
+			t.Errorf("unexpected token %+v", brand)
+		}
+		accent, _ := tokens.Lookup("color.brand.accent")
+		if accent.Color != (OKLCH{0.7, 0.1, 0}) || accent.Opacity() != 0.5 {
+			t.Errorf("unexpected token %+v", accent)
+		}
+		text, _ := tokens.Lookup("color.text")
+		if text.Alias != "color.link" || text.Color != (RGB{255, 128, 0}) {
+			t.Errorf("unexpected alias %+v", text)
+		}
+		wide, _ := tokens.Lookup("color.wide")
+		xyz := wide.Color.(XYZ)
+		if rgb := xyz.ToRgb(); rgb != (RGB{255, 0, 0}) || xyz.X < 0.48 {
+			t.Errorf("expected out of gamut red, got %v", xyz)
+		}
+	})
+
+	t.Run("write tokens", func(t *testing.T) {
+		tokens, _ := ReadDesignTokens(strings.NewReader(src))
+		var b bytes.Buffer
+		if err := WriteDesignTokens(&b, tokens); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(b.String(), `"$value": "{color.link}"`) {
+			t.Errorf("alias not written: %s", b.String())
+		}
+		again, err := ReadDesignTokens(&b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for i, tok := range again.Tokens {
+			orig := tokens.Tokens[i]
+			if tok.Path != orig.Path || tok.ColorSpace != orig.ColorSpace || tok.Opacity() != orig.Opacity() {
+				t.Errorf("expected %+v, got %+v", orig, tok)
+			}
+			a, _ := designXyz(orig.Color)
+			c, _ := designXyz(tok.Color)
+			if math.Abs(a.X-c.X) > 1e-9 || math.Abs(a.Y-c.Y) > 1e-9 || math.Abs(a.Z-c.Z) > 1e-9 {
+				t.Errorf("%s: expected %v, got %v", tok.Path, a, c)
+			}
+		}
+	})
+
+	t.Run("legacy hex strings", func(t *testing.T) {
+		src := `{"a": {"$type": "color", "$value": "#f008"}, "b": {"$type": "color", "$value": "#abc"}}`
+		tokens, err := ReadDesignTokens(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		a, _ := tokens.Lookup("a")
+		if a.Hex != "#ff0000" || a.Alpha == nil || math.Abs(*a.Alpha-0x88/255.0) > 1e-9 {
+			t.Errorf("unexpected token %+v", a)
+		}
+		b, _ := tokens.Lookup("b")
+		if b.Hex != "#aabbcc" || b.Alpha != nil {
+			t.Errorf("unexpected token %+v", b)
+		}
+		var out bytes.Buffer
+		if err := WriteDesignTokens(&out, tokens); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.Contains(out.String(), `"hex": "#ff0000"`) || !strings.Contains(out.String(), `"hex": "#aabbcc"`) {
+			t.Errorf("hex fallback not normalized: %s", out.String())
+		}
+	})
+
+	t.Run("write go tokens", func(t *testing.T) {
+		clear := 0.0
+		tokens := &DesignTokens{Tokens: []DesignToken{
+			{Path: "color.solid", Color: RGB{255, 0, 0}},
+			{Path: "color.clear", Color: RGB{0, 0, 255}, Alpha: &clear},
+		}}
+		var b bytes.Buffer
+		if err := WriteDesignTokens(&b, tokens); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		again, err := ReadDesignTokens(&b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if solid, _ := again.Lookup("color.solid"); solid.Alpha != nil || solid.Color != (RGB{255, 0, 0}) {
+			t.Errorf("unexpected token %+v", solid)
+		}
+		if c, _ := again.Lookup("color.clear"); c.Alpha == nil || *c.Alpha != 0 {
+			t.Errorf("unexpected token %+v", c)
+		}
+	})
+
+	t.Run("invalid tokens", func(t *testing.T) {
+		for _, src := range []string{
+			`{"a": {"$type": "color", "$value": "{b}"}, "b": {"$type": "color", "$value": "{a}"}}`,
+			`{"a": {"$type": "color", "$value": "{missing}"}}`,
+			`{"a": {"$type": "color", "$value": {"colorSpace": "cmyk", "components": [0, 0, 0]}}}`,
+			`{"a": {"$type": "color", "$value": "{b}"}, "b": {"$type": "dimension", "$value": "4px"}}`,
+		} {
+			if _, err := ReadDesignTokens(strings.NewReader(src)); err == nil {
+				t.Errorf("%s: expected error", src)
+			}
+		}
+	})
+}
+
+func TestMobile(t *testing.T) {
+	t.Run("android hex", func(t *testing.T) {
+		cases := map[string]RGBA{
+			"#80FF8000": {RGB{255, 128, 0}, float32(128) / 255},
+			"#ff8000":   {RGB{255, 128, 0}, 1.0},
+			"#f80":      {RGB{255, 136, 0}, 1.0},
+			"#0f80":     {RGB{255, 136, 0}, 0},
+		}
+		for s, expected := range cases {
+			c, err := StrToAndroidHex(s)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", s, err)
+			}
+			if *c != expected {
+				t.Errorf("%s: expected %v, got %v", s, expected, *c)
+			}
+		}
+		c := RGBA{RGB{255, 128, 0}, 0.5}
+		if s := c.ToAndroidHex(); s != "#80FF8000" {
+			t.Errorf("expected #80FF8000, got %s", s)
+		}
+		if _, err := StrToAndroidHex("ff8000"); err == nil {
+			t.Error("expected error without #")
+		}
+	})
+
+	t.Run("flutter", func(t *testing.T) {
+		c := RGBA{RGB{255, 128, 0}, 1.0}
+		if s := c.ToFlutter(); s != "Color(0xFFFF8000)" {
+			t.Errorf("expected Color(0xFFFF8000), got %s", s)
+		}
+		for _, s := range []string{"const Color(0xFFFF8000)", "Color.fromARGB(255, 255, 128, 0)", "Color.fromRGBO(255, 128, 0, 1.0);"} {
+			got, err := ParseFlutterColor(s)
+			if err != nil || got != c {
+				t.Errorf("%s: expected %v, got %v (%v)", s, c, got, err)
+			}
+		}
+	})
+
+	t.Run("swiftui", func(t *testing.T) {
+		c := RGBA{RGB{255, 128, 0}, 1.0}
+		s := c.ToSwiftUI()
+		if s != "Color(red: 1.000, green: 0.502, blue: 0.000, opacity: 1.000)" {
+			t.Errorf("unexpected %s", s)
+		}
+		for _, s := range []string{s, "Color(.sRGB, red: 1, green: 128/255, blue: 0)"} {
+			got, err := ParseSwiftUIColor(s)
+			if err != nil || got != c {
+				t.Errorf("%s: expected %v, got %v (%v)", s, c, got, err)
+			}
+		}
+		if got, _ := ParseSwiftUIColor("Color(white: 0.5, opacity: 0.25)"); got != (RGBA{RGB{128, 128, 128}, 0.25}) {
+			t.Errorf("unexpected white color %v", got)
+		}
+	})
+
+	t.Run("colorset", func(t *testing.T) {
+		src := `{
+  "colors" : [
+    {"color" : {"color-space" : "srgb", "components" : {"alpha" : "1.000", "blue" : "0x00", "green" : "128", "red" : "1.000"}}, "idiom" : "universal"},
+    {"appearances" : [{"appearance" : "luminosity", "value" : "dark"}],
+     "color" : {"color-space" : "srgb", "components" : {"alpha" : "0.500", "blue" : "0.000", "green" : "0.000", "red" : "0.000"}}, "idiom" : "universal"}
+  ],
+  "info" : {"author" : "xcode", "version" : 1}
+}`
+		set, err := ReadColorset(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if set.Light != (RGBA{RGB{255, 128, 0}, 1.0}) || set.Dark == nil || *set.Dark != (RGBA{RGB{0, 0, 0}, 0.5}) {
+			t.Errorf("unexpected colorset %v %v", set.Light, set.Dark)
+		}
+		var b bytes.Buffer
+		if err := WriteColorset(&b, set); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		again, err := ReadColorset(&b)
+		if err != nil || again.Light != set.Light || *again.Dark != *set.Dark {
+			t.Errorf("round trip failed: %v %v", again, err)
+		}
+	})
+}
+
+func TestPackedPixels(t *testing.T) {
+	t.Run("pack", func(t *testing.T) {
+		c := RGBA{RGB{255, 128, 0}, 0.5}
+		cases := []struct {
+			f        PixelFormat
+			expected uint32
+		}{
+			{RGB565, 0xFC00},
+			{BGR565, 0x041F},
+			{RGB555, 0x7E00},
+			{ARGB1555, 0xFE00},
+			{ARGB4444, 0x8F80},
+			{RGBA8888, 0xFF800080},
+			{ARGB8888, 0x80FF8000},
+			{BGRA8888, 0x0080FF80},
+			{ABGR8888, 0x800080FF},
+		}
+		for _, tc := range cases {
+			if got := c.Pack(tc.f); got != tc.expected {
+				t.Errorf("%v: expected %#x, got %#x", tc.f, tc.expected, got)
+			}
+		}
+	})
+
+	t.Run("bit expansion", func(t *testing.T) {
+		white := UnpackPixel(0xFFFF, RGB565)
+		if white != (RGBA{RGB{255, 255, 255}, 1.0}) {
+			t.Errorf("expected white, got %v", white)
+		}
+		if c := UnpackPixel(0x0410, RGB565); c.RGB != (RGB{0, 130, 132}) {
+			t.Errorf("expected {0 130 132}, got %v", c.RGB)
+		}
+		for v := uint32(0); v < 1<<16; v++ {
+			c := UnpackPixel(v, RGB565)
+			if got := c.Pack(RGB565); got != v {
+				t.Fatalf("%#x: round trip gave %#x", v, got)
+			}
+		}
+	})
+
+	t.Run("bulk", func(t *testing.T) {
+		src := []RGBA{{RGB{255, 0, 0}, 1.0}, {RGB{0, 0, 255}, 0}}
+		buf := EncodePixels(nil, src, ARGB1555, binary.LittleEndian)
+		if !bytes.Equal(buf, []byte{0x00, 0xFC, 0x1F, 0x00}) {
+			t.Errorf("unexpected buffer % x", buf)
+		}
+		got, err := DecodePixels(buf, ARGB1555, binary.LittleEndian)
+		if err != nil || got[0] != src[0] || got[1] != src[1] {
+			t.Errorf("expected %v, got %v (%v)", src, got, err)
+		}
+		if _, err := DecodePixels(buf[:3], ARGB1555, binary.LittleEndian); err == nil {
+			t.Error("expected error for partial pixel")
+		}
+		if _, err := PackPixels16(src, ARGB8888); err == nil {
+			t.Error("expected error for 32 bit format")
+		}
+		words, _ := PackPixels32(src, BGRA8888)
+		if words[1] != 0xFF000000 {
+			t.Errorf("expected 0xff000000, got %#x", words[1])
+		}
+	})
+}
+
+func TestWindowsAndQt(t *testing.T) {
+	t.Run("colorref and argb", func(t *testing.T) {
+		c := RGB{255, 128, 0}
+		if v := c.ToColorref(); v != 0x000080FF {
+			t.Errorf("expected 0x80ff, got %#x", v)
+		}
+		if got := ColorrefToRgb(0x020080FF); got != c {
+			t.Errorf("expected %v, got %v", c, got)
+		}
+		red := RGBA{RGB{255, 0, 0}, 1.0}
+		if v := red.ToArgb(); v != -65536 {
+			t.Errorf("expected -65536, got %d", v)
+		}
+		if got := FromArgb(-65536); got != red {
+			t.Errorf("expected %v, got %v", red, got)
+		}
+	})
+
+	t.Run("qt names", func(t *testing.T) {
+		c := RGBA{RGB{255, 128, 0}, 0.5}
+		expected := map[QtNameFormat]string{QtHexRgb: "#ff8000", QtHexArgb: "#80ff8000", QtHex12: "#fff808000"}
+		for f, s := range expected {
+			if got := c.ToQtName(f); got != s {
+				t.Errorf("expected %s, got %s", s, got)
+			}
+		}
+		cases := map[string]RGBA{
+			"#80ff8000":     {RGB{255, 128, 0}, float32(128) / 255},
+			"#fff808000":    {RGB{255, 128, 0}, 1.0},
+			"#ffff80800000": {RGB{255, 128, 0}, 1.0},
+			"#f80":          {RGB{255, 136, 0}, 1.0},
+			"SteelBlue":     {RGB{70, 130, 180}, 1.0},
+			"transparent":   {},
+		}
+		for s, want := range cases {
+			got, err := ParseQtColor(s)
+			if err != nil || got != want {
+				t.Errorf("%s: expected %v, got %v (%v)", s, want, got, err)
+			}
+		}
+		for _, s := range []string{"#12345", "nocolor", "#gg0000"} {
+			if _, err := ParseQtColor(s); err == nil {
+				t.Errorf("%s: expected error", s)
+			}
+		}
+	})
+
+	t.Run("hslf", func(t *testing.T) {
+		c := RGBA{RGB{0, 255, 0}, 1.0}
+		h, s, l, a := c.ToHslF()
+		if math.Abs(h-1.0/3) > 1e-9 || s != 1 || l != 0.5 || a != 1 {
+			t.Errorf("unexpected hslF %v %v %v %v", h, s, l, a)
+		}
+		gray := RGBA{RGB{128, 128, 128}, 1.0}
+		if h, _, _, _ := gray.ToHslF(); h != -1 {
+			t.Errorf("expected -1 hue for gray, got %v", h)
+		}
+		for _, c := range []RGBA{{RGB{12, 200, 77}, 0.25}, {RGB{255, 128, 0}, 1.0}, gray} {
+			if got := FromHslF(c.ToHslF()); got != c {
+				t.Errorf("expected %v, got %v", c, got)
+			}
+		}
+	})
+}
+
+func TestXcolor(t *testing.T) {
+	t.Run("expressions", func(t *testing.T) {
+		cases := map[string]RGB{
+			"red!30!blue":        {77, 0, 179},
+			"red!30":             {255, 179, 179},
+			"-red":               {0, 255, 255},
+			"-red!30!blue":       {179, 255, 77},
+			"--red!30!blue":      {77, 0, 179},
+			"{red!50!black}":     {128, 0, 0},
+			"{rgb}{0.2,0.4,0.6}": {51, 102, 153},
+			"{HTML}{FF8800}":     {255, 136, 0},
+			"{cmyk}{0,1,0,0}":    {255, 0, 255},
+			"[Hsb]{120,1,1}":     {0, 255, 0},
+			"{wave}{550}":        {163, 255, 0},
+		}
+		for expr, expected := range cases {
+			got, err := ParseXcolor(expr)
+			if err != nil || got != expected {
+				t.Errorf("%s: expected %v, got %v (%v)", expr, expected, got, err)
+			}
+		}
+		if c, _ := DefaultXcolors.EvalCmyk("cyan!50"); c != (CMYK{50, 0, 0, 0}) {
+			t.Errorf("expected cmyk mix in cmyk, got %v", c)
+		}
+	})
+
+	t.Run("define", func(t *testing.T) {
+		set := NewXcolors()
+		if err := set.Define("brand", "RGB", "255,136,0"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if c, _ := set.Eval("brand!50!black"); c != (RGB{128, 68, 0}) {
+			t.Errorf("expected {128 68 0}, got %v", c)
+		}
+		for _, expr := range []string{"nocolor", "red!x!blue", "{rgb}{1,0}", "{lab}{1,2,3}", "red!150"} {
+			if _, err := set.Eval(expr); err == nil {
+				t.Errorf("%s: expected error", expr)
+			}
+		}
+	})
+
+	t.Run("definecolor", func(t *testing.T) {
+		cases := []struct {
+			model    string
+			c        any
+			expected string
+		}{
+			{"HTML", RGB{255, 136, 0}, `\definecolor{brand}{HTML}{FF8800}`},
+			{"rgb", RGB{255, 136, 0}, `\definecolor{brand}{rgb}{1,0.5333,0}`},
+			{"cmyk", CMYK{0, 50, 100, 0}, `\definecolor{brand}{cmyk}{0,0.5,1,0}`},
+			{"gray", &HSL{0, 0, 50}, `\definecolor{brand}{gray}{0.502}`},
+		}
+		for _, tc := range cases {
+			got, err := FormatDefinecolor("brand", tc.model, tc.c)
+			if err != nil || got != tc.expected {
+				t.Errorf("expected %s, got %s (%v)", tc.expected, got, err)
+			}
+		}
+	})
+}
+
+func TestDistance(t *testing.T) {
+	// pairs from Sharma, Wu and Dalal's CIEDE2000 test data
+	pairs := []struct {
+		a, b LAB
+		want float64
+	}{
+		{LAB{50, 2.6772, -79.7751}, LAB{50, 0, -82.7485}, 2.0425},
+		{LAB{50, 0, 0}, LAB{50, -1, 2}, 2.3669},
+		{LAB{50, 2.5, 0}, LAB{73, 25, -18}, 27.1492},
+		{LAB{50, 2.49, -0.001}, LAB{50, -2.49, 0.0011}, 7.2195},
+		{LAB{2.0776, 0.0795, -1.135}, LAB{0.9033, -0.0636, -0.5514}, 0.9082},
+	}
+	for _, p := range pairs {
+		if d := p.a.DeltaE2000(p.b); math.Abs(d-p.want) > 1e-4 {
+			t.Errorf("DeltaE2000(%v, %v) = %.4f, want %.4f", p.a, p.b, d, p.want)
+		}
+		if d := p.b.DeltaE2000(p.a); math.Abs(d-p.want) > 1e-4 {
+			t.Errorf("DeltaE2000 not symmetric for %v, %v", p.a, p.b)
+		}
+	}
+
+	a, b := LAB{50, 0, 0}, LAB{50, 3, 4}
+	if d := a.DeltaE76(b); math.Abs(d-5) > 1e-9 {
+		t.Errorf("DeltaE76 = %v", d)
+	}
+	if d := a.DeltaE94(b); math.Abs(d-5) > 1e-9 {
+		t.Errorf("DeltaE94 = %v", d)
+	}
+	if d := (&LAB{50, 40, 0}).DeltaE94(LAB{50, 50, 0}); math.Abs(d-10/2.8) > 1e-9 {
+		t.Errorf("DeltaE94 chroma weighting = %v", d)
+	}
+	ok := OKLAB{0.5, 0, 0}
+	if d := ok.DeltaE(OKLAB{0.5, 0.03, 0.04}); math.Abs(d-0.05) > 1e-9 {
+		t.Errorf("Oklab DeltaE = %v", d)
+	}
+
+	for _, m := range []DistanceMetric{DistanceOklab, DistanceCIE76, DistanceCIE94, DistanceCIEDE2000, DistanceRGB, DistanceCAM16UCS} {
+		if d := m.Distance(RGB{10, 20, 30}, RGB{10, 20, 30}); d != 0 {
+			t.Errorf("%s: distance to itself %v", m, d)
+		}
+		matcher := NewMatcher([]RGB{{0, 0, 0}, {255, 255, 255}, {255, 0, 0}}, m)
+		if i := matcher.Nearest(RGB{200, 200, 200}); i != 1 {
+			t.Errorf("%s: nearest to light gray is %d", m, i)
+		}
+		if i := matcher.Nearest(RGB{180, 20, 10}); i != 2 {
+			t.Errorf("%s: nearest to dark red is %d", m, i)
+		}
+	}
+	if DistanceRGB.Distance(RGB{0, 0, 0}, RGB{3, 4, 0}) != 5 {
+		t.Error("rgb distance")
+	}
+}
+
+func TestHct(t *testing.T) {
+	cases := []struct {
+		rgb RGB
+		hct HCT
+	}{
+		{RGB{255, 0, 0}, HCT{27.41, 113.36, 53.24}},
+		{RGB{0, 255, 0}, HCT{142.14, 108.41, 87.73}},
+		{RGB{0, 0, 255}, HCT{282.76, 87.23, 32.30}},
+		{RGB{255, 255, 255}, HCT{209.49, 2.87, 100.00}},
+	}
+	for _, c := range cases {
+		got := c.rgb.ToHct()
+		if math.Abs(got.H-c.hct.H) > 0.01 || math.Abs(got.C-c.hct.C) > 0.01 || math.Abs(got.T-c.hct.T) > 0.01 {
+			t.Errorf("%v.ToHct() = %s, want %s", c.rgb, got.String(), c.hct.String())
+		}
+		if back := got.ToRgb(); back != c.rgb {
+			t.Errorf("%s.ToRgb() = %v, want %v", got.String(), back, c.rgb)
+		}
+	}
+
+	// round trips across the cube
+	for r := 0; r < 256; r += 51 {
+		for g := 0; g < 256; g += 51 {
+			for b := 0; b < 256; b += 51 {
+				rgb := RGB{uint8(r), uint8(g), uint8(b)}
+				hct := rgb.ToHct()
+				if back := hct.ToRgb(); back != rgb {
+					t.Errorf("%v -> %s -> %v", rgb, hct.String(), back)
+				}
+			}
+		}
+	}
+
+	// unreachable chroma is reduced while hue and tone are kept
+	wide := HCT{282.76, 200, 50}
+	rgb := wide.ToRgb()
+	got := rgb.ToHct()
+	if math.Abs(got.T-50) > 0.5 || math.Abs(got.H-282.76) > 2 || got.C > 200 || got.C < 60 {
+		t.Errorf("clamped %s = %s", wide.String(), got.String())
+	}
+	gray := HCT{123, 0, 50}
+	if c := gray.ToRgb(); c.R != c.G || c.G != c.B || c.R != 119 {
+		t.Errorf("tone 50 gray = %v", c)
+	}
+
+	c, err := StrToHct("hct(27.41, 113.36, 53.24)")
+	if err != nil || c.ToHex() != "#ff0000" {
+		t.Errorf("StrToHct = %v, %v", c, err)
+	}
+	var _ ToHct = &OKLCH{}
+}
+
+func TestCam16(t *testing.T) {
+	near := func(a, b, tol float64) bool { return math.Abs(a-b) <= tol }
+
+	// colour-science reference values; Y_b=20 is a background L* of about 51.84
+	vc := NewViewingConditions(XYZ{0.9505, 1, 1.0888}, 318.31, lstarFromY(20), SurroundAverage, false)
+	xyz := XYZ{0.1901, 0.2, 0.2178}
+	cam := xyz.ToCam16(vc)
+	want := CAM16{J: 41.731207905126, C: 0.103355738709, H: 217.067959767393, M: 0.107436772335, S: 2.345015072980, Q: 195.371708992822}
+	if !near(cam.J, want.J, 1e-6) || !near(cam.C, want.C, 1e-6) || !near(cam.H, want.H, 1e-6) ||
+		!near(cam.M, want.M, 1e-6) || !near(cam.S, want.S, 1e-6) || !near(cam.Q, want.Q, 1e-6) {
+		t.Errorf("ToCam16 = %+v, want %+v", cam, want)
+	}
+	back := cam.ToXyz(vc)
+	if !near(back.X, xyz.X, 1e-9) || !near(back.Y, xyz.Y, 1e-9) || !near(back.Z, xyz.Z, 1e-9) {
+		t.Errorf("ToXyz = %v, want %v", back, xyz)
+	}
+
+	red := RGB{255, 0, 0}
+	rc := red.ToCam16(nil)
+	if !near(rc.J, 46.45, 0.01) || !near(rc.C, 113.36, 0.01) || !near(rc.H, 27.41, 0.01) {
+		t.Errorf("red ToCam16 = %+v", rc)
+	}
+	if rgb := rc.ToRgb(nil); rgb != red {
+		t.Errorf("red round trip = %v", rgb)
+	}
+	// hue and chroma agree with HCT under the default conditions
+	hct := red.ToHct()
+	if !near(hct.H, rc.H, 1e-9) || !near(hct.C, rc.C, 1e-9) {
+		t.Errorf("HCT %s disagrees with CAM16 %s", hct.String(), rc.String())
+	}
+
+	// a dark surround lowers the contrast, which raises the lightness of a mid gray
+	gray := XYZ{0.18 * 0.9505, 0.18, 0.18 * 1.0888}
+	dim := NewViewingConditions(IlluminantD65, 64, 20, SurroundDim, false)
+	dark := NewViewingConditions(IlluminantD65, 64, 20, SurroundDark, false)
+	if jd, jk := gray.ToCam16(dim).J, gray.ToCam16(dark).J; jk <= jd {
+		t.Errorf("dark surround J %.2f, dim surround J %.2f", jk, jd)
+	}
+
+	ucs := rc.ToUcs()
+	if !near(ucs.J, 59.59, 0.01) || !near(ucs.A, 43.30, 0.01) || !near(ucs.B, 22.45, 0.01) {
+		t.Errorf("ToUcs = %+v", ucs)
+	}
+	fromUcs := ucs.ToCam16(nil)
+	if !near(fromUcs.J, rc.J, 1e-6) || !near(fromUcs.C, rc.C, 1e-6) || !near(fromUcs.H, rc.H, 1e-6) {
+		t.Errorf("UCS round trip = %+v, want %+v", fromUcs, rc)
+	}
+	if d := ucs.DeltaE(ucs); d != 0 {
+		t.Errorf("DeltaE to itself = %v", d)
+	}
+	other := RGB{250, 10, 5}
+	oc := other.ToCam16(nil)
+	if d := ucs.DeltaE(oc.ToUcs()); !near(d, 1.149, 0.001) {
+		t.Errorf("DeltaE = %v", d)
+	}
+	if d := ucs.DeltaECorrected(oc.ToUcs()); !near(d, 1.41*math.Pow(1.149, 0.63), 0.001) {
+		t.Errorf("DeltaECorrected = %v", d)
+	}
+	if d := DistanceCAM16UCS.Distance(red, other); !near(d, 1.149, 0.001) {
+		t.Errorf("DistanceCAM16UCS = %v", d)
+	}
+
+	c, err := StrToCam16("cam16(46.45, 113.36, 27.41)")
+	if err != nil || c.String() != "cam16(46.45,113.36,27.41)" {
+		t.Errorf("StrToCam16 = %v, %v", c, err)
+	}
+}
+
+func TestHsluv(t *testing.T) {
+	near := func(a, b, tol float64) bool { return math.Abs(a-b) <= tol }
+
+	// entries of the HSLuv project's snapshot-rev4.json, including mid-gamut
+	// colors whose maximum chroma comes from an inner bound line
+	snapshot := []struct {
+		hex          string
+		hsluv, hpluv [3]float64
+	}{
+		{"#ff0000", [3]float64{12.177050630061776, 100.0000000000022, 53.23711559542933}, [3]float64{12.177050630061776, 426.7467891831252, 53.23711559542933}},
+		{"#00ff00", [3]float64{127.71501294924047, 100.00000000000222, 87.73551910965973}, [3]float64{127.71501294924047, 490.1453750637022, 87.73551910965973}},
+		{"#0000ff", [3]float64{265.8743202181779, 100.00000000000082, 32.30087290398002}, [3]float64{265.8743202181779, 513.4126968442804, 32.30087290398002}},
+		{"#000000", [3]float64{0, 0, 0}, [3]float64{0, 0, 0}},
+		{"#ffffff", [3]float64{0, 0, 100}, [3]float64{0, 0, 100}},
+		{"#11aa33", [3]float64{130.33326794825732, 97.73523714435949, 60.87539563474931}, [3]float64{130.33326794825732, 178.36108800211815, 60.87539563474931}},
+		{"#887766", [3]float64{51.66132459753881, 28.249266508872605, 51.137493218969155}, [3]float64{51.66132459753881, 46.2439140208651, 51.137493218969155}},
+		{"#3355cc", [3]float64{261.4120403511602, 82.54782640761837, 40.50779390911347}, [3]float64{261.4120403511602, 305.1705463000935, 40.50779390911347}},
+		{"#ee9911", [3]float64{43.97667828445641, 98.41522961435383, 70.17761261657718}, [3]float64{43.97667828445641, 177.15910010767405, 70.17761261657718}},
+		{"#22ffbb", [3]float64{152.70181868951565, 99.99999999999099, 89.60078750203346}, [3]float64{152.70181868951565, 361.5536980335398, 89.60078750203346}},
+		{"#cc44dd", [3]float64{300.9417733619225, 81.00009460033905, 54.23191263293795}, [3]float64{300.9417733619225, 243.66724889744043, 54.23191263293795}},
+		{"#663300", [3]float64{33.11380405317354, 100.00000000000229, 27.277270236516102}, [3]float64{33.11380405317354, 217.14741038655725, 27.277270236516102}},
+		{"#111111", [3]float64{0, 1.9241939994479228e-12, 5.0633294928927866}, [3]float64{0, 6.72041492281092e-12, 5.0633294928927866}},
+	}
+	for _, s := range snapshot {
+		c, _ := StrToHex(s.hex)
+		hsl, hpl := c.ToHsluv(), c.ToHpluv()
+		if !near(hsl.H, s.hsluv[0], 1e-9) || !near(hsl.S, s.hsluv[1], 1e-9) || !near(hsl.L, s.hsluv[2], 1e-9) {
+			t.Errorf("%s ToHsluv = %+v, want %v", s.hex, hsl, s.hsluv)
+		}
+		if !near(hpl.H, s.hpluv[0], 1e-9) || !near(hpl.P, s.hpluv[1], 1e-9) || !near(hpl.L, s.hpluv[2], 1e-9) {
+			t.Errorf("%s ToHpluv = %+v, want %v", s.hex, hpl, s.hpluv)
+		}
+		if got := hsl.ToHex(); got != s.hex {
+			t.Errorf("%s HSLuv round trip = %s", s.hex, got)
+		}
+		if got := hpl.ToHex(); got != s.hex {
+			t.Errorf("%s HPLuv round trip = %s", s.hex, got)
+		}
+	}
+
+	for r := 0; r < 256; r += 17 {
+		for g := 0; g < 256; g += 17 {
+			for b := 0; b < 256; b += 17 {
+				c := RGB{uint8(r), uint8(g), uint8(b)}
+				hsl := c.ToHsluv()
+				if hsl.S > 100+1e-6 {
+					t.Errorf("%v saturation %v", c, hsl.S)
+				}
+				if back := hsl.ToRgb(); back != c {
+					t.Errorf("%v HSLuv round trip = %v", c, back)
+				}
+				hpl := c.ToHpluv()
+				if back := hpl.ToRgb(); back != c {
+					t.Errorf("%v HPLuv round trip = %v", c, back)
+				}
+			}
+		}
+	}
+
+	// any hue at HPLuv saturation 100 stays in gamut, so the chroma survives
+	for h := 0.0; h < 360; h += 15 {
+		c := HPLUV{h, 100, 60}
+		rgb := c.ToRgb()
+		if back := rgb.ToHpluv(); !near(back.L, 60, 0.5) || !near(back.P, 100, 2) {
+			t.Errorf("HPLUV{%v,100,60} = %v, back %+v", h, rgb, back)
+		}
+	}
+
+	c, err := StrToHsluv("hsluv(12.18, 100, 53.24)")
+	if err != nil || c.String() != "hsluv(12.18,100.00,53.24)" || c.ToHex() != "#ff0000" {
+		t.Errorf("StrToHsluv = %v, %v", c, err)
+	}
+	p, err := StrToHpluv("hpluv(12.18, 426.75, 53.24)")
+	if err != nil || p.String() != "hpluv(12.18,426.75,53.24)" || p.ToHex() != "#ff0000" {
+		t.Errorf("StrToHpluv = %v, %v", p, err)
+	}
+}
+
+func TestHsvFloat(t *testing.T) {
+	h, s, v := RgbToHsvFloat(RGB{12, 200, 77})
+	if math.Abs(h-140.74) > 0.01 || math.Abs(s-0.94) > 0.001 || math.Abs(v-200.0/255) > 1e-9 {
+		t.Errorf("unexpected hsv %v %v %v", h, s, v)
+	}
+	for r := 0; r < 256; r += 5 {
+		for g := 0; g < 256; g += 3 {
+			for b := 0; b < 256; b += 5 {
+				c := RGB{uint8(r), uint8(g), uint8(b)}
+				if got := HsvFloatToRgb(RgbToHsvFloat(c)); got != c {
+					t.Fatalf("%v round trips to %v", c, got)
+				}
+			}
+		}
+	}
+	if c := HsvFloatToRgb(-120, 1, 1); c != (RGB{0, 0, 255}) {
+		t.Errorf("expected negative hue to wrap, got %v", c)
+	}
+}
@@ -0,0 +1,44 @@
+package color
+
+// ToColorref converts RGB to a Win32 COLORREF value
+// Returns:
+//   uint32: 0x00BBGGRR value as built by the RGB() macro
+// Example:
+//   c := RGB{255, 128, 0}
+//   v := c.ToColorref() // returns 0x000080FF
+func (c *RGB) ToColorref() uint32 {
+	return uint32(c.R) | uint32(c.G)<<8 | uint32(c.B)<<16
+}
+
+// ColorrefToRgb converts a Win32 COLORREF value to RGB
+// The high byte holds flags such as the palette-relative 0x02 marker and is ignored.
+// Parameters:
+//   v: 0x00BBGGRR value
+// Returns:
+//   RGB: corresponding RGB color object
+// Example:
+//   c := ColorrefToRgb(0x000080FF) // returns RGB{255,128,0}
+func ColorrefToRgb(v uint32) RGB {
+	return RGB{R: uint8(v), G: uint8(v >> 8), B: uint8(v >> 16)}
+}
+
+// ToArgb converts RGBA to the value of .NET System.Drawing.Color.ToArgb()
+// Returns:
+//   int32: 0xAARRGGBB as a signed integer, negative when alpha >= 128
+// Example:
+//   c := RGBA{RGB{255, 0, 0}, 1.0}
+//   v := c.ToArgb() // returns -65536
+func (c *RGBA) ToArgb() int32 {
+	return int32(c.Pack(ARGB8888))
+}
+
+// FromArgb converts a .NET Color.FromArgb(int) value to RGBA
+// Parameters:
+//   v: 0xAARRGGBB as a signed integer
+// Returns:
+//   RGBA: corresponding RGBA color object
+// Example:
+//   c := FromArgb(-65536) // returns RGBA{RGB{255,0,0},1.0}
+func FromArgb(v int32) RGBA {
+	return UnpackPixel(uint32(v), ARGB8888)
+}
@@ -0,0 +1,241 @@
+package color
+
+import "math"
+
+// DistanceMetric selects how the difference between two colors is measured
+type DistanceMetric int
+
+const (
+	DistanceOklab     DistanceMetric = iota // Euclidean distance in Oklab
+	DistanceCIE76                           // CIE76 ΔE*ab, Euclidean in L*a*b*
+	DistanceCIE94                           // CIE94 ΔE with graphic arts weights
+	DistanceCIEDE2000                       // CIEDE2000 ΔE00
+	DistanceRGB                             // Euclidean distance in 8 bit sRGB
+	DistanceCAM16UCS                        // CAM16-UCS ΔE', Euclidean in J'a'b' under DefaultViewingConditions
+)
+
+// String returns the metric name
+func (m DistanceMetric) String() string {
+	switch m {
+	case DistanceOklab:
+		return "oklab"
+	case DistanceCIE76:
+		return "cie76"
+	case DistanceCIE94:
+		return "cie94"
+	case DistanceCIEDE2000:
+		return "ciede2000"
+	case DistanceRGB:
+		return "rgb"
+	case DistanceCAM16UCS:
+		return "cam16-ucs"
+	}
+	return "unknown"
+}
+
+// Distance measures the difference between two sRGB colors
+// Parameters:
+//   a, b: colors to compare
+// Returns:
+//   float64: distance in the units of the metric, 0 for equal colors
+// Example:
+//   d := DistanceCIEDE2000.Distance(RGB{255,0,0}, RGB{250,10,5})
+func (m DistanceMetric) Distance(a, b RGB) float64 {
+	return m.distance(m.coords(a), m.coords(b))
+}
+
+// coords converts c into the space the metric works in
+func (m DistanceMetric) coords(c RGB) [3]float64 {
+	switch m {
+	case DistanceCIE76, DistanceCIE94, DistanceCIEDE2000:
+		lab := c.ToLab()
+		return [3]float64{lab.L, lab.A, lab.B}
+	case DistanceRGB:
+		return [3]float64{float64(c.R), float64(c.G), float64(c.B)}
+	case DistanceCAM16UCS:
+		cam := c.ToCam16(DefaultViewingConditions)
+		ucs := cam.ToUcs()
+		return [3]float64{ucs.J, ucs.A, ucs.B}
+	}
+	lab := c.ToOklab()
+	return [3]float64{lab.L, lab.A, lab.B}
+}
+
+func (m DistanceMetric) distance(a, b [3]float64) float64 {
+	switch m {
+	case DistanceCIE94:
+		return deltaE94(a, b)
+	case DistanceCIEDE2000:
+		return deltaE2000(a, b)
+	}
+	dx, dy, dz := a[0]-b[0], a[1]-b[1], a[2]-b[2]
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+// DeltaE76 returns the CIE76 color difference to another LAB color
+// Parameters:
+//   o: color to compare with
+// Returns:
+//   float64: Euclidean distance in L*a*b*, about 2.3 is a just noticeable difference
+// Example:
+//   c := LAB{50, 0, 0}
+//   d := c.DeltaE76(LAB{50, 3, 4}) // returns 5
+func (c *LAB) DeltaE76(o LAB) float64 {
+	return DistanceCIE76.distance([3]float64{c.L, c.A, c.B}, [3]float64{o.L, o.A, o.B})
+}
+
+// DeltaE94 returns the CIE94 color difference with graphic arts weights,
+// taking c as the reference color
+// Parameters:
+//   o: sample color to compare with
+// Returns:
+//   float64: CIE94 ΔE, not symmetric in c and o
+// Example:
+//   c := LAB{50, 0, 0}
+//   d := c.DeltaE94(LAB{50, 3, 4}) // returns 5
+func (c *LAB) DeltaE94(o LAB) float64 {
+	return deltaE94([3]float64{c.L, c.A, c.B}, [3]float64{o.L, o.A, o.B})
+}
+
+// DeltaE2000 returns the CIEDE2000 color difference to another LAB color
+// Parameters:
+//   o: color to compare with
+// Returns:
+//   float64: ΔE00 with unit parametric factors
+// Example:
+//   c := LAB{50, 2.6772, -79.7751}
+//   d := c.DeltaE2000(LAB{50, 0, -82.7485}) // returns 2.0425
+func (c *LAB) DeltaE2000(o LAB) float64 {
+	return deltaE2000([3]float64{c.L, c.A, c.B}, [3]float64{o.L, o.A, o.B})
+}
+
+// DeltaE returns the Euclidean distance to another OKLAB color
+// Parameters:
+//   o: color to compare with
+// Returns:
+//   float64: ΔEok, about 0.02 is a just noticeable difference
+// Example:
+//   c := OKLAB{0.5, 0, 0}
+//   d := c.DeltaE(OKLAB{0.5, 0.03, 0.04}) // returns 0.05
+func (c *OKLAB) DeltaE(o OKLAB) float64 {
+	return DistanceOklab.distance([3]float64{c.L, c.A, c.B}, [3]float64{o.L, o.A, o.B})
+}
+
+func deltaE94(ref, s [3]float64) float64 {
+	dl := ref[0] - s[0]
+	c1 := math.Hypot(ref[1], ref[2])
+	c2 := math.Hypot(s[1], s[2])
+	dc := c1 - c2
+	da, db := ref[1]-s[1], ref[2]-s[2]
+	dh2 := math.Max(0, da*da+db*db-dc*dc)
+	sc := 1 + 0.045*c1
+	sh := 1 + 0.015*c1
+	return math.Sqrt(dl*dl + dc*dc/(sc*sc) + dh2/(sh*sh))
+}
+
+// deltaE2000 follows Sharma, Wu and Dalal's implementation notes
+func deltaE2000(x, y [3]float64) float64 {
+	rad := math.Pi / 180
+	cbar := (math.Hypot(x[1], x[2]) + math.Hypot(y[1], y[2])) / 2
+	c7 := math.Pow(cbar, 7)
+	g := 0.5 * (1 - math.Sqrt(c7/(c7+math.Pow(25, 7))))
+	a1, a2 := x[1]*(1+g), y[1]*(1+g)
+	c1, c2 := math.Hypot(a1, x[2]), math.Hypot(a2, y[2])
+	hue := func(a, b float64) float64 {
+		if a == 0 && b == 0 {
+			return 0
+		}
+		h := math.Atan2(b, a) / rad
+		if h < 0 {
+			h += 360
+		}
+		return h
+	}
+	h1, h2 := hue(a1, x[2]), hue(a2, y[2])
+
+	dl := y[0] - x[0]
+	dc := c2 - c1
+	var dh float64
+	if c1*c2 != 0 {
+		dh = h2 - h1
+		if dh > 180 {
+			dh -= 360
+		} else if dh < -180 {
+			dh += 360
+		}
+	}
+	dH := 2 * math.Sqrt(c1*c2) * math.Sin(dh/2*rad)
+
+	lbar := (x[0] + y[0]) / 2
+	cbarp := (c1 + c2) / 2
+	hbar := h1 + h2
+	if c1*c2 != 0 {
+		switch {
+		case math.Abs(h1-h2) <= 180:
+			hbar /= 2
+		case h1+h2 < 360:
+			hbar = (hbar + 360) / 2
+		default:
+			hbar = (hbar - 360) / 2
+		}
+	}
+	t := 1 - 0.17*math.Cos((hbar-30)*rad) + 0.24*math.Cos(2*hbar*rad) +
+		0.32*math.Cos((3*hbar+6)*rad) - 0.20*math.Cos((4*hbar-63)*rad)
+	dtheta := 30 * math.Exp(-math.Pow((hbar-275)/25, 2))
+	cp7 := math.Pow(cbarp, 7)
+	rc := 2 * math.Sqrt(cp7/(cp7+math.Pow(25, 7)))
+	l50 := (lbar - 50) * (lbar - 50)
+	sl := 1 + 0.015*l50/math.Sqrt(20+l50)
+	sc := 1 + 0.045*cbarp
+	sh := 1 + 0.015*cbarp*t
+	rt := -math.Sin(2*dtheta*rad) * rc
+
+	lt, ct, ht := dl/sl, dc/sc, dH/sh
+	return math.Sqrt(lt*lt + ct*ct + ht*ht + rt*ct*ht)
+}
+
+// Matcher finds the closest palette color under a distance metric. The
+// palette is converted once and results are cached per color, so a Matcher
+// must not be shared between goroutines.
+type Matcher struct {
+	metric DistanceMetric
+	coords [][3]float64
+	cache  map[RGB]int
+}
+
+// NewMatcher prepares a palette for nearest color lookups
+// Parameters:
+//   palette: candidate colors
+//   metric: distance used to rank the candidates
+// Returns:
+//   *Matcher: matcher over the palette
+// Example:
+//   m := NewMatcher([]RGB{{0,0,0}, {255,255,255}}, DistanceCIEDE2000)
+//   i := m.Nearest(RGB{200,200,200}) // returns 1
+func NewMatcher(palette []RGB, metric DistanceMetric) *Matcher {
+	m := &Matcher{metric: metric, coords: make([][3]float64, len(palette)), cache: map[RGB]int{}}
+	for i, c := range palette {
+		m.coords[i] = metric.coords(c)
+	}
+	return m
+}
+
+// Nearest returns the index of the palette color closest to c
+// Parameters:
+//   c: color to match
+// Returns:
+//   int: palette index, -1 for an empty palette
+func (m *Matcher) Nearest(c RGB) int {
+	if i, ok := m.cache[c]; ok {
+		return i
+	}
+	p := m.metric.coords(c)
+	best, bestDist := -1, math.Inf(1)
+	for i, q := range m.coords {
+		if d := m.metric.distance(q, p); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	m.cache[c] = best
+	return best
+}
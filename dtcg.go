@@ -0,0 +1,546 @@
+package color
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// DesignToken is a color token of a Design Tokens Community Group (DTCG) file
+// Color holds the value in the typed space closest to ColorSpace: RGB for
+// srgb, HSL for hsl, LAB for lab and lch, OKLAB, OKLCH, and XYZ (D65) for
+// the XYZ and wide-gamut RGB spaces; hwb is read as RGB.
+type DesignToken struct {
+	Path        string   // dot separated group path, e.g. "color.brand.500"
+	Description string   // optional $description
+	ColorSpace  string   // DTCG colorSpace the value is read from or written in
+	Color       any      // typed color value
+	Alpha       *float64 // 0-1 opacity, nil for an opaque color
+	Hex         string   // optional "#rrggbb" sRGB fallback
+	Alias       string   // referenced token path when $value is an alias
+}
+
+// Opacity returns the token's alpha, 1 when Alpha is nil
+// Returns:
+//   float64: 0-1 opacity
+func (t *DesignToken) Opacity() float64 {
+	if t.Alpha == nil {
+		return 1.0
+	}
+	return *t.Alpha
+}
+
+// DesignTokens is the list of color tokens of a DTCG file sorted by path
+type DesignTokens struct {
+	Tokens []DesignToken
+}
+
+// rgbColorSpace describes an RGB space of the DTCG color type
+type rgbColorSpace struct {
+	toXyz   [3][3]float64 // linear RGB to XYZ (D65)
+	fromXyz [3][3]float64
+	decode  func(float64) float64 // transfer curve to linear light
+	encode  func(float64) float64
+}
+
+// rgbSpaceMatrix builds the linear RGB to XYZ (D65) matrix from the xy
+// chromaticities of the primaries and the white point of a space
+func rgbSpaceMatrix(rx, ry, gx, gy, bx, by float64, white XYZ) [3][3]float64 {
+	r, g, b := WhitePointFromXy(rx, ry), WhitePointFromXy(gx, gy), WhitePointFromXy(bx, by)
+	p := [3][3]float64{{r.X, g.X, b.X}, {r.Y, g.Y, b.Y}, {r.Z, g.Z, b.Z}}
+	sr, sg, sb := mulMat3Vec(invertMat3(p), white.X, white.Y, white.Z)
+	m := mulMat3(p, [3][3]float64{{sr, 0, 0}, {0, sg, 0}, {0, 0, sb}})
+	if white != IlluminantD65 {
+		m = mulMat3(AdaptationMatrix(white, IlluminantD65, Bradford), m)
+	}
+	return m
+}
+
+// signedCurve applies a transfer function to |v| and keeps the sign,
+// as CSS Color 4 does for extended-range values
+func signedCurve(f func(float64) float64) func(float64) float64 {
+	return func(v float64) float64 {
+		if v < 0 {
+			return -f(-v)
+		}
+		return f(v)
+	}
+}
+
+func newRgbColorSpace(toXyz [3][3]float64, decode, encode func(float64) float64) rgbColorSpace {
+	return rgbColorSpace{toXyz, invertMat3(toXyz), signedCurve(decode), signedCurve(encode)}
+}
+
+// rgbColorSpaces are the RGB spaces of the DTCG color type besides srgb
+var rgbColorSpaces = map[string]rgbColorSpace{
+	"srgb-linear": newRgbColorSpace(srgbToXyzMatrix,
+		func(v float64) float64 { return v },
+		func(v float64) float64 { return v }),
+	"display-p3": newRgbColorSpace(rgbSpaceMatrix(0.680, 0.320, 0.265, 0.690, 0.150, 0.060, IlluminantD65),
+		srgbToLinear, linearToSrgb),
+	"a98-rgb": newRgbColorSpace(rgbSpaceMatrix(0.640, 0.330, 0.210, 0.710, 0.150, 0.060, IlluminantD65),
+		func(v float64) float64 { return math.Pow(v, 563.0/256.0) },
+		func(v float64) float64 { return math.Pow(v, 256.0/563.0) }),
+	"prophoto-rgb": newRgbColorSpace(rgbSpaceMatrix(0.734699, 0.265301, 0.159597, 0.840403, 0.036598, 0.000105, IlluminantD50),
+		func(v float64) float64 {
+			if v <= 16.0/512.0 {
+				return v / 16
+			}
+			return math.Pow(v, 1.8)
+		},
+		func(v float64) float64 {
+			if v < 1.0/512.0 {
+				return v * 16
+			}
+			return math.Pow(v, 1/1.8)
+		}),
+	"rec2020": newRgbColorSpace(rgbSpaceMatrix(0.708, 0.292, 0.170, 0.797, 0.131, 0.046, IlluminantD65),
+		func(v float64) float64 {
+			if v < 0.018053968510807*4.5 {
+				return v / 4.5
+			}
+			return math.Pow((v+0.09929682680944)/1.09929682680944, 1/0.45)
+		},
+		func(v float64) float64 {
+			if v < 0.018053968510807 {
+				return v * 4.5
+			}
+			return 1.09929682680944*math.Pow(v, 0.45) - 0.09929682680944
+		}),
+}
+
+type dtcgColorValue struct {
+	ColorSpace string   `json:"colorSpace"`
+	Components []any    `json:"components"`
+	Alpha      *float64 `json:"alpha,omitempty"`
+	Hex        string   `json:"hex,omitempty"`
+}
+
+// dtcgRawToken is a token of any type collected while walking the groups
+type dtcgRawToken struct {
+	typ         string
+	value       json.RawMessage
+	description string
+}
+
+// ReadDesignTokens reads the color tokens of a DTCG JSON file
+// Group $type values are inherited, {alias} references are resolved across
+// the whole file and tokens of other types are skipped. String values in
+// "#rrggbb" or "#rrggbbaa" form from older drafts are accepted as srgb.
+// Parameters:
+//   r: reader with the token JSON
+// Returns:
+//   *DesignTokens: color tokens sorted by path
+//   error: JSON error, unknown color space, or broken or circular alias
+// Example:
+//   f, _ := os.Open("tokens.json")
+//   tokens, err := ReadDesignTokens(f)
+//   t, _ := tokens.Lookup("color.brand.500")
+func ReadDesignTokens(r io.Reader) (*DesignTokens, error) {
+	var root map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&root); err != nil {
+		return nil, fmt.Errorf("invalid design token file: %w", err)
+	}
+	raw := map[string]dtcgRawToken{}
+	if err := collectDesignTokens(root, "", "", raw); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(raw))
+	for path := range raw {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	tokens := &DesignTokens{}
+	for _, path := range paths {
+		tok := raw[path]
+		target, alias, err := resolveDesignAlias(raw, path)
+		if err != nil {
+			return nil, err
+		}
+		if tok.typ == "" {
+			tok.typ = target.typ
+		}
+		if tok.typ != "color" {
+			continue
+		}
+		if target.typ != "color" {
+			return nil, fmt.Errorf("design token %s references %s of type %q", path, alias, target.typ)
+		}
+		t, err := parseDesignColor(target.value)
+		if err != nil {
+			return nil, fmt.Errorf("design token %s: %w", path, err)
+		}
+		t.Path, t.Description, t.Alias = path, tok.description, alias
+		tokens.Tokens = append(tokens.Tokens, t)
+	}
+	return tokens, nil
+}
+
+// collectDesignTokens walks a group and records every token by path
+func collectDesignTokens(group map[string]json.RawMessage, prefix, typ string, out map[string]dtcgRawToken) error {
+	if t, ok := group["$type"]; ok {
+		if err := json.Unmarshal(t, &typ); err != nil {
+			return fmt.Errorf("invalid $type in %q: %w", prefix, err)
+		}
+	}
+	for name, raw := range group {
+		if strings.HasPrefix(name, "$") {
+			continue
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		var node map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &node); err != nil {
+			return fmt.Errorf("invalid design token node %s: %w", path, err)
+		}
+		value, ok := node["$value"]
+		if !ok {
+			if err := collectDesignTokens(node, path, typ, out); err != nil {
+				return err
+			}
+			continue
+		}
+		tok := dtcgRawToken{typ: typ, value: value}
+		if t, ok := node["$type"]; ok {
+			json.Unmarshal(t, &tok.typ)
+		}
+		if d, ok := node["$description"]; ok {
+			json.Unmarshal(d, &tok.description)
+		}
+		out[path] = tok
+	}
+	return nil
+}
+
+// designAlias returns the referenced path of a "{group.token}" value
+func designAlias(value json.RawMessage) (string, bool) {
+	var s string
+	if json.Unmarshal(value, &s) != nil || len(s) < 3 || s[0] != '{' || s[len(s)-1] != '}' {
+		return "", false
+	}
+	return s[1 : len(s)-1], true
+}
+
+// resolveDesignAlias follows alias chains to the token holding a value
+// It returns the first referenced path, or "" when the token is not an alias.
+func resolveDesignAlias(raw map[string]dtcgRawToken, path string) (dtcgRawToken, string, error) {
+	tok := raw[path]
+	first, ok := designAlias(tok.value)
+	if !ok {
+		return tok, "", nil
+	}
+	seen := map[string]bool{path: true}
+	for ref, ok := first, true; ok; ref, ok = designAlias(tok.value) {
+		if seen[ref] {
+			return tok, "", fmt.Errorf("circular alias in design token %s", path)
+		}
+		seen[ref] = true
+		next, found := raw[ref]
+		if !found {
+			return tok, "", fmt.Errorf("design token %s references unknown token %s", path, ref)
+		}
+		if next.typ == "" {
+			next.typ = tok.typ
+		}
+		tok = next
+	}
+	return tok, first, nil
+}
+
+// parseDesignColor converts a DTCG color $value to a typed color
+func parseDesignColor(value json.RawMessage) (DesignToken, error) {
+	var s string
+	if json.Unmarshal(value, &s) == nil {
+		r, g, b, a, err := hexToRGBA(s)
+		if err != nil {
+			return DesignToken{}, err
+		}
+		rgb := RGB{R: r, G: g, B: b}
+		t := DesignToken{ColorSpace: "srgb", Color: rgb, Hex: rgb.ToHex()}
+		if a != 255 {
+			alpha := float64(a) / 255
+			t.Alpha = &alpha
+		}
+		return t, nil
+	}
+
+	var v dtcgColorValue
+	if err := json.Unmarshal(value, &v); err != nil {
+		return DesignToken{}, fmt.Errorf("invalid color value: %w", err)
+	}
+	if len(v.Components) != 3 {
+		return DesignToken{}, fmt.Errorf("color space %q needs 3 components, got %d", v.ColorSpace, len(v.Components))
+	}
+	var c [3]float64
+	for i, comp := range v.Components {
+		switch comp := comp.(type) {
+		case float64:
+			c[i] = comp
+		case string:
+			if comp != "none" {
+				return DesignToken{}, fmt.Errorf("invalid color component %q", comp)
+			}
+		default:
+			return DesignToken{}, fmt.Errorf("invalid color component %v", comp)
+		}
+	}
+	t := DesignToken{ColorSpace: v.ColorSpace, Alpha: v.Alpha}
+	// the fallback is kept as "#rrggbb", alpha only goes in Alpha
+	if v.Hex != "" {
+		r, g, b, _, err := hexToRGBA(v.Hex)
+		if err != nil {
+			return DesignToken{}, fmt.Errorf("invalid hex fallback %q: %w", v.Hex, err)
+		}
+		rgb := RGB{R: r, G: g, B: b}
+		t.Hex = rgb.ToHex()
+	}
+
+	switch v.ColorSpace {
+	case "srgb":
+		t.Color = RGB{R: unitToUint8(c[0]), G: unitToUint8(c[1]), B: unitToUint8(c[2])}
+	case "hsl":
+		h := math.Mod(math.Round(c[0]), 360)
+		if h < 0 {
+			h += 360
+		}
+		t.Color = HSL{H: uint32(h), S: uint32(math.Round(math.Max(0, math.Min(100, c[1])))), L: uint32(math.Round(math.Max(0, math.Min(100, c[2]))))}
+	case "hwb":
+		t.Color = hwbToRgb(c[0], c[1]/100, c[2]/100)
+	case "lab":
+		t.Color = LAB{L: c[0], A: c[1], B: c[2]}
+	case "lch":
+		h := c[2] * math.Pi / 180
+		t.Color = LAB{L: c[0], A: c[1] * math.Cos(h), B: c[1] * math.Sin(h)}
+	case "oklab":
+		t.Color = OKLAB{L: c[0], A: c[1], B: c[2]}
+	case "oklch":
+		t.Color = OKLCH{L: c[0], C: c[1], H: c[2]}
+	case "xyz-d65":
+		t.Color = XYZ{X: c[0], Y: c[1], Z: c[2]}
+	case "xyz-d50":
+		d50 := XYZ{X: c[0], Y: c[1], Z: c[2]}
+		t.Color = d50.Adapt(IlluminantD50, IlluminantD65, Bradford)
+	default:
+		space, ok := rgbColorSpaces[v.ColorSpace]
+		if !ok {
+			return DesignToken{}, fmt.Errorf("unknown color space %q", v.ColorSpace)
+		}
+		x, y, z := mulMat3Vec(space.toXyz, space.decode(c[0]), space.decode(c[1]), space.decode(c[2]))
+		t.Color = XYZ{X: x, Y: y, Z: z}
+	}
+	return t, nil
+}
+
+// hwbToRgb converts hue in degrees and 0-1 whiteness and blackness to sRGB
+func hwbToRgb(h, w, b float64) RGB {
+	if w+b >= 1 {
+		g := unitToUint8(w / (w + b))
+		return RGB{R: g, G: g, B: g}
+	}
+	channel := func(n float64) float64 {
+		k := math.Mod(n+h/30, 12)
+		if k < 0 {
+			k += 12
+		}
+		v := 0.5 - 0.5*math.Max(-1, math.Min(math.Min(k-3, 9-k), 1))
+		return v*(1-w-b) + w
+	}
+	return RGB{R: unitToUint8(channel(0)), G: unitToUint8(channel(8)), B: unitToUint8(channel(4))}
+}
+
+// Lookup returns the token with the given dot separated path
+// Parameters:
+//   path: token path, e.g. "color.brand.500"
+// Returns:
+//   *DesignToken: the token, nil if missing
+//   bool: whether the token exists
+func (t *DesignTokens) Lookup(path string) (*DesignToken, bool) {
+	for i := range t.Tokens {
+		if t.Tokens[i].Path == path {
+			return &t.Tokens[i], true
+		}
+	}
+	return nil, false
+}
+
+// WriteDesignTokens writes color tokens as DTCG JSON nested by path
+// Aliases are written as "{path}" references. Tokens without a ColorSpace
+// are written in the space matching their Color type, srgb for the others.
+// A "#rrggbb" fallback is added when Hex is empty.
+// Parameters:
+//   w: destination for the JSON
+//   t: tokens to write
+// Returns:
+//   error: unsupported color or space, path conflict, or write error
+func WriteDesignTokens(w io.Writer, t *DesignTokens) error {
+	root := map[string]any{}
+	for _, tok := range t.Tokens {
+		node := map[string]any{"$type": "color"}
+		if tok.Description != "" {
+			node["$description"] = tok.Description
+		}
+		if tok.Alias != "" {
+			node["$value"] = "{" + tok.Alias + "}"
+		} else {
+			value, err := formatDesignColor(tok)
+			if err != nil {
+				return fmt.Errorf("design token %s: %w", tok.Path, err)
+			}
+			node["$value"] = value
+		}
+
+		group := root
+		parts := strings.Split(tok.Path, ".")
+		for _, name := range parts[:len(parts)-1] {
+			child, ok := group[name].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				group[name] = child
+			} else if _, isToken := child["$value"]; isToken {
+				return fmt.Errorf("design token path %s conflicts with a token", tok.Path)
+			}
+			group = child
+		}
+		if _, exists := group[parts[len(parts)-1]]; exists {
+			return fmt.Errorf("design token path %s conflicts with another token or group", tok.Path)
+		}
+		group[parts[len(parts)-1]] = node
+	}
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// designXyz converts a typed token color to XYZ (D65)
+func designXyz(c any) (XYZ, error) {
+	switch c := c.(type) {
+	case XYZ:
+		return c, nil
+	case RGB:
+		return c.ToXyz(), nil
+	case HSL:
+		return c.ToXyz(), nil
+	case HSV:
+		return c.ToXyz(), nil
+	case CMYK:
+		return c.ToXyz(), nil
+	case LAB:
+		return c.ToXyz(), nil
+	case OKLAB:
+		return c.ToXyz(), nil
+	case OKLCH:
+		return c.ToXyz(), nil
+	case ToXyz:
+		return c.ToXyz(), nil
+	}
+	return XYZ{}, fmt.Errorf("unsupported design token color: %T", c)
+}
+
+// formatDesignColor builds the DTCG color $value of a token
+func formatDesignColor(t DesignToken) (dtcgColorValue, error) {
+	xyz, err := designXyz(t.Color)
+	if err != nil {
+		return dtcgColorValue{}, err
+	}
+	space := t.ColorSpace
+	if space == "" {
+		switch t.Color.(type) {
+		case HSL:
+			space = "hsl"
+		case LAB:
+			space = "lab"
+		case OKLAB:
+			space = "oklab"
+		case OKLCH:
+			space = "oklch"
+		case XYZ:
+			space = "xyz-d65"
+		default:
+			space = "srgb"
+		}
+	}
+
+	var c [3]float64
+	switch space {
+	case "srgb":
+		if rgb, ok := t.Color.(RGB); ok {
+			c = [3]float64{float64(rgb.R) / 255, float64(rgb.G) / 255, float64(rgb.B) / 255}
+		} else {
+			r, g, b := mulMat3Vec(xyzToSrgbMatrix, xyz.X, xyz.Y, xyz.Z)
+			c = [3]float64{linearToSrgb(r), linearToSrgb(g), linearToSrgb(b)}
+		}
+	case "hsl":
+		hsl, ok := t.Color.(HSL)
+		if !ok {
+			hsl = xyz.ToHsl()
+		}
+		c = [3]float64{float64(hsl.H), float64(hsl.S), float64(hsl.L)}
+	case "hwb":
+		rgb := xyz.ToRgb()
+		hsl := rgb.ToHsl()
+		hi := math.Max(float64(rgb.R), math.Max(float64(rgb.G), float64(rgb.B)))
+		lo := math.Min(float64(rgb.R), math.Min(float64(rgb.G), float64(rgb.B)))
+		c = [3]float64{float64(hsl.H), lo / 255 * 100, (1 - hi/255) * 100}
+	case "lab", "lch":
+		lab, ok := t.Color.(LAB)
+		if !ok {
+			lab = xyz.ToLab()
+		}
+		c = [3]float64{lab.L, lab.A, lab.B}
+		if space == "lch" {
+			h := math.Atan2(lab.B, lab.A) * 180 / math.Pi
+			if h < 0 {
+				h += 360
+			}
+			c = [3]float64{lab.L, math.Hypot(lab.A, lab.B), h}
+		}
+	case "oklab":
+		lab, ok := t.Color.(OKLAB)
+		if !ok {
+			lab = xyz.ToOklab()
+		}
+		c = [3]float64{lab.L, lab.A, lab.B}
+	case "oklch":
+		lch, ok := t.Color.(OKLCH)
+		if !ok {
+			lch = xyz.ToOklch()
+		}
+		c = [3]float64{lch.L, lch.C, lch.H}
+	case "xyz-d65":
+		c = [3]float64{xyz.X, xyz.Y, xyz.Z}
+	case "xyz-d50":
+		d50 := xyz.Adapt(IlluminantD65, IlluminantD50, Bradford)
+		c = [3]float64{d50.X, d50.Y, d50.Z}
+	default:
+		rs, ok := rgbColorSpaces[space]
+		if !ok {
+			return dtcgColorValue{}, fmt.Errorf("unknown color space %q", space)
+		}
+		r, g, b := mulMat3Vec(rs.fromXyz, xyz.X, xyz.Y, xyz.Z)
+		c = [3]float64{rs.encode(r), rs.encode(g), rs.encode(b)}
+	}
+
+	v := dtcgColorValue{ColorSpace: space, Components: []any{c[0], c[1], c[2]}, Hex: xyz.ToHex()}
+	if t.Hex != "" {
+		r, g, b, _, err := hexToRGBA(t.Hex)
+		if err != nil {
+			return dtcgColorValue{}, fmt.Errorf("invalid hex fallback %q: %w", t.Hex, err)
+		}
+		v.Hex = fmt.Sprintf("#%02x%02x%02x", r, g, b)
+	}
+	if t.Alpha != nil && *t.Alpha != 1 {
+		alpha := *t.Alpha
+		v.Alpha = &alpha
+	}
+	return v, nil
+}
@@ -0,0 +1,152 @@
+package color
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// gitAttributes maps git color attribute names to their AnsiStyle field
+var gitAttributes = []struct {
+	name  string
+	field func(*AnsiStyle) *bool
+}{
+	{"bold", func(s *AnsiStyle) *bool { return &s.Bold }},
+	{"dim", func(s *AnsiStyle) *bool { return &s.Faint }},
+	{"italic", func(s *AnsiStyle) *bool { return &s.Italic }},
+	{"ul", func(s *AnsiStyle) *bool { return &s.Underline }},
+	{"blink", func(s *AnsiStyle) *bool { return &s.Blink }},
+	{"reverse", func(s *AnsiStyle) *bool { return &s.Inverse }},
+	{"strike", func(s *AnsiStyle) *bool { return &s.Strike }},
+}
+
+// ParseGitColor parses a git config color value such as "bold red ul #ff0ab3"
+// The first color is the foreground and the second the background. Colors
+// are "normal", "default", the eight basic names with an optional "bright"
+// prefix, 0-255 or "#rrggbb"/"#rgb". Attributes may be negated with "no" or
+// "no-"; "reset" is accepted and ignored.
+// Parameters:
+//   value: git color value
+// Returns:
+//   AnsiStyle: parsed style
+//   error: error on unknown words or more than two colors
+// Example:
+//   s, err := ParseGitColor("bold red ul #ff0ab3")
+func ParseGitColor(value string) (AnsiStyle, error) {
+	var s AnsiStyle
+	colors := 0
+	for _, word := range strings.Fields(value) {
+		if word == "reset" {
+			continue
+		}
+		if c, ok, err := parseGitColorWord(word); ok || err != nil {
+			if err != nil {
+				return AnsiStyle{}, err
+			}
+			switch colors {
+			case 0:
+				s.Fg = c
+			case 1:
+				s.Bg = c
+			default:
+				return AnsiStyle{}, fmt.Errorf("too many colors in git color: %s", value)
+			}
+			colors++
+			continue
+		}
+		on := true
+		name := word
+		if strings.HasPrefix(name, "no") {
+			name = strings.TrimPrefix(strings.TrimPrefix(name, "no"), "-")
+			on = false
+		}
+		if name == "underline" {
+			name = "ul"
+		}
+		found := false
+		for _, attr := range gitAttributes {
+			if attr.name == name {
+				*attr.field(&s) = on
+				found = true
+			}
+		}
+		if !found {
+			return AnsiStyle{}, fmt.Errorf("invalid git color word: %s", word)
+		}
+	}
+	return s, nil
+}
+
+// parseGitColorWord parses a single color word, reporting false if the word
+// is not a color at all
+func parseGitColorWord(word string) (AnsiColor, bool, error) {
+	if word == "normal" || word == "default" {
+		return AnsiColor{}, true, nil
+	}
+	if strings.HasPrefix(word, "#") {
+		r, g, b, _, err := hexToRGBA(word)
+		if err != nil || (len(word) != 4 && len(word) != 7) {
+			return AnsiColor{}, false, fmt.Errorf("invalid git color: %s", word)
+		}
+		return AnsiColor{Kind: AnsiColorRGB, RGB: RGB{r, g, b}}, true, nil
+	}
+	if n, err := strconv.Atoi(word); err == nil {
+		if n < 0 || n > 255 {
+			return AnsiColor{}, false, fmt.Errorf("invalid git color: %s", word)
+		}
+		return AnsiColor{Kind: AnsiColorIndexed, Index: uint8(n)}, true, nil
+	}
+	name, bright := strings.CutPrefix(word, "bright")
+	for i, n := range ansiColorNames {
+		if n == name {
+			if bright {
+				i += 8
+			}
+			return AnsiColor{Kind: AnsiColorIndexed, Index: uint8(i)}, true, nil
+		}
+	}
+	return AnsiColor{}, false, nil
+}
+
+// FormatGitColor formats a style as a git config color value
+// Parameters:
+//   s: style to format
+// Returns:
+//   string: git color value, "normal" for the default style
+// Example:
+//   s := AnsiStyle{Fg: AnsiColor{Kind: AnsiColorIndexed, Index: 1}, Bold: true}
+//   FormatGitColor(s) // returns "red bold"
+func FormatGitColor(s AnsiStyle) string {
+	var words []string
+	if s.Fg.Kind != AnsiColorDefault || s.Bg.Kind != AnsiColorDefault {
+		words = append(words, formatGitColorWord(s.Fg))
+	}
+	if s.Bg.Kind != AnsiColorDefault {
+		words = append(words, formatGitColorWord(s.Bg))
+	}
+	for _, attr := range gitAttributes {
+		if *attr.field(&s) {
+			words = append(words, attr.name)
+		}
+	}
+	if len(words) == 0 {
+		return "normal"
+	}
+	return strings.Join(words, " ")
+}
+
+func formatGitColorWord(c AnsiColor) string {
+	switch c.Kind {
+	case AnsiColorIndexed:
+		switch {
+		case c.Index < 8:
+			return ansiColorNames[c.Index]
+		case c.Index < 16:
+			return "bright" + ansiColorNames[c.Index-8]
+		}
+		return strconv.Itoa(int(c.Index))
+	case AnsiColorRGB:
+		return c.RGB.ToHex()
+	}
+	return "normal"
+}
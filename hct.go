@@ -0,0 +1,249 @@
+package color
+
+import (
+	"fmt"
+	"math"
+)
+
+// HCT is Google's hue, chroma and tone space used by Material Design: hue
+// and chroma come from CAM16 under sRGB viewing conditions and tone is
+// CIE L*, so tones map directly to contrast
+type HCT struct {
+	H, C, T float64
+}
+
+// StrToHct converts an hct() format string to HCT object
+// Parameters:
+//   str: string in "hct(h,c,t)" format
+// Returns:
+//   *HCT: pointer to HCT object
+//   error: parsing error if format is invalid
+// Example:
+//   c, err := StrToHct("hct(27.41,113.36,53.24)") // red
+func StrToHct(str string) (*HCT, error) {
+	var h, ch, t float64
+	_, err := fmt.Sscanf(RemoveSpace(str), "hct(%f,%f,%f)", &h, &ch, &t)
+	if err != nil {
+		return nil, err
+	}
+	return &HCT{H: h, C: ch, T: t}, nil
+}
+
+// String converts HCT object to hct() format string
+// Returns:
+//   string: "hct(h,c,t)" formatted string
+// Example:
+//   c := HCT{27.41, 113.36, 53.24}
+//   fmt.Println(c.String()) // outputs "hct(27.41,113.36,53.24)"
+func (c *HCT) String() string {
+	return fmt.Sprintf("hct(%.2f,%.2f,%.2f)", c.H, c.C, c.T)
+}
+
+// ToRgb converts HCT to sRGB. When the chroma is not reachable at the
+// given hue and tone, the most chromatic sRGB color with that hue and
+// tone is returned instead.
+// Returns:
+//   RGB: corresponding RGB color object
+// Example:
+//   c := HCT{282.76, 87.23, 32.30} // blue
+//   rgb := c.ToRgb() // returns RGB{0,0,255}
+//   c = HCT{282.76, 200, 50}
+//   rgb = c.ToRgb() // returns the most chromatic tone 50 blue
+func (c *HCT) ToRgb() RGB {
+	lr, lg, lb := hctToLinear(c.H, c.C, c.T)
+	return RGB{unitToUint8(linearToSrgb(lr)), unitToUint8(linearToSrgb(lg)), unitToUint8(linearToSrgb(lb))}
+}
+
+// ToRgba converts HCT to RGBA with full opacity
+// Returns:
+//   RGBA: RGBA object with alpha=1.0
+// Example:
+//   c := HCT{0, 0, 0} // black
+//   rgba := c.ToRgba() // returns RGBA{RGB{0,0,0},1.0}
+func (c *HCT) ToRgba() RGBA {
+	return RGBA{c.ToRgb(), 1.0}
+}
+
+// ToHex converts HCT to hexadecimal string
+// Returns:
+//   string: "#RRGGBB" format string
+// Example:
+//   c := HCT{282.76, 87.23, 32.30} // blue
+//   hex := c.ToHex() // returns "#0000ff"
+func (c *HCT) ToHex() string {
+	rgb := c.ToRgb()
+	return rgb.ToHex()
+}
+
+// ToHsl converts HCT to HSL representation
+// Returns:
+//   HSL: corresponding HSL color object
+// Example:
+//   c := HCT{27.41, 113.36, 53.24} // red
+//   hsl := c.ToHsl() // returns HSL{0,100,50}
+func (c *HCT) ToHsl() HSL {
+	rgb := c.ToRgb()
+	return rgb.ToHsl()
+}
+
+// ToHsla converts HCT to HSLA with full opacity
+// Returns:
+//   HSLA: HSLA object with alpha=1.0
+// Example:
+//   c := HCT{27.41, 113.36, 53.24} // red
+//   hsla := c.ToHsla() // returns HSLA{HSL{0,100,50},1.0}
+func (c *HCT) ToHsla() HSLA {
+	rgb := c.ToRgb()
+	return rgb.ToHsla()
+}
+
+// ToHsv converts HCT to HSV representation
+// Returns:
+//   HSV: corresponding HSV color object
+// Example:
+//   c := HCT{27.41, 113.36, 53.24} // red
+//   hsv := c.ToHsv() // returns HSV{0,100,100}
+func (c *HCT) ToHsv() HSV {
+	rgb := c.ToRgb()
+	return rgb.ToHsv()
+}
+
+// ToCmyk converts HCT to CMYK representation
+// Returns:
+//   CMYK: corresponding CMYK color object
+// Example:
+//   c := HCT{0, 0, 100} // white
+//   cmyk := c.ToCmyk() // returns CMYK{0,0,0,0}
+func (c *HCT) ToCmyk() CMYK {
+	rgb := c.ToRgb()
+	return rgb.ToCmyk()
+}
+
+// ToXyz converts HCT to CIE XYZ (D65) representation
+// Returns:
+//   XYZ: corresponding XYZ color object, inside the sRGB gamut
+// Example:
+//   c := HCT{0, 0, 100} // white
+//   xyz := c.ToXyz() // returns XYZ{0.9505,1.0000,1.0888}
+func (c *HCT) ToXyz() XYZ {
+	lr, lg, lb := hctToLinear(c.H, c.C, c.T)
+	x, y, z := mulMat3Vec(srgbToXyzMatrix, lr, lg, lb)
+	return XYZ{x, y, z}
+}
+
+// ToLab converts HCT to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c := HCT{0, 0, 100} // white
+//   lab := c.ToLab() // returns LAB{100,0,0}
+func (c *HCT) ToLab() LAB {
+	return xyzToLabD50(c.ToXyz())
+}
+
+// ToOklab converts HCT to Oklab representation
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c := HCT{0, 0, 100} // white
+//   lab := c.ToOklab() // returns OKLAB{1.0000,0.0000,0.0000}
+func (c *HCT) ToOklab() OKLAB {
+	return xyzToOklab(c.ToXyz())
+}
+
+// ToOklch converts HCT to OKLCH representation
+// Returns:
+//   OKLCH: corresponding OKLCH color object
+// Example:
+//   c := HCT{27.41, 113.36, 53.24} // red
+//   lch := c.ToOklch() // returns OKLCH{0.6280,0.2577,29.23}
+func (c *HCT) ToOklch() OKLCH {
+	lab := c.ToOklab()
+	return lab.ToOklch()
+}
+
+// ToHct returns a copy of the HCT color
+// Returns:
+//   HCT: the same color
+func (c *HCT) ToHct() HCT {
+	return *c
+}
+
+// ToHsluv converts HCT to HSLuv representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c := HCT{27.41, 113.36, 53.24} // red
+//   hsl := c.ToHsluv() // returns HSLUV{12.18,100.00,53.24}
+func (c *HCT) ToHsluv() HSLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHsluv()
+}
+
+// ToHpluv converts HCT to HPLuv representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c := HCT{27.41, 113.36, 53.24} // red
+//   hpl := c.ToHpluv() // returns HPLUV{12.18,426.75,53.24}
+func (c *HCT) ToHpluv() HPLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHpluv()
+}
+
+// xyzToHct converts XYZ (D65, Y=1) to HCT
+func xyzToHct(c XYZ) HCT {
+	cam := c.ToCam16(DefaultViewingConditions)
+	return HCT{H: cam.H, C: cam.C, T: lstarFromY(c.Y * 100)}
+}
+
+// hctToLinear solves for the linear sRGB color with the given hue, chroma
+// and tone. Grays and the tone extremes are exact; otherwise the CAM16
+// lightness is found with Newton's method on Y, and chroma is bisected
+// down to the gamut boundary when no in-gamut solution exists.
+func hctToLinear(h, c, t float64) (float64, float64, float64) {
+	if c < 0.0001 || t < 0.0001 || t > 99.9999 {
+		y := math.Max(0, math.Min(1, yFromLstar(t)/100))
+		return y, y, y
+	}
+	h = math.Mod(math.Mod(h, 360)+360, 360)
+	y := yFromLstar(t)
+	if lin, ok := hctFindByJ(h, c, y); ok {
+		return lin[0], lin[1], lin[2]
+	}
+	g := y / 100
+	best := [3]float64{g, g, g}
+	lo, hi := 0.0, c
+	for hi-lo > 0.01 {
+		mid := (lo + hi) / 2
+		if lin, ok := hctFindByJ(h, mid, y); ok {
+			best, lo = lin, mid
+		} else {
+			hi = mid
+		}
+	}
+	return best[0], best[1], best[2]
+}
+
+// hctFindByJ returns the in-gamut linear sRGB color with CAM16 chroma c,
+// hue h and relative luminance y in 0-100
+func hctFindByJ(h, c, y float64) ([3]float64, bool) {
+	j := math.Sqrt(y) * 11
+	for i := 0; i < 5; i++ {
+		cam := CAM16{J: j, C: c, H: h}
+		xyz := cam.ToXyz(DefaultViewingConditions)
+		lr, lg, lb := mulMat3Vec(xyzToSrgbMatrix, xyz.X, xyz.Y, xyz.Z)
+		fy := xyz.Y * 100
+		if lr < 0 || lg < 0 || lb < 0 || fy <= 0 {
+			return [3]float64{}, false
+		}
+		if i == 4 || math.Abs(fy-y) < 0.002 {
+			if lr > 1.0001 || lg > 1.0001 || lb > 1.0001 {
+				return [3]float64{}, false
+			}
+			return [3]float64{lr, lg, lb}, true
+		}
+		j -= (fy - y) * j / (2 * fy)
+	}
+	return [3]float64{}, false
+}
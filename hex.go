@@ -99,4 +99,81 @@ func (c *HEX) ToHsv() HSV {
 func (c *HEX) ToCmyk() CMYK {
 	rgb := c.ToRgb()
 	return rgb.ToCmyk()
+}
+
+// ToXyz converts HEX to CIE XYZ (D65) representation
+// Returns:
+//   XYZ: corresponding XYZ color object
+// Example:
+//   c, _ := StrToHex("#00ff00") // green
+//   xyz := c.ToXyz() // returns XYZ{0.3576,0.7152,0.1192}
+func (c *HEX) ToXyz() XYZ {
+	rgb := c.ToRgb()
+	return rgb.ToXyz()
+}
+
+// ToLab converts HEX to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c, _ := StrToHex("#ffffff") // white
+//   lab := c.ToLab() // returns LAB{100,0,0}
+func (c *HEX) ToLab() LAB {
+	rgb := c.ToRgb()
+	return rgb.ToLab()
+}
+
+// ToOklab converts HEX to Oklab representation
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c, _ := StrToHex("#ff0000") // red
+//   lab := c.ToOklab() // returns OKLAB{0.6280,0.2249,0.1258}
+func (c *HEX) ToOklab() OKLAB {
+	rgb := c.ToRgb()
+	return rgb.ToOklab()
+}
+
+// ToOklch converts HEX to OKLCH representation
+// Returns:
+//   OKLCH: corresponding OKLCH color object
+// Example:
+//   c, _ := StrToHex("#0000ff") // blue
+//   lch := c.ToOklch() // returns OKLCH{0.4520,0.3132,264.05}
+func (c *HEX) ToOklch() OKLCH {
+	rgb := c.ToRgb()
+	return rgb.ToOklch()
+}
+
+// ToHct converts HEX to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c, _ := StrToHex("#0000ff") // blue
+//   hct := c.ToHct() // returns HCT{282.76,87.23,32.30}
+func (c *HEX) ToHct() HCT {
+	rgb := c.ToRgb()
+	return rgb.ToHct()
+}
+
+// ToHsluv converts HEX to HSLuv representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c, _ := StrToHex("#0000ff") // blue
+//   hsl := c.ToHsluv() // returns HSLUV{265.87,100.00,32.30}
+func (c *HEX) ToHsluv() HSLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHsluv()
+}
+
+// ToHpluv converts HEX to HPLuv representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c, _ := StrToHex("#0000ff") // blue
+//   hpl := c.ToHpluv() // returns HPLUV{265.87,513.41,32.30}
+func (c *HEX) ToHpluv() HPLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHpluv()
 }
\ No newline at end of file
@@ -97,4 +97,81 @@ func (c *HSL) ToHsv() HSV {
 func (c *HSL) ToCmyk() CMYK {
 	rgb := c.ToRgb()
 	return rgb.ToCmyk()
+}
+
+// ToXyz converts HSL to CIE XYZ (D65) representation
+// Returns:
+//   XYZ: corresponding XYZ color object
+// Example:
+//   c := HSL{240,100,50} // blue
+//   xyz := c.ToXyz() // returns XYZ{0.1805,0.0722,0.9505}
+func (c *HSL) ToXyz() XYZ {
+	rgb := c.ToRgb()
+	return rgb.ToXyz()
+}
+
+// ToLab converts HSL to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c := HSL{120,100,50} // green
+//   lab := c.ToLab() // returns LAB{87.82,-79.29,80.99}
+func (c *HSL) ToLab() LAB {
+	rgb := c.ToRgb()
+	return rgb.ToLab()
+}
+
+// ToOklab converts HSL to Oklab representation
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c := HSL{0,100,50} // red
+//   lab := c.ToOklab() // returns OKLAB{0.6280,0.2249,0.1258}
+func (c *HSL) ToOklab() OKLAB {
+	rgb := c.ToRgb()
+	return rgb.ToOklab()
+}
+
+// ToOklch converts HSL to OKLCH representation
+// Returns:
+//   OKLCH: corresponding OKLCH color object
+// Example:
+//   c := HSL{120,100,50} // green
+//   lch := c.ToOklch() // returns OKLCH{0.8664,0.2948,142.50}
+func (c *HSL) ToOklch() OKLCH {
+	rgb := c.ToRgb()
+	return rgb.ToOklch()
+}
+
+// ToHct converts HSL to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c := HSL{0,100,50} // red
+//   hct := c.ToHct() // returns HCT{27.41,113.36,53.24}
+func (c *HSL) ToHct() HCT {
+	rgb := c.ToRgb()
+	return rgb.ToHct()
+}
+
+// ToHsluv converts HSL to HSLuv representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c := HSL{0,100,50} // red
+//   hsl := c.ToHsluv() // returns HSLUV{12.18,100.00,53.24}
+func (c *HSL) ToHsluv() HSLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHsluv()
+}
+
+// ToHpluv converts HSL to HPLuv representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c := HSL{0,100,50} // red
+//   hpl := c.ToHpluv() // returns HPLUV{12.18,426.75,53.24}
+func (c *HSL) ToHpluv() HPLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHpluv()
 }
\ No newline at end of file
@@ -105,4 +105,81 @@ func (c *HSLA) ToHsv() HSV {
 func (c *HSLA) ToCmyk() CMYK {
 	rgb := c.ToRgb()
 	return rgb.ToCmyk()
+}
+
+// ToXyz converts HSLA to CIE XYZ (D65) representation
+// Returns:
+//   XYZ: corresponding XYZ color object
+// Example:
+//   c := HSLA{HSL{0,100,50}, 1.0} // red
+//   xyz := c.ToXyz() // returns XYZ{0.4124,0.2126,0.0193}
+func (c *HSLA) ToXyz() XYZ {
+	rgb := c.ToRgb()
+	return rgb.ToXyz()
+}
+
+// ToLab converts HSLA to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c := HSLA{HSL{0,100,50}, 1.0} // red
+//   lab := c.ToLab() // returns LAB{54.29,80.81,69.89}
+func (c *HSLA) ToLab() LAB {
+	rgb := c.ToRgb()
+	return rgb.ToLab()
+}
+
+// ToOklab converts HSLA to Oklab representation
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c := HSLA{HSL{240,100,50},1.0} // blue
+//   lab := c.ToOklab() // returns OKLAB{0.4520,-0.0325,-0.3115}
+func (c *HSLA) ToOklab() OKLAB {
+	rgb := c.ToRgb()
+	return rgb.ToOklab()
+}
+
+// ToOklch converts HSLA to OKLCH representation
+// Returns:
+//   OKLCH: corresponding OKLCH color object
+// Example:
+//   c := HSLA{HSL{0,100,50},1.0} // red
+//   lch := c.ToOklch() // returns OKLCH{0.6280,0.2577,29.23}
+func (c *HSLA) ToOklch() OKLCH {
+	rgb := c.ToRgb()
+	return rgb.ToOklch()
+}
+
+// ToHct converts HSLA to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c := HSLA{HSL{240,100,50},1.0} // blue
+//   hct := c.ToHct() // returns HCT{282.76,87.23,32.30}
+func (c *HSLA) ToHct() HCT {
+	rgb := c.ToRgb()
+	return rgb.ToHct()
+}
+
+// ToHsluv converts HSLA to HSLuv representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c := HSLA{HSL{240,100,50},1.0} // blue
+//   hsl := c.ToHsluv() // returns HSLUV{265.87,100.00,32.30}
+func (c *HSLA) ToHsluv() HSLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHsluv()
+}
+
+// ToHpluv converts HSLA to HPLuv representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c := HSLA{HSL{240,100,50},1.0} // blue
+//   hpl := c.ToHpluv() // returns HPLUV{265.87,513.41,32.30}
+func (c *HSLA) ToHpluv() HPLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHpluv()
 }
\ No newline at end of file
@@ -0,0 +1,529 @@
+package color
+
+import (
+	"fmt"
+	"math"
+)
+
+// HSLUV is a color in Alexei Boronine's HSLuv space: CIE LCHuv with the
+// chroma rescaled so that saturation 100 is the sRGB gamut edge at every
+// hue and lightness. Lightness is perceptually uniform, unlike HSL.
+type HSLUV struct {
+	H, S, L float64
+}
+
+// HPLUV is the pastel variant of HSLuv: saturation 100 is the largest
+// chroma available at a lightness for all hues, so chroma stays uniform
+// but only pastel colors are reachable within 0-100.
+type HPLUV struct {
+	H, P, L float64
+}
+
+// Constants of the HSLuv reference implementation. They are kept as
+// published rather than derived from the package's sRGB matrices so that
+// conversions reproduce the project's snapshot values.
+var (
+	hsluvM = [3][3]float64{
+		{3.240969941904521, -1.537383177570093, -0.498610760293},
+		{-0.96924363628087, 1.87596750150772, 0.041555057407175},
+		{0.055630079696993, -0.20397695888897, 1.056971514242878},
+	}
+	hsluvMInv = [3][3]float64{
+		{0.41239079926595, 0.35758433938387, 0.18048078840183},
+		{0.21263900587151, 0.71516867876775, 0.072192315360733},
+		{0.019330818715591, 0.11919477979462, 0.95053215224966},
+	}
+)
+
+const (
+	hsluvRefU    = 0.19783000664283
+	hsluvRefV    = 0.46831999493879
+	hsluvKappa   = 903.2962962
+	hsluvEpsilon = 0.0088564516
+)
+
+// StrToHsluv converts an hsluv() format string to HSLUV object
+// Parameters:
+//   str: string in "hsluv(h,s,l)" format
+// Returns:
+//   *HSLUV: pointer to HSLUV object
+//   error: parsing error if format is invalid
+// Example:
+//   c, err := StrToHsluv("hsluv(12.18,100.00,53.24)") // red
+func StrToHsluv(str string) (*HSLUV, error) {
+	var h, s, l float64
+	_, err := fmt.Sscanf(RemoveSpace(str), "hsluv(%f,%f,%f)", &h, &s, &l)
+	if err != nil {
+		return nil, err
+	}
+	return &HSLUV{H: h, S: s, L: l}, nil
+}
+
+// String converts HSLUV object to hsluv() format string
+// Returns:
+//   string: "hsluv(h,s,l)" formatted string
+// Example:
+//   c := HSLUV{12.177, 100, 53.237}
+//   fmt.Println(c.String()) // outputs "hsluv(12.18,100.00,53.24)"
+func (c *HSLUV) String() string {
+	return fmt.Sprintf("hsluv(%.2f,%.2f,%.2f)", c.H, c.S, c.L)
+}
+
+// ToXyz converts HSLUV to CIE XYZ (D65) representation
+// Returns:
+//   XYZ: corresponding XYZ color object
+// Example:
+//   c := HSLUV{0, 0, 100} // white
+//   xyz := c.ToXyz() // returns XYZ{0.9505,1.0000,1.0891}
+func (c *HSLUV) ToXyz() XYZ {
+	l, ch, h := c.L, 0.0, c.H
+	if l > 99.9999999 {
+		l = 100
+	} else if l >= 0.00000001 {
+		ch = hsluvMaxChromaForLH(l, h) / 100 * c.S
+	}
+	return hsluvLchToXyz(l, ch, h)
+}
+
+// ToRgb converts HSLUV to sRGB representation
+// Returns:
+//   RGB: corresponding RGB color object
+// Example:
+//   c := HSLUV{12.177, 100, 53.237} // red
+//   rgb := c.ToRgb() // returns RGB{255,0,0}
+func (c *HSLUV) ToRgb() RGB {
+	return hsluvXyzToRgb(c.ToXyz())
+}
+
+// ToRgba converts HSLUV to RGBA with full opacity
+// Returns:
+//   RGBA: RGBA object with alpha=1.0
+// Example:
+//   c := HSLUV{0, 0, 0} // black
+//   rgba := c.ToRgba() // returns RGBA{RGB{0,0,0},1.0}
+func (c *HSLUV) ToRgba() RGBA {
+	return RGBA{c.ToRgb(), 1.0}
+}
+
+// ToHex converts HSLUV to hexadecimal string
+// Returns:
+//   string: "#RRGGBB" format string
+// Example:
+//   c := HSLUV{265.874, 100, 32.301} // blue
+//   hex := c.ToHex() // returns "#0000ff"
+func (c *HSLUV) ToHex() string {
+	rgb := c.ToRgb()
+	return rgb.ToHex()
+}
+
+// ToHsl converts HSLUV to HSL representation
+// Returns:
+//   HSL: corresponding HSL color object
+// Example:
+//   c := HSLUV{12.177, 100, 53.237} // red
+//   hsl := c.ToHsl() // returns HSL{0,100,50}
+func (c *HSLUV) ToHsl() HSL {
+	rgb := c.ToRgb()
+	return rgb.ToHsl()
+}
+
+// ToHsla converts HSLUV to HSLA with full opacity
+// Returns:
+//   HSLA: HSLA object with alpha=1.0
+// Example:
+//   c := HSLUV{12.177, 100, 53.237} // red
+//   hsla := c.ToHsla() // returns HSLA{HSL{0,100,50},1.0}
+func (c *HSLUV) ToHsla() HSLA {
+	rgb := c.ToRgb()
+	return rgb.ToHsla()
+}
+
+// ToHsv converts HSLUV to HSV representation
+// Returns:
+//   HSV: corresponding HSV color object
+// Example:
+//   c := HSLUV{127.715, 100, 87.736} // green
+//   hsv := c.ToHsv() // returns HSV{120,100,100}
+func (c *HSLUV) ToHsv() HSV {
+	rgb := c.ToRgb()
+	return rgb.ToHsv()
+}
+
+// ToCmyk converts HSLUV to CMYK representation
+// Returns:
+//   CMYK: corresponding CMYK color object
+// Example:
+//   c := HSLUV{0, 0, 100} // white
+//   cmyk := c.ToCmyk() // returns CMYK{0,0,0,0}
+func (c *HSLUV) ToCmyk() CMYK {
+	rgb := c.ToRgb()
+	return rgb.ToCmyk()
+}
+
+// ToLab converts HSLUV to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c := HSLUV{12.177, 100, 53.237} // red
+//   lab := c.ToLab() // returns LAB{54.29,80.81,69.89}
+func (c *HSLUV) ToLab() LAB {
+	rgb := c.ToRgb()
+	return rgb.ToLab()
+}
+
+// ToOklab converts HSLUV to Oklab representation
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c := HSLUV{12.177, 100, 53.237} // red
+//   lab := c.ToOklab() // returns OKLAB{0.6280,0.2249,0.1258}
+func (c *HSLUV) ToOklab() OKLAB {
+	rgb := c.ToRgb()
+	return rgb.ToOklab()
+}
+
+// ToOklch converts HSLUV to OKLCH representation
+// Returns:
+//   OKLCH: corresponding OKLCH color object
+// Example:
+//   c := HSLUV{12.177, 100, 53.237} // red
+//   lch := c.ToOklch() // returns OKLCH{0.6280,0.2577,29.23}
+func (c *HSLUV) ToOklch() OKLCH {
+	rgb := c.ToRgb()
+	return rgb.ToOklch()
+}
+
+// ToHct converts HSLUV to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c := HSLUV{12.177, 100, 53.237} // red
+//   hct := c.ToHct() // returns HCT{27.41,113.36,53.24}
+func (c *HSLUV) ToHct() HCT {
+	rgb := c.ToRgb()
+	return rgb.ToHct()
+}
+
+// ToHsluv returns a copy of the HSLUV color
+// Returns:
+//   HSLUV: the same color
+func (c *HSLUV) ToHsluv() HSLUV {
+	return *c
+}
+
+// ToHpluv converts HSLUV to HPLUV representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c := HSLUV{12.177, 100, 53.237} // red
+//   hpl := c.ToHpluv() // returns HPLUV{12.18,426.75,53.24}
+func (c *HSLUV) ToHpluv() HPLUV {
+	return xyzToHpluv(c.ToXyz())
+}
+
+// StrToHpluv converts an hpluv() format string to HPLUV object
+// Parameters:
+//   str: string in "hpluv(h,p,l)" format
+// Returns:
+//   *HPLUV: pointer to HPLUV object
+//   error: parsing error if format is invalid
+// Example:
+//   c, err := StrToHpluv("hpluv(12.18,426.75,53.24)") // red
+func StrToHpluv(str string) (*HPLUV, error) {
+	var h, p, l float64
+	_, err := fmt.Sscanf(RemoveSpace(str), "hpluv(%f,%f,%f)", &h, &p, &l)
+	if err != nil {
+		return nil, err
+	}
+	return &HPLUV{H: h, P: p, L: l}, nil
+}
+
+// String converts HPLUV object to hpluv() format string
+// Returns:
+//   string: "hpluv(h,p,l)" formatted string
+// Example:
+//   c := HPLUV{12.177, 426.747, 53.237}
+//   fmt.Println(c.String()) // outputs "hpluv(12.18,426.75,53.24)"
+func (c *HPLUV) String() string {
+	return fmt.Sprintf("hpluv(%.2f,%.2f,%.2f)", c.H, c.P, c.L)
+}
+
+// ToXyz converts HPLUV to CIE XYZ (D65) representation
+// Returns:
+//   XYZ: corresponding XYZ color object
+// Example:
+//   c := HPLUV{0, 0, 100} // white
+//   xyz := c.ToXyz() // returns XYZ{0.9505,1.0000,1.0891}
+func (c *HPLUV) ToXyz() XYZ {
+	l, ch, h := c.L, 0.0, c.H
+	if l > 99.9999999 {
+		l = 100
+	} else if l >= 0.00000001 {
+		ch = hsluvMaxSafeChromaForL(l) / 100 * c.P
+	}
+	return hsluvLchToXyz(l, ch, h)
+}
+
+// ToRgb converts HPLUV to sRGB representation, clipping out-of-gamut values
+// Returns:
+//   RGB: corresponding RGB color object
+// Example:
+//   c := HPLUV{12.177, 426.747, 53.237} // red
+//   rgb := c.ToRgb() // returns RGB{255,0,0}
+func (c *HPLUV) ToRgb() RGB {
+	return hsluvXyzToRgb(c.ToXyz())
+}
+
+// ToRgba converts HPLUV to RGBA with full opacity
+// Returns:
+//   RGBA: RGBA object with alpha=1.0
+// Example:
+//   c := HPLUV{0, 0, 0} // black
+//   rgba := c.ToRgba() // returns RGBA{RGB{0,0,0},1.0}
+func (c *HPLUV) ToRgba() RGBA {
+	return RGBA{c.ToRgb(), 1.0}
+}
+
+// ToHex converts HPLUV to hexadecimal string
+// Returns:
+//   string: "#RRGGBB" format string
+// Example:
+//   c := HPLUV{0, 0, 100} // white
+//   hex := c.ToHex() // returns "#ffffff"
+func (c *HPLUV) ToHex() string {
+	rgb := c.ToRgb()
+	return rgb.ToHex()
+}
+
+// ToHsl converts HPLUV to HSL representation
+// Returns:
+//   HSL: corresponding HSL color object
+// Example:
+//   c := HPLUV{12.177, 426.747, 53.237} // red
+//   hsl := c.ToHsl() // returns HSL{0,100,50}
+func (c *HPLUV) ToHsl() HSL {
+	rgb := c.ToRgb()
+	return rgb.ToHsl()
+}
+
+// ToHsla converts HPLUV to HSLA with full opacity
+// Returns:
+//   HSLA: HSLA object with alpha=1.0
+// Example:
+//   c := HPLUV{12.177, 426.747, 53.237} // red
+//   hsla := c.ToHsla() // returns HSLA{HSL{0,100,50},1.0}
+func (c *HPLUV) ToHsla() HSLA {
+	rgb := c.ToRgb()
+	return rgb.ToHsla()
+}
+
+// ToHsv converts HPLUV to HSV representation
+// Returns:
+//   HSV: corresponding HSV color object
+// Example:
+//   c := HPLUV{12.177, 426.747, 53.237} // red
+//   hsv := c.ToHsv() // returns HSV{0,100,100}
+func (c *HPLUV) ToHsv() HSV {
+	rgb := c.ToRgb()
+	return rgb.ToHsv()
+}
+
+// ToCmyk converts HPLUV to CMYK representation
+// Returns:
+//   CMYK: corresponding CMYK color object
+// Example:
+//   c := HPLUV{0, 0, 100} // white
+//   cmyk := c.ToCmyk() // returns CMYK{0,0,0,0}
+func (c *HPLUV) ToCmyk() CMYK {
+	rgb := c.ToRgb()
+	return rgb.ToCmyk()
+}
+
+// ToLab converts HPLUV to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c := HPLUV{0, 0, 100} // white
+//   lab := c.ToLab() // returns LAB{100,0,0}
+func (c *HPLUV) ToLab() LAB {
+	rgb := c.ToRgb()
+	return rgb.ToLab()
+}
+
+// ToOklab converts HPLUV to Oklab representation
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c := HPLUV{0, 0, 100} // white
+//   lab := c.ToOklab() // returns OKLAB{1.0000,0.0000,0.0000}
+func (c *HPLUV) ToOklab() OKLAB {
+	rgb := c.ToRgb()
+	return rgb.ToOklab()
+}
+
+// ToOklch converts HPLUV to OKLCH representation
+// Returns:
+//   OKLCH: corresponding OKLCH color object
+// Example:
+//   c := HPLUV{12.177, 426.747, 53.237} // red
+//   lch := c.ToOklch() // returns OKLCH{0.6280,0.2577,29.23}
+func (c *HPLUV) ToOklch() OKLCH {
+	rgb := c.ToRgb()
+	return rgb.ToOklch()
+}
+
+// ToHct converts HPLUV to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c := HPLUV{12.177, 426.747, 53.237} // red
+//   hct := c.ToHct() // returns HCT{27.41,113.36,53.24}
+func (c *HPLUV) ToHct() HCT {
+	rgb := c.ToRgb()
+	return rgb.ToHct()
+}
+
+// ToHsluv converts HPLUV to HSLUV representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c := HPLUV{12.177, 426.747, 53.237} // red
+//   hsl := c.ToHsluv() // returns HSLUV{12.18,100.00,53.24}
+func (c *HPLUV) ToHsluv() HSLUV {
+	return xyzToHsluv(c.ToXyz())
+}
+
+// ToHpluv returns a copy of the HPLUV color
+// Returns:
+//   HPLUV: the same color
+func (c *HPLUV) ToHpluv() HPLUV {
+	return *c
+}
+
+// xyzToHsluv converts XYZ (D65, Y=1) to HSLuv
+func xyzToHsluv(c XYZ) HSLUV {
+	l, ch, h := hsluvXyzToLch(c)
+	switch {
+	case l > 99.9999999:
+		return HSLUV{H: h, L: 100}
+	case l < 0.00000001:
+		return HSLUV{H: h}
+	}
+	return HSLUV{H: h, S: ch / hsluvMaxChromaForLH(l, h) * 100, L: l}
+}
+
+// xyzToHpluv converts XYZ (D65, Y=1) to HPLuv
+func xyzToHpluv(c XYZ) HPLUV {
+	l, ch, h := hsluvXyzToLch(c)
+	switch {
+	case l > 99.9999999:
+		return HPLUV{H: h, L: 100}
+	case l < 0.00000001:
+		return HPLUV{H: h}
+	}
+	return HPLUV{H: h, P: ch / hsluvMaxSafeChromaForL(l) * 100, L: l}
+}
+
+// rgbToHsluvXyz converts sRGB to XYZ with the HSLuv reference matrix
+func rgbToHsluvXyz(c *RGB) XYZ {
+	r := srgbToLinear(float64(c.R) / 255)
+	g := srgbToLinear(float64(c.G) / 255)
+	b := srgbToLinear(float64(c.B) / 255)
+	x, y, z := mulMat3Vec(hsluvMInv, r, g, b)
+	return XYZ{x, y, z}
+}
+
+// hsluvXyzToRgb converts XYZ to sRGB with the HSLuv reference matrix
+func hsluvXyzToRgb(c XYZ) RGB {
+	r, g, b := mulMat3Vec(hsluvM, c.X, c.Y, c.Z)
+	return RGB{unitToUint8(linearToSrgb(r)), unitToUint8(linearToSrgb(g)), unitToUint8(linearToSrgb(b))}
+}
+
+// hsluvXyzToLch converts XYZ to CIE LCHuv with the HSLuv constants
+func hsluvXyzToLch(c XYZ) (float64, float64, float64) {
+	var l float64
+	if c.Y <= hsluvEpsilon {
+		l = c.Y * hsluvKappa
+	} else {
+		l = 116*math.Cbrt(c.Y) - 16
+	}
+	if l == 0 {
+		return 0, 0, 0
+	}
+	d := c.X + 15*c.Y + 3*c.Z
+	u := 13 * l * (4*c.X/d - hsluvRefU)
+	v := 13 * l * (9*c.Y/d - hsluvRefV)
+	ch := math.Hypot(u, v)
+	if ch < 0.00000001 {
+		return l, ch, 0
+	}
+	h := math.Atan2(v, u) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return l, ch, h
+}
+
+// hsluvLchToXyz converts CIE LCHuv to XYZ with the HSLuv constants
+func hsluvLchToXyz(l, ch, h float64) XYZ {
+	if l == 0 {
+		return XYZ{}
+	}
+	hSin, hCos := math.Sincos(h * math.Pi / 180)
+	u := hCos*ch/(13*l) + hsluvRefU
+	v := hSin*ch/(13*l) + hsluvRefV
+	var y float64
+	if l <= 8 {
+		y = l / hsluvKappa
+	} else {
+		y = math.Pow((l+16)/116, 3)
+	}
+	x := -(9 * y * u) / ((u-4)*v - u*v)
+	z := (9*y - 15*v*y - v*x) / (3 * v)
+	return XYZ{x, y, z}
+}
+
+// hsluvBounds returns the six lines, as slope and intercept in the u-v
+// chroma plane, where an sRGB channel reaches 0 or 1 at lightness l
+func hsluvBounds(l float64) [6][2]float64 {
+	sub1 := math.Pow(l+16, 3) / 1560896
+	sub2 := l / hsluvKappa
+	if sub1 > hsluvEpsilon {
+		sub2 = sub1
+	}
+	var lines [6][2]float64
+	for c := 0; c < 3; c++ {
+		m1, m2, m3 := hsluvM[c][0], hsluvM[c][1], hsluvM[c][2]
+		for t := 0; t < 2; t++ {
+			top1 := (284517*m1 - 94839*m3) * sub2
+			top2 := (838422*m3+769860*m2+731718*m1)*l*sub2 - 769860*float64(t)*l
+			bottom := (632260*m3-126452*m2)*sub2 + 126452*float64(t)
+			lines[c*2+t] = [2]float64{top1 / bottom, top2 / bottom}
+		}
+	}
+	return lines
+}
+
+// hsluvMaxChromaForLH returns the largest in-gamut chroma at a lightness and hue
+func hsluvMaxChromaForLH(l, h float64) float64 {
+	hSin, hCos := math.Sincos(h * math.Pi / 180)
+	min := math.MaxFloat64
+	for _, line := range hsluvBounds(l) {
+		if length := line[1] / (hSin - line[0]*hCos); length >= 0 && length < min {
+			min = length
+		}
+	}
+	return min
+}
+
+// hsluvMaxSafeChromaForL returns the largest chroma in gamut for all hues
+// at a lightness
+func hsluvMaxSafeChromaForL(l float64) float64 {
+	min := math.MaxFloat64
+	for _, line := range hsluvBounds(l) {
+		if d := math.Abs(line[1]) / math.Sqrt(line[0]*line[0]+1); d < min {
+			min = d
+		}
+	}
+	return min
+}
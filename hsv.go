@@ -1,6 +1,9 @@
 package color
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 type HSV struct {
 	H, S, V uint32
@@ -97,4 +100,145 @@ func (c *HSV) ToHsla() HSLA {
 func (c *HSV) ToCmyk() CMYK {
 	rgb := c.ToRgb()
 	return rgb.ToCmyk()
+}
+
+// ToXyz converts HSV to CIE XYZ (D65) representation
+// Returns:
+//   XYZ: corresponding XYZ color object
+// Example:
+//   c := HSV{0,0,0} // black
+//   xyz := c.ToXyz() // returns XYZ{0,0,0}
+func (c *HSV) ToXyz() XYZ {
+	rgb := c.ToRgb()
+	return rgb.ToXyz()
+}
+
+// ToLab converts HSV to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c := HSV{0,0,0} // black
+//   lab := c.ToLab() // returns LAB{0,0,0}
+func (c *HSV) ToLab() LAB {
+	rgb := c.ToRgb()
+	return rgb.ToLab()
+}
+
+// ToOklab converts HSV to Oklab representation
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c := HSV{120,100,100} // green
+//   lab := c.ToOklab() // returns OKLAB{0.8664,-0.2339,0.1795}
+func (c *HSV) ToOklab() OKLAB {
+	rgb := c.ToRgb()
+	return rgb.ToOklab()
+}
+
+// ToOklch converts HSV to OKLCH representation
+// Returns:
+//   OKLCH: corresponding OKLCH color object
+// Example:
+//   c := HSV{240,100,100} // blue
+//   lch := c.ToOklch() // returns OKLCH{0.4520,0.3132,264.05}
+func (c *HSV) ToOklch() OKLCH {
+	rgb := c.ToRgb()
+	return rgb.ToOklch()
+}
+
+// ToHct converts HSV to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c := HSV{120,100,100} // green
+//   hct := c.ToHct() // returns HCT{142.14,108.41,87.73}
+func (c *HSV) ToHct() HCT {
+	rgb := c.ToRgb()
+	return rgb.ToHct()
+}
+
+// ToHsluv converts HSV to HSLuv representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c := HSV{120,100,100} // green
+//   hsl := c.ToHsluv() // returns HSLUV{127.72,100.00,87.74}
+func (c *HSV) ToHsluv() HSLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHsluv()
+}
+
+// ToHpluv converts HSV to HPLuv representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c := HSV{120,100,100} // green
+//   hpl := c.ToHpluv() // returns HPLUV{127.72,490.15,87.74}
+func (c *HSV) ToHpluv() HPLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHpluv()
+}
+
+// RgbToHsvFloat converts RGB to hue, saturation and value without the
+// whole degree and percent rounding of HSV
+// Parameters:
+//   c: color to convert
+// Returns:
+//   float64: hue in 0-360
+//   float64: saturation in 0-1
+//   float64: value in 0-1
+// Example:
+//   h, s, v := RgbToHsvFloat(RGB{128,128,128}) // returns 0, 0, 0.502
+func RgbToHsvFloat(c RGB) (float64, float64, float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	max := math.Max(r, math.Max(g, b))
+	delta := max - math.Min(r, math.Min(g, b))
+	if max == 0 {
+		return 0, 0, 0
+	}
+	var h float64
+	switch {
+	case delta == 0:
+	case max == r:
+		h = math.Mod((g-b)/delta+6, 6)
+	case max == g:
+		h = (b-r)/delta + 2
+	default:
+		h = (r-g)/delta + 4
+	}
+	return h * 60, delta / max, max
+}
+
+// HsvFloatToRgb converts unrounded hue, saturation and value to RGB,
+// rounding each channel; it is the inverse of RgbToHsvFloat
+// Parameters:
+//   h: hue in degrees, wrapped into 0-360
+//   s: saturation in 0-1
+//   v: value in 0-1
+// Returns:
+//   RGB: corresponding RGB color object
+// Example:
+//   rgb := HsvFloatToRgb(0, 0, 0.502) // returns RGB{128,128,128}
+func HsvFloatToRgb(h, s, v float64) RGB {
+	h = math.Mod(math.Mod(h, 360)+360, 360) / 60
+	s = math.Max(0, math.Min(1, s))
+	v = math.Max(0, math.Min(1, v))
+	f := h - math.Floor(h)
+	p, q, t := v*(1-s), v*(1-s*f), v*(1-s*(1-f))
+	var r, g, b float64
+	switch int(h) {
+	case 0:
+		r, g, b = v, t, p
+	case 1:
+		r, g, b = q, v, p
+	case 2:
+		r, g, b = p, v, t
+	case 3:
+		r, g, b = p, q, v
+	case 4:
+		r, g, b = t, p, v
+	default:
+		r, g, b = v, p, q
+	}
+	return RGB{unitToUint8(r), unitToUint8(g), unitToUint8(b)}
 }
\ No newline at end of file
@@ -30,4 +30,32 @@ type ToHsv interface {
 
 type ToCmyk interface {
 	ToCmyk() CMYK
+}
+
+type ToXyz interface {
+	ToXyz() XYZ
+}
+
+type ToLab interface {
+	ToLab() LAB
+}
+
+type ToOklab interface {
+	ToOklab() OKLAB
+}
+
+type ToOklch interface {
+	ToOklch() OKLCH
+}
+
+type ToHct interface {
+	ToHct() HCT
+}
+
+type ToHsluv interface {
+	ToHsluv() HSLUV
+}
+
+type ToHpluv interface {
+	ToHpluv() HPLUV
 }
\ No newline at end of file
@@ -0,0 +1,185 @@
+package color
+
+import "fmt"
+
+// LAB is a CIE L*a*b* color relative to a D50 white, the reference white
+// used by ICC profiles, Adobe applications and CSS lab()
+type LAB struct {
+	L, A, B float64
+}
+
+// StrToLab converts a lab() format string to LAB object
+// Parameters:
+//   str: string in "lab(l,a,b)" format
+// Returns:
+//   *LAB: pointer to LAB object
+//   error: parsing error if format is invalid
+// Example:
+//   c, err := StrToLab("lab(54.29,80.81,69.89)") // red
+func StrToLab(str string) (*LAB, error) {
+	var l, a, b float64
+	_, err := fmt.Sscanf(RemoveSpace(str), "lab(%f,%f,%f)", &l, &a, &b)
+	if err != nil {
+		return nil, err
+	}
+	return &LAB{L: l, A: a, B: b}, nil
+}
+
+// String converts LAB object to lab() format string
+// Returns:
+//   string: "lab(l,a,b)" formatted string
+// Example:
+//   c := LAB{54.29, 80.81, 69.89}
+//   fmt.Println(c.String()) // outputs "lab(54.29,80.81,69.89)"
+func (c *LAB) String() string {
+	return fmt.Sprintf("lab(%.2f,%.2f,%.2f)", c.L, c.A, c.B)
+}
+
+// ToXyz converts LAB to CIE XYZ (D65) with Bradford adaptation
+// Returns:
+//   XYZ: corresponding XYZ color object
+// Example:
+//   c := LAB{100, 0, 0} // white
+//   xyz := c.ToXyz() // returns XYZ{0.9505,1.0000,1.0888}
+func (c *LAB) ToXyz() XYZ {
+	x, y, z := labToXyz(c.L, c.A, c.B, IlluminantD50)
+	d50 := XYZ{x, y, z}
+	return d50.Adapt(IlluminantD50, IlluminantD65, Bradford)
+}
+
+// ToRgb converts LAB to sRGB representation, clipping out-of-gamut values
+// Returns:
+//   RGB: corresponding RGB color object
+// Example:
+//   c := LAB{54.29, 80.81, 69.89} // red
+//   rgb := c.ToRgb() // returns RGB{255,0,0}
+func (c *LAB) ToRgb() RGB {
+	xyz := c.ToXyz()
+	return xyz.ToRgb()
+}
+
+// ToRgba converts LAB to RGBA with full opacity
+// Returns:
+//   RGBA: RGBA object with alpha=1.0
+// Example:
+//   c := LAB{0, 0, 0} // black
+//   rgba := c.ToRgba() // returns RGBA{RGB{0,0,0},1.0}
+func (c *LAB) ToRgba() RGBA {
+	return RGBA{c.ToRgb(), 1.0}
+}
+
+// ToHex converts LAB to hexadecimal string
+// Returns:
+//   string: "#RRGGBB" format string
+// Example:
+//   c := LAB{29.57, 68.30, -112.03} // blue
+//   hex := c.ToHex() // returns "#0000ff"
+func (c *LAB) ToHex() string {
+	rgb := c.ToRgb()
+	return rgb.ToHex()
+}
+
+// ToHsl converts LAB to HSL representation
+// Returns:
+//   HSL: corresponding HSL color object
+// Example:
+//   c := LAB{54.29, 80.81, 69.89} // red
+//   hsl := c.ToHsl() // returns HSL{0,100,50}
+func (c *LAB) ToHsl() HSL {
+	rgb := c.ToRgb()
+	return rgb.ToHsl()
+}
+
+// ToHsla converts LAB to HSLA with full opacity
+// Returns:
+//   HSLA: HSLA object with alpha=1.0
+// Example:
+//   c := LAB{54.29, 80.81, 69.89} // red
+//   hsla := c.ToHsla() // returns HSLA{HSL{0,100,50},1.0}
+func (c *LAB) ToHsla() HSLA {
+	rgb := c.ToRgb()
+	return rgb.ToHsla()
+}
+
+// ToHsv converts LAB to HSV representation
+// Returns:
+//   HSV: corresponding HSV color object
+// Example:
+//   c := LAB{87.82, -79.29, 80.99} // green
+//   hsv := c.ToHsv() // returns HSV{120,100,100}
+func (c *LAB) ToHsv() HSV {
+	rgb := c.ToRgb()
+	return rgb.ToHsv()
+}
+
+// ToCmyk converts LAB to CMYK representation
+// Returns:
+//   CMYK: corresponding CMYK color object
+// Example:
+//   c := LAB{100, 0, 0} // white
+//   cmyk := c.ToCmyk() // returns CMYK{0,0,0,0}
+func (c *LAB) ToCmyk() CMYK {
+	rgb := c.ToRgb()
+	return rgb.ToCmyk()
+}
+
+// ToOklab converts LAB to Oklab representation
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c := LAB{54.29, 80.81, 69.89} // red
+//   lab := c.ToOklab() // returns OKLAB{0.6280,0.2249,0.1258}
+func (c *LAB) ToOklab() OKLAB {
+	return xyzToOklab(c.ToXyz())
+}
+
+// ToOklch converts LAB to OKLCH representation
+// Returns:
+//   OKLCH: corresponding OKLCH color object
+// Example:
+//   c := LAB{29.57, 68.30, -112.03} // blue
+//   lch := c.ToOklch() // returns OKLCH{0.4520,0.3132,264.05}
+func (c *LAB) ToOklch() OKLCH {
+	lab := c.ToOklab()
+	return lab.ToOklch()
+}
+
+// ToHct converts LAB to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c := LAB{54.29, 80.81, 69.89} // red
+//   hct := c.ToHct() // returns HCT{27.41,113.36,53.24}
+func (c *LAB) ToHct() HCT {
+	rgb := c.ToRgb()
+	return rgb.ToHct()
+}
+
+// ToHsluv converts LAB to HSLuv representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c := LAB{54.29, 80.81, 69.89} // red
+//   hsl := c.ToHsluv() // returns HSLUV{12.18,100.00,53.24}
+func (c *LAB) ToHsluv() HSLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHsluv()
+}
+
+// ToHpluv converts LAB to HPLuv representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c := LAB{54.29, 80.81, 69.89} // red
+//   hpl := c.ToHpluv() // returns HPLUV{12.18,426.75,53.24}
+func (c *LAB) ToHpluv() HPLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHpluv()
+}
+
+// xyzToLabD50 converts a D65 XYZ value to D50 L*a*b* with Bradford adaptation
+func xyzToLabD50(c XYZ) LAB {
+	d50 := c.Adapt(IlluminantD65, IlluminantD50, Bradford)
+	l, a, b := xyzToLab(d50.X, d50.Y, d50.Z, IlluminantD50)
+	return LAB{L: l, A: a, B: b}
+}
@@ -0,0 +1,175 @@
+package color
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+// LsColorsEntry is one "key=sgr" entry of an LS_COLORS value
+// Key is a file type code such as "di" or "ln", or a glob such as "*.tar".
+// Target is set for "ln=target", where symlinks take the style of the file
+// they point to.
+type LsColorsEntry struct {
+	Key    string
+	Style  AnsiStyle
+	Target bool
+}
+
+// LsColors is a parsed LS_COLORS value in its original entry order
+type LsColors struct {
+	Entries []LsColorsEntry
+}
+
+// ParseLsColors parses an LS_COLORS value as produced by dircolors
+// Parameters:
+//   value: colon separated entries, e.g. "di=01;34:ln=01;36:*.tar=38;5;9"
+// Returns:
+//   *LsColors: parsed entries
+//   error: error if an entry has no "="
+// Example:
+//   lc, err := ParseLsColors(os.Getenv("LS_COLORS"))
+func ParseLsColors(value string) (*LsColors, error) {
+	lc := &LsColors{}
+	for _, entry := range strings.Split(value, ":") {
+		if entry == "" {
+			continue
+		}
+		key, sgr, ok := strings.Cut(entry, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid LS_COLORS entry: %s", entry)
+		}
+		if sgr == "target" {
+			lc.Entries = append(lc.Entries, LsColorsEntry{Key: key, Target: true})
+			continue
+		}
+		var style AnsiStyle
+		style.ApplySgr(sgr)
+		lc.Entries = append(lc.Entries, LsColorsEntry{Key: key, Style: style})
+	}
+	return lc, nil
+}
+
+// String formats the entries back into an LS_COLORS value
+// Returns:
+//   string: colon separated "key=sgr" entries
+// Example:
+//   lc, _ := ParseLsColors("di=01;34")
+//   lc.String() // returns "di=1;34"
+func (lc *LsColors) String() string {
+	parts := make([]string, len(lc.Entries))
+	for i, e := range lc.Entries {
+		if e.Target {
+			parts[i] = e.Key + "=target"
+			continue
+		}
+		parts[i] = e.Key + "=" + e.Style.Sgr()
+	}
+	return strings.Join(parts, ":")
+}
+
+// Lookup finds the style of a key, later entries overriding earlier ones
+// Parameters:
+//   key: file type code or glob, exactly as written in LS_COLORS
+// Returns:
+//   AnsiStyle: style of the entry
+//   bool: false if the key is not present
+// Example:
+//   style, ok := lc.Lookup("di")
+func (lc *LsColors) Lookup(key string) (AnsiStyle, bool) {
+	for i := len(lc.Entries) - 1; i >= 0; i-- {
+		if lc.Entries[i].Key == key {
+			return lc.Entries[i].Style, true
+		}
+	}
+	return AnsiStyle{}, false
+}
+
+// Set replaces the style of a key or appends a new entry
+// Parameters:
+//   key: file type code or glob
+//   style: style of the entry
+func (lc *LsColors) Set(key string, style AnsiStyle) {
+	for i := range lc.Entries {
+		if lc.Entries[i].Key == key {
+			lc.Entries[i] = LsColorsEntry{Key: key, Style: style}
+			return
+		}
+	}
+	lc.Entries = append(lc.Entries, LsColorsEntry{Key: key, Style: style})
+}
+
+// StyleFor picks the style ls would use for a file
+// Directories, symlinks, pipes, sockets, devices and executables use their
+// type code; regular files are matched against the glob entries (case
+// insensitively, longest suffix first) and fall back to "fi". With
+// "ln=target" symlinks report false, so the caller can use the style of
+// the link's target instead.
+// Parameters:
+//   name: file name
+//   mode: file mode from os.Lstat
+// Returns:
+//   AnsiStyle: style for the file
+//   bool: false if no entry applies
+// Example:
+//   style, ok := lc.StyleFor("backup.tar", 0644) // style of "*.tar"
+func (lc *LsColors) StyleFor(name string, mode fs.FileMode) (AnsiStyle, bool) {
+	var code string
+	switch {
+	case mode&fs.ModeDir != 0:
+		code = "di"
+	case mode&fs.ModeSymlink != 0:
+		code = "ln"
+	case mode&fs.ModeNamedPipe != 0:
+		code = "pi"
+	case mode&fs.ModeSocket != 0:
+		code = "so"
+	case mode&fs.ModeCharDevice != 0:
+		code = "cd"
+	case mode&fs.ModeDevice != 0:
+		code = "bd"
+	case mode&fs.ModeSetuid != 0:
+		code = "su"
+	case mode&fs.ModeSetgid != 0:
+		code = "sg"
+	case mode&0111 != 0:
+		code = "ex"
+	}
+	if code != "" {
+		if code == "ln" && lc.linksToTarget() {
+			return AnsiStyle{}, false
+		}
+		if style, ok := lc.Lookup(code); ok {
+			return style, true
+		}
+		if code != "ex" && code != "su" && code != "sg" {
+			return AnsiStyle{}, false
+		}
+	}
+
+	lower := strings.ToLower(name)
+	best := -1
+	for i, e := range lc.Entries {
+		if !strings.HasPrefix(e.Key, "*") {
+			continue
+		}
+		suffix := strings.ToLower(e.Key[1:])
+		if strings.HasSuffix(lower, suffix) && (best < 0 || len(suffix) >= len(lc.Entries[best].Key)-1) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return lc.Entries[best].Style, true
+	}
+	return lc.Lookup("fi")
+}
+
+// linksToTarget reports whether the last "ln" entry is "ln=target"
+func (lc *LsColors) linksToTarget() bool {
+	for i := len(lc.Entries) - 1; i >= 0; i-- {
+		if lc.Entries[i].Key == "ln" {
+			return lc.Entries[i].Target
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package material
+
+import (
+	"math"
+	"testing"
+
+	"github.com/zjhsd2007/color"
+)
+
+var baselineSeed = color.RGB{R: 0x67, G: 0x50, B: 0xa4}
+
+func hex(c color.RGB) string {
+	return c.ToHex()
+}
+
+func TestTonalPalette(t *testing.T) {
+	p := NewTonalPalette(baselineSeed)
+	colors := p.Colors()
+	if colors[0] != (color.RGB{}) || colors[len(colors)-1] != (color.RGB{R: 255, G: 255, B: 255}) {
+		t.Errorf("tone ends = %v, %v", colors[0], colors[len(colors)-1])
+	}
+	for i, c := range colors {
+		hct := c.ToHct()
+		if math.Abs(hct.T-Tones[i]) > 0.5 {
+			t.Errorf("tone %v has tone %.2f", Tones[i], hct.T)
+		}
+		if Tones[i] > 5 && Tones[i] < 95 && math.Abs(hct.H-p.Hue) > 2 {
+			t.Errorf("tone %v drifted to hue %.2f, want %.2f", Tones[i], hct.H, p.Hue)
+		}
+	}
+	if got := hex(p.Tone(40)); got != "#6750a4" {
+		t.Errorf("seed tone = %s", got)
+	}
+}
+
+func TestBaselineScheme(t *testing.T) {
+	// Material 3 baseline roles generated from #6750a4
+	cases := []struct {
+		dark bool
+		want map[string]string
+	}{
+		{false, map[string]string{
+			"primary": "#65558f", "onPrimary": "#ffffff", "primaryContainer": "#e9ddff",
+			"secondary": "#625b71", "tertiary": "#7e5260", "error": "#ba1a1a",
+		}},
+		{true, map[string]string{
+			"primary": "#cfbdfe", "onPrimary": "#36275d", "primaryContainer": "#4d3d75",
+			"secondary": "#cbc2db", "tertiary": "#efb8c8", "error": "#ffb4ab",
+		}},
+	}
+	for _, c := range cases {
+		s := NewScheme(baselineSeed, TonalSpot, c.dark)
+		got := map[string]string{
+			"primary": hex(s.Primary), "onPrimary": hex(s.OnPrimary),
+			"primaryContainer": hex(s.PrimaryContainer), "secondary": hex(s.Secondary),
+			"tertiary": hex(s.Tertiary), "error": hex(s.Error),
+		}
+		for role, want := range c.want {
+			if got[role] != want {
+				t.Errorf("dark=%v %s = %s, want %s", c.dark, role, got[role], want)
+			}
+		}
+	}
+}
+
+func TestVariants(t *testing.T) {
+	seed := color.RGB{R: 0x42, G: 0x85, B: 0xf4}
+	seedHct := seed.ToHct()
+	for v := TonalSpot; v <= Monochrome; v++ {
+		for _, dark := range []bool{false, true} {
+			s := NewScheme(seed, v, dark)
+			// text roles must stay readable on their backgrounds
+			pairs := [][2]color.RGB{
+				{s.Primary, s.OnPrimary}, {s.PrimaryContainer, s.OnPrimaryContainer},
+				{s.Secondary, s.OnSecondary}, {s.Tertiary, s.OnTertiary},
+				{s.Surface, s.OnSurface}, {s.Error, s.OnError},
+			}
+			for i, p := range pairs {
+				bg, fg := p[0].ToHct(), p[1].ToHct()
+				if r := contrastRatio(bg.T, fg.T); r < 4.5 {
+					t.Errorf("%s dark=%v pair %d contrast %.2f", v, dark, i, r)
+				}
+			}
+		}
+	}
+
+	mono := NewScheme(seed, Monochrome, false)
+	for _, c := range []color.RGB{mono.Primary, mono.Secondary, mono.Tertiary, mono.Surface} {
+		if c.R != c.G || c.G != c.B {
+			t.Errorf("monochrome role %v is not gray", c)
+		}
+	}
+	content := NewScheme(seed, Content, false)
+	if content.PrimaryContainer != seed {
+		t.Errorf("content primary container = %v, want seed", content.PrimaryContainer)
+	}
+	vibrant := NewPalettes(seed, Vibrant)
+	if vibrant.Primary.Chroma != 200 || vibrant.Secondary.Hue == seedHct.H {
+		t.Errorf("vibrant palettes = %+v", vibrant)
+	}
+	expressive := NewPalettes(seed, Expressive)
+	if math.Abs(expressive.Primary.Hue-sanitizeHue(seedHct.H+240)) > 1e-9 {
+		t.Errorf("expressive primary hue = %.2f", expressive.Primary.Hue)
+	}
+	tonal := NewScheme(seed, TonalSpot, false)
+	if h := tonal.Primary.ToHct(); math.Abs(h.H-seedHct.H) > 2 || math.Abs(h.T-40) > 0.5 {
+		t.Errorf("tonal spot primary = %v", h)
+	}
+}
@@ -0,0 +1,99 @@
+// Package material generates Material Design 3 color schemes from a seed
+// color. Palettes and roles are computed in HCT, so every role keeps the
+// seed's hue while the tone sets its contrast.
+package material
+
+import (
+	"math"
+
+	"github.com/zjhsd2007/color"
+)
+
+// TonalPalette is a hue and chroma pair whose colors are picked by tone
+type TonalPalette struct {
+	Hue, Chroma float64
+}
+
+// NewTonalPalette creates a palette from the hue and chroma of a color
+// Parameters:
+//   c: key color
+// Returns:
+//   TonalPalette: palette with the color's HCT hue and chroma
+// Example:
+//   p := NewTonalPalette(color.RGB{R: 103, G: 80, B: 164})
+//   primary := p.Tone(40)
+func NewTonalPalette(c color.RGB) TonalPalette {
+	hct := c.ToHct()
+	return TonalPalette{Hue: hct.H, Chroma: hct.C}
+}
+
+// Tone returns the palette color at a tone
+// Parameters:
+//   t: tone in 0-100, 0 is black and 100 is white
+// Returns:
+//   color.RGB: the color, with chroma reduced if it is out of gamut
+func (p TonalPalette) Tone(t float64) color.RGB {
+	hct := color.HCT{H: p.Hue, C: p.Chroma, T: t}
+	return hct.ToRgb()
+}
+
+// Tones are the tones Material lists for a tonal palette
+var Tones = []float64{0, 10, 20, 30, 40, 50, 60, 70, 80, 90, 95, 99, 100}
+
+// Colors returns the palette color at each of Tones
+// Returns:
+//   []color.RGB: colors from black to white
+func (p TonalPalette) Colors() []color.RGB {
+	colors := make([]color.RGB, len(Tones))
+	for i, t := range Tones {
+		colors[i] = p.Tone(t)
+	}
+	return colors
+}
+
+// sanitizeHue wraps a hue into 0-360
+func sanitizeHue(h float64) float64 {
+	return math.Mod(math.Mod(h, 360)+360, 360)
+}
+
+// yFromTone returns the relative luminance in 0-100 of a tone
+func yFromTone(t float64) float64 {
+	f := (t + 16) / 116
+	if f3 := f * f * f; f3 > 216.0/24389.0 {
+		return 100 * f3
+	}
+	return 100 * t / (24389.0 / 27.0)
+}
+
+// toneFromY is the inverse of yFromTone
+func toneFromY(y float64) float64 {
+	y /= 100
+	if y > 216.0/24389.0 {
+		return 116*math.Cbrt(y) - 16
+	}
+	return 24389.0 / 27.0 * y
+}
+
+// contrastRatio returns the WCAG contrast ratio of two tones
+func contrastRatio(a, b float64) float64 {
+	ya, yb := yFromTone(a), yFromTone(b)
+	return (math.Max(ya, yb) + 5) / (math.Min(ya, yb) + 5)
+}
+
+// foregroundTone returns a tone for text on a background tone that reaches
+// the contrast ratio, preferring light text on backgrounds below tone 60
+func foregroundTone(bg, ratio float64) float64 {
+	lighter := math.Min(100, toneFromY(ratio*(yFromTone(bg)+5)-5)+0.4)
+	darker := math.Max(0, toneFromY((yFromTone(bg)+5)/ratio-5)-0.4)
+	lighterRatio, darkerRatio := contrastRatio(lighter, bg), contrastRatio(darker, bg)
+	if math.Round(bg) < 60 {
+		if lighterRatio >= ratio || lighterRatio >= darkerRatio {
+			return lighter
+		}
+		return darker
+	}
+	if darkerRatio >= ratio || darkerRatio >= lighterRatio {
+		return darker
+	}
+	return lighter
+}
@@ -0,0 +1,198 @@
+package material
+
+import (
+	"math"
+
+	"github.com/zjhsd2007/color"
+)
+
+// Variant selects how the palettes are derived from the seed color
+type Variant int
+
+const (
+	TonalSpot  Variant = iota // Material's default: calm, moderately colorful
+	Vibrant                   // maximum primary chroma, hue shifted accents
+	Expressive                // primary rotated away from the seed hue
+	Content                   // keeps the seed chroma and tone, for image based themes
+	Fidelity                  // like Content with a complementary tertiary
+	Monochrome                // grayscale
+)
+
+// String returns the variant name
+func (v Variant) String() string {
+	switch v {
+	case TonalSpot:
+		return "tonal spot"
+	case Vibrant:
+		return "vibrant"
+	case Expressive:
+		return "expressive"
+	case Content:
+		return "content"
+	case Fidelity:
+		return "fidelity"
+	case Monochrome:
+		return "monochrome"
+	}
+	return "unknown"
+}
+
+// Palettes are the tonal palettes a scheme draws its roles from
+type Palettes struct {
+	Primary, Secondary, Tertiary TonalPalette
+	Neutral, NeutralVariant      TonalPalette
+	Error                        TonalPalette
+}
+
+// Hue ranges and rotations of the secondary and tertiary palettes, from
+// Google's SchemeVibrant and SchemeExpressive
+var (
+	rotationHues        = []float64{0, 41, 61, 101, 131, 181, 251, 301, 360}
+	vibrantSecondary    = []float64{18, 15, 10, 12, 15, 18, 15, 12, 12}
+	vibrantTertiary     = []float64{35, 30, 20, 25, 30, 35, 30, 25, 25}
+	expressiveSecondary = []float64{45, 95, 45, 20, 45, 90, 45, 45, 45}
+	expressiveTertiary  = []float64{120, 120, 20, 45, 20, 15, 20, 120, 120}
+	errorPalette        = TonalPalette{Hue: 25, Chroma: 84}
+)
+
+// rotateHue rotates h by the rotation of the range it falls in
+func rotateHue(h float64, rotations []float64) float64 {
+	for i := 0; i < len(rotationHues)-1; i++ {
+		if rotationHues[i] < h && h < rotationHues[i+1] {
+			return sanitizeHue(h + rotations[i])
+		}
+	}
+	return h
+}
+
+// NewPalettes derives the tonal palettes of a variant from a seed color
+// Parameters:
+//   seed: source color, typically a brand color or an image's dominant color
+//   variant: palette style
+// Returns:
+//   Palettes: primary, secondary, tertiary, neutral, neutral variant and error
+// Example:
+//   p := NewPalettes(color.RGB{R: 103, G: 80, B: 164}, TonalSpot)
+//   p.Primary.Tone(40)
+func NewPalettes(seed color.RGB, variant Variant) Palettes {
+	hct := seed.ToHct()
+	h, c := hct.H, hct.C
+	p := Palettes{Error: errorPalette}
+	switch variant {
+	case Vibrant:
+		p.Primary = TonalPalette{h, 200}
+		p.Secondary = TonalPalette{rotateHue(h, vibrantSecondary), 24}
+		p.Tertiary = TonalPalette{rotateHue(h, vibrantTertiary), 32}
+		p.Neutral = TonalPalette{h, 10}
+		p.NeutralVariant = TonalPalette{h, 12}
+	case Expressive:
+		p.Primary = TonalPalette{sanitizeHue(h + 240), 40}
+		p.Secondary = TonalPalette{rotateHue(h, expressiveSecondary), 24}
+		p.Tertiary = TonalPalette{rotateHue(h, expressiveTertiary), 32}
+		p.Neutral = TonalPalette{sanitizeHue(h + 15), 8}
+		p.NeutralVariant = TonalPalette{sanitizeHue(h + 15), 12}
+	case Content, Fidelity:
+		// tertiary is an analogous hue for Content and the complement for
+		// Fidelity, rotated in HCT rather than picked by color temperature
+		p.Primary = TonalPalette{h, c}
+		p.Secondary = TonalPalette{h, math.Max(c-32, c*0.5)}
+		if variant == Content {
+			p.Tertiary = TonalPalette{sanitizeHue(h + 60), c / 2}
+		} else {
+			p.Tertiary = TonalPalette{sanitizeHue(h + 180), c / 2}
+		}
+		p.Neutral = TonalPalette{h, c / 8}
+		p.NeutralVariant = TonalPalette{h, c/8 + 4}
+	case Monochrome:
+		p.Primary = TonalPalette{h, 0}
+		p.Secondary = TonalPalette{h, 0}
+		p.Tertiary = TonalPalette{h, 0}
+		p.Neutral = TonalPalette{h, 0}
+		p.NeutralVariant = TonalPalette{h, 0}
+	default:
+		p.Primary = TonalPalette{h, 36}
+		p.Secondary = TonalPalette{h, 16}
+		p.Tertiary = TonalPalette{sanitizeHue(h + 60), 24}
+		p.Neutral = TonalPalette{h, 6}
+		p.NeutralVariant = TonalPalette{h, 8}
+	}
+	return p
+}
+
+// Scheme holds the color roles of a light or dark Material 3 theme
+type Scheme struct {
+	Primary, OnPrimary, PrimaryContainer, OnPrimaryContainer         color.RGB
+	Secondary, OnSecondary, SecondaryContainer, OnSecondaryContainer color.RGB
+	Tertiary, OnTertiary, TertiaryContainer, OnTertiaryContainer     color.RGB
+	Error, OnError, ErrorContainer, OnErrorContainer                 color.RGB
+	Background, OnBackground                                         color.RGB
+	Surface, OnSurface, SurfaceVariant, OnSurfaceVariant             color.RGB
+	Outline, OutlineVariant, Shadow, Scrim                           color.RGB
+	InverseSurface, InverseOnSurface, InversePrimary                 color.RGB
+}
+
+// accentTones are the tones of an accent role group
+type accentTones struct {
+	base, on, container, onContainer float64
+}
+
+func (t accentTones) colors(p TonalPalette) (color.RGB, color.RGB, color.RGB, color.RGB) {
+	return p.Tone(t.base), p.Tone(t.on), p.Tone(t.container), p.Tone(t.onContainer)
+}
+
+// NewScheme generates the color roles of a variant from a seed color
+// Parameters:
+//   seed: source color
+//   variant: palette style
+//   dark: true for the dark theme
+// Returns:
+//   Scheme: color roles as RGB values
+// Example:
+//   light := NewScheme(color.RGB{R: 103, G: 80, B: 164}, TonalSpot, false)
+//   dark := NewScheme(color.RGB{R: 103, G: 80, B: 164}, TonalSpot, true)
+func NewScheme(seed color.RGB, variant Variant, dark bool) Scheme {
+	p := NewPalettes(seed, variant)
+	accent := accentTones{40, 100, 90, 10}
+	if dark {
+		accent = accentTones{80, 20, 30, 90}
+	}
+	primary, secondary, tertiary := accent, accent, accent
+	switch variant {
+	case Monochrome:
+		if dark {
+			primary = accentTones{100, 10, 85, 0}
+			tertiary = accentTones{90, 10, 60, 0}
+		} else {
+			primary = accentTones{0, 90, 25, 100}
+			tertiary = accentTones{25, 90, 49, 100}
+		}
+	case Content, Fidelity:
+		// containers keep the seed's tone, with text tones picked for contrast
+		t := seed.ToHct().T
+		primary.container, primary.onContainer = t, foregroundTone(t, 4.5)
+		tertiary.container, tertiary.onContainer = t, foregroundTone(t, 4.5)
+	}
+
+	var s Scheme
+	s.Primary, s.OnPrimary, s.PrimaryContainer, s.OnPrimaryContainer = primary.colors(p.Primary)
+	s.Secondary, s.OnSecondary, s.SecondaryContainer, s.OnSecondaryContainer = secondary.colors(p.Secondary)
+	s.Tertiary, s.OnTertiary, s.TertiaryContainer, s.OnTertiaryContainer = tertiary.colors(p.Tertiary)
+	s.Error, s.OnError, s.ErrorContainer, s.OnErrorContainer = accent.colors(p.Error)
+	s.Shadow, s.Scrim = p.Neutral.Tone(0), p.Neutral.Tone(0)
+	if dark {
+		s.Background, s.OnBackground = p.Neutral.Tone(10), p.Neutral.Tone(90)
+		s.Surface, s.OnSurface = p.Neutral.Tone(10), p.Neutral.Tone(90)
+		s.SurfaceVariant, s.OnSurfaceVariant = p.NeutralVariant.Tone(30), p.NeutralVariant.Tone(80)
+		s.Outline, s.OutlineVariant = p.NeutralVariant.Tone(60), p.NeutralVariant.Tone(30)
+		s.InverseSurface, s.InverseOnSurface = p.Neutral.Tone(90), p.Neutral.Tone(20)
+		s.InversePrimary = p.Primary.Tone(40)
+	} else {
+		s.Background, s.OnBackground = p.Neutral.Tone(99), p.Neutral.Tone(10)
+		s.Surface, s.OnSurface = p.Neutral.Tone(99), p.Neutral.Tone(10)
+		s.SurfaceVariant, s.OnSurfaceVariant = p.NeutralVariant.Tone(90), p.NeutralVariant.Tone(30)
+		s.Outline, s.OutlineVariant = p.NeutralVariant.Tone(50), p.NeutralVariant.Tone(80)
+		s.InverseSurface, s.InverseOnSurface = p.Neutral.Tone(20), p.Neutral.Tone(95)
+		s.InversePrimary = p.Primary.Tone(80)
+	}
+	return s
+}
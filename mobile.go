@@ -0,0 +1,322 @@
+package color
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ToAndroidHex converts RGBA to the Android #AARRGGBB color resource form
+// Returns:
+//   string: "#AARRGGBB" format string, alpha first unlike CSS
+// Example:
+//   c := RGBA{RGB{255, 128, 0}, 0.5}
+//   s := c.ToAndroidHex() // returns "#80FF8000"
+func (c *RGBA) ToAndroidHex() string {
+	return fmt.Sprintf("#%02X%02X%02X%02X", unitToUint8(float64(c.A)), c.R, c.G, c.B)
+}
+
+// StrToAndroidHex parses an Android color resource value
+// Parameters:
+//   str: "#RGB", "#ARGB", "#RRGGBB" or "#AARRGGBB"; alpha comes first
+// Returns:
+//   *RGBA: pointer to RGBA object
+//   error: parsing error if format is invalid
+// Example:
+//   c, err := StrToAndroidHex("#80FF8000") // returns &RGBA{RGB{255,128,0},0.5}
+func StrToAndroidHex(str string) (*RGBA, error) {
+	s := strings.TrimSpace(str)
+	if !strings.HasPrefix(s, "#") {
+		return nil, fmt.Errorf("invalid android color: %s", str)
+	}
+	s = s[1:]
+	switch len(s) {
+	case 3:
+		s = "f" + s
+	case 6:
+		s = "ff" + s
+	case 4, 8:
+	default:
+		return nil, fmt.Errorf("invalid android color: %s", str)
+	}
+	if len(s) == 4 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2], s[3], s[3]})
+	}
+	v, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid android color: %s", str)
+	}
+	return &RGBA{RGB{uint8(v >> 16), uint8(v >> 8), uint8(v)}, float32(v>>24) / 255}, nil
+}
+
+// ToFlutter converts RGBA to a Flutter Color literal
+// Returns:
+//   string: "Color(0xAARRGGBB)" Dart expression
+// Example:
+//   c := RGBA{RGB{255, 128, 0}, 1.0}
+//   s := c.ToFlutter() // returns "Color(0xFFFF8000)"
+func (c *RGBA) ToFlutter() string {
+	return fmt.Sprintf("Color(0x%02X%02X%02X%02X)", unitToUint8(float64(c.A)), c.R, c.G, c.B)
+}
+
+var (
+	flutterHexPattern  = regexp.MustCompile(`^(?:const\s+)?Color\(\s*0[xX]([0-9a-fA-F]{8})\s*\)$`)
+	flutterArgsPattern = regexp.MustCompile(`^(?:const\s+)?Color\.(fromARGB|fromRGBO)\(([^)]*)\)$`)
+)
+
+// ParseFlutterColor parses a Flutter Color expression
+// Parameters:
+//   str: "Color(0xAARRGGBB)", "Color.fromARGB(a, r, g, b)" or
+//        "Color.fromRGBO(r, g, b, opacity)", optionally prefixed by "const"
+// Returns:
+//   RGBA: parsed color
+//   error: parsing error if the expression is not supported
+// Example:
+//   c, err := ParseFlutterColor("Color(0xFFFF8000)") // returns RGBA{RGB{255,128,0},1.0}
+func ParseFlutterColor(str string) (RGBA, error) {
+	s := strings.TrimSuffix(strings.TrimSpace(str), ";")
+	if m := flutterHexPattern.FindStringSubmatch(s); m != nil {
+		v, _ := strconv.ParseUint(m[1], 16, 32)
+		return RGBA{RGB{uint8(v >> 16), uint8(v >> 8), uint8(v)}, float32(v>>24) / 255}, nil
+	}
+	m := flutterArgsPattern.FindStringSubmatch(s)
+	if m == nil {
+		return RGBA{}, fmt.Errorf("invalid flutter color: %s", str)
+	}
+	args := strings.Split(m[2], ",")
+	if len(args) != 4 {
+		return RGBA{}, fmt.Errorf("invalid flutter color: %s", str)
+	}
+	var v [4]float64
+	for i, arg := range args {
+		f, err := strconv.ParseFloat(strings.TrimSpace(arg), 64)
+		if err != nil {
+			return RGBA{}, fmt.Errorf("invalid flutter color: %s", str)
+		}
+		v[i] = f
+	}
+	channel := func(f float64) uint8 { return uint8(math.Max(0, math.Min(255, math.Round(f)))) }
+	if m[1] == "fromARGB" {
+		return RGBA{RGB{channel(v[1]), channel(v[2]), channel(v[3])}, float32(channel(v[0])) / 255}, nil
+	}
+	return RGBA{RGB{channel(v[0]), channel(v[1]), channel(v[2])}, float32(math.Max(0, math.Min(1, v[3])))}, nil
+}
+
+// ToSwiftUI converts RGBA to a SwiftUI Color initializer
+// Returns:
+//   string: "Color(red: r, green: g, blue: b, opacity: a)" with 0-1 components
+// Example:
+//   c := RGBA{RGB{255, 128, 0}, 1.0}
+//   s := c.ToSwiftUI() // returns "Color(red: 1.000, green: 0.502, blue: 0.000, opacity: 1.000)"
+func (c *RGBA) ToSwiftUI() string {
+	return fmt.Sprintf("Color(red: %.3f, green: %.3f, blue: %.3f, opacity: %.3f)",
+		float64(c.R)/255, float64(c.G)/255, float64(c.B)/255, c.A)
+}
+
+var swiftArgPattern = regexp.MustCompile(`(red|green|blue|opacity|white)\s*:\s*([0-9.]+)(?:\s*/\s*([0-9.]+))?`)
+
+// ParseSwiftUIColor parses a SwiftUI Color initializer
+// The .sRGB color space label is accepted, components may be written as
+// divisions such as 128/255, and opacity defaults to 1.
+// Parameters:
+//   str: "Color(red:green:blue:opacity:)" or "Color(white:opacity:)" call
+// Returns:
+//   RGBA: parsed color
+//   error: parsing error if the expression is not supported
+// Example:
+//   c, err := ParseSwiftUIColor("Color(red: 1, green: 0.5, blue: 0)") // returns RGBA{RGB{255,128,0},1.0}
+func ParseSwiftUIColor(str string) (RGBA, error) {
+	s := strings.TrimSpace(str)
+	if !strings.HasPrefix(s, "Color(") || !strings.HasSuffix(s, ")") {
+		return RGBA{}, fmt.Errorf("invalid swiftui color: %s", str)
+	}
+	if strings.Contains(s, ".displayP3") || strings.Contains(s, ".sRGBLinear") {
+		return RGBA{}, fmt.Errorf("unsupported swiftui color space: %s", str)
+	}
+	args := map[string]float64{"opacity": 1}
+	for _, m := range swiftArgPattern.FindAllStringSubmatch(s, -1) {
+		v, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			return RGBA{}, fmt.Errorf("invalid swiftui color: %s", str)
+		}
+		if m[3] != "" {
+			d, err := strconv.ParseFloat(m[3], 64)
+			if err != nil || d == 0 {
+				return RGBA{}, fmt.Errorf("invalid swiftui color: %s", str)
+			}
+			v /= d
+		}
+		args[m[1]] = v
+	}
+	a := float32(math.Max(0, math.Min(1, args["opacity"])))
+	if w, ok := args["white"]; ok {
+		g := unitToUint8(w)
+		return RGBA{RGB{g, g, g}, a}, nil
+	}
+	for _, k := range []string{"red", "green", "blue"} {
+		if _, ok := args[k]; !ok {
+			return RGBA{}, fmt.Errorf("invalid swiftui color, missing %s: %s", k, str)
+		}
+	}
+	return RGBA{RGB{unitToUint8(args["red"]), unitToUint8(args["green"]), unitToUint8(args["blue"])}, a}, nil
+}
+
+// Colorset is an iOS asset catalog color (.colorset/Contents.json) with an
+// optional dark appearance
+type Colorset struct {
+	Light RGBA  // universal color, used when no appearance matches
+	Dark  *RGBA // color for the dark appearance, nil when absent
+}
+
+type colorsetFile struct {
+	Colors []colorsetEntry `json:"colors"`
+	Info   struct {
+		Author  string `json:"author"`
+		Version int    `json:"version"`
+	} `json:"info"`
+}
+
+type colorsetEntry struct {
+	Appearances []struct {
+		Appearance string `json:"appearance"`
+		Value      string `json:"value"`
+	} `json:"appearances,omitempty"`
+	Color struct {
+		ColorSpace string            `json:"color-space"`
+		Components map[string]string `json:"components"`
+	} `json:"color"`
+	Idiom string `json:"idiom"`
+}
+
+// parseColorsetComponent reads a component in any of the forms Xcode
+// writes: "0.502" floats, "0x80" hex bytes and "128" integers
+func parseColorsetComponent(s string, alpha bool) (float64, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		v, err := strconv.ParseUint(s[2:], 16, 8)
+		return float64(v) / 255, err
+	case strings.Contains(s, ".") || alpha:
+		return strconv.ParseFloat(s, 64)
+	}
+	v, err := strconv.ParseUint(s, 10, 8)
+	return float64(v) / 255, err
+}
+
+// rgba converts the color of a Contents.json entry to sRGB
+func (e *colorsetEntry) rgba() (RGBA, error) {
+	var c [4]float64
+	for i, k := range []string{"red", "green", "blue", "alpha"} {
+		s, ok := e.Color.Components[k]
+		if !ok {
+			if k == "alpha" {
+				c[i] = 1
+				continue
+			}
+			if w, ok := e.Color.Components["white"]; ok {
+				s = w
+			} else {
+				return RGBA{}, fmt.Errorf("colorset component %s missing", k)
+			}
+		}
+		v, err := parseColorsetComponent(s, k == "alpha")
+		if err != nil {
+			return RGBA{}, fmt.Errorf("invalid colorset component %s: %s", k, s)
+		}
+		c[i] = v
+	}
+	rgb := RGB{unitToUint8(c[0]), unitToUint8(c[1]), unitToUint8(c[2])}
+	switch e.Color.ColorSpace {
+	case "", "srgb", "extended-srgb", "gray-gamma-22", "extended-gray":
+	case "display-p3":
+		p3 := rgbColorSpaces["display-p3"]
+		x, y, z := mulMat3Vec(p3.toXyz, p3.decode(c[0]), p3.decode(c[1]), p3.decode(c[2]))
+		r, g, b := xyzToRgb(x, y, z)
+		rgb = RGB{r, g, b}
+	default:
+		return RGBA{}, fmt.Errorf("unsupported colorset color space %q", e.Color.ColorSpace)
+	}
+	return RGBA{rgb, float32(math.Max(0, math.Min(1, c[3])))}, nil
+}
+
+// ReadColorset reads an iOS asset catalog Contents.json color
+// The universal entry without appearances is the light color; entries for
+// other idioms and appearances such as high contrast are ignored.
+// Parameters:
+//   r: reader with the Contents.json data
+// Returns:
+//   *Colorset: light and optional dark color
+//   error: JSON error, missing universal color or invalid component
+// Example:
+//   f, _ := os.Open("Brand.colorset/Contents.json")
+//   set, err := ReadColorset(f)
+func ReadColorset(r io.Reader) (*Colorset, error) {
+	var file colorsetFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid colorset: %w", err)
+	}
+	set := &Colorset{}
+	found := false
+	for _, e := range file.Colors {
+		if e.Idiom != "" && e.Idiom != "universal" {
+			continue
+		}
+		c, err := e.rgba()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case len(e.Appearances) == 0:
+			set.Light, found = c, true
+		case len(e.Appearances) == 1 && e.Appearances[0].Appearance == "luminosity" && e.Appearances[0].Value == "dark":
+			set.Dark = &c
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("colorset has no universal color")
+	}
+	return set, nil
+}
+
+// WriteColorset writes an iOS asset catalog Contents.json color in sRGB
+// with float components, the form Xcode uses by default
+// Parameters:
+//   w: destination for the JSON
+//   set: colors to write
+// Returns:
+//   error: write error
+func WriteColorset(w io.Writer, set *Colorset) error {
+	entry := func(c RGBA) colorsetEntry {
+		var e colorsetEntry
+		e.Idiom = "universal"
+		e.Color.ColorSpace = "srgb"
+		e.Color.Components = map[string]string{
+			"red":   fmt.Sprintf("%.3f", float64(c.R)/255),
+			"green": fmt.Sprintf("%.3f", float64(c.G)/255),
+			"blue":  fmt.Sprintf("%.3f", float64(c.B)/255),
+			"alpha": fmt.Sprintf("%.3f", c.A),
+		}
+		return e
+	}
+	var file colorsetFile
+	file.Info.Author, file.Info.Version = "xcode", 1
+	file.Colors = append(file.Colors, entry(set.Light))
+	if set.Dark != nil {
+		dark := entry(*set.Dark)
+		dark.Appearances = append(dark.Appearances, struct {
+			Appearance string `json:"appearance"`
+			Value      string `json:"value"`
+		}{"luminosity", "dark"})
+		file.Colors = append(file.Colors, dark)
+	}
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
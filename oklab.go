@@ -0,0 +1,407 @@
+package color
+
+import (
+	"fmt"
+	"math"
+)
+
+// OKLAB is a color in Björn Ottosson's Oklab perceptual space relative to
+// a D65 white, as used by CSS oklab(); L is in 0-1
+type OKLAB struct {
+	L, A, B float64
+}
+
+// OKLCH is the polar form of OKLAB with chroma C and hue H in degrees,
+// as used by CSS oklch()
+type OKLCH struct {
+	L, C, H float64
+}
+
+// Linear sRGB to cone response and cone response to Oklab, from Björn
+// Ottosson's reference implementation; chained with the sRGB matrix so
+// that the D65 white maps to L=1 exactly
+var (
+	srgbToOklmsMatrix = [3][3]float64{
+		{0.4122214708, 0.5363325363, 0.0514459929},
+		{0.2119034982, 0.6806995451, 0.1073969566},
+		{0.0883024619, 0.2817188376, 0.6299787005},
+	}
+	xyzToOklmsMatrix   = mulMat3(srgbToOklmsMatrix, xyzToSrgbMatrix)
+	oklmsToOklabMatrix = [3][3]float64{
+		{0.2104542553, 0.7936177850, -0.0040720468},
+		{1.9779984951, -2.4285922050, 0.4505937099},
+		{0.0259040425, 0.7827717662, -0.8086757660},
+	}
+	oklmsToXyzMatrix   = invertMat3(xyzToOklmsMatrix)
+	oklabToOklmsMatrix = invertMat3(oklmsToOklabMatrix)
+)
+
+// StrToOklab converts an oklab() format string to OKLAB object
+// Parameters:
+//   str: string in "oklab(l,a,b)" format
+// Returns:
+//   *OKLAB: pointer to OKLAB object
+//   error: parsing error if format is invalid
+// Example:
+//   c, err := StrToOklab("oklab(0.6280,0.2249,0.1258)") // red
+func StrToOklab(str string) (*OKLAB, error) {
+	var l, a, b float64
+	_, err := fmt.Sscanf(RemoveSpace(str), "oklab(%f,%f,%f)", &l, &a, &b)
+	if err != nil {
+		return nil, err
+	}
+	return &OKLAB{L: l, A: a, B: b}, nil
+}
+
+// String converts OKLAB object to oklab() format string
+// Returns:
+//   string: "oklab(l,a,b)" formatted string
+// Example:
+//   c := OKLAB{0.628, 0.2249, 0.1258}
+//   fmt.Println(c.String()) // outputs "oklab(0.6280,0.2249,0.1258)"
+func (c *OKLAB) String() string {
+	return fmt.Sprintf("oklab(%.4f,%.4f,%.4f)", c.L, c.A, c.B)
+}
+
+// ToXyz converts OKLAB to CIE XYZ (D65) representation
+// Returns:
+//   XYZ: corresponding XYZ color object
+// Example:
+//   c := OKLAB{1, 0, 0} // white
+//   xyz := c.ToXyz() // returns XYZ{0.9505,1.0000,1.0888}
+func (c *OKLAB) ToXyz() XYZ {
+	l, m, s := mulMat3Vec(oklabToOklmsMatrix, c.L, c.A, c.B)
+	x, y, z := mulMat3Vec(oklmsToXyzMatrix, l*l*l, m*m*m, s*s*s)
+	return XYZ{X: x, Y: y, Z: z}
+}
+
+// ToRgb converts OKLAB to sRGB representation, clipping out-of-gamut values
+// Returns:
+//   RGB: corresponding RGB color object
+// Example:
+//   c := OKLAB{0.628, 0.2249, 0.1258} // red
+//   rgb := c.ToRgb() // returns RGB{255,0,0}
+func (c *OKLAB) ToRgb() RGB {
+	xyz := c.ToXyz()
+	return xyz.ToRgb()
+}
+
+// ToRgba converts OKLAB to RGBA with full opacity
+// Returns:
+//   RGBA: RGBA object with alpha=1.0
+// Example:
+//   c := OKLAB{0, 0, 0} // black
+//   rgba := c.ToRgba() // returns RGBA{RGB{0,0,0},1.0}
+func (c *OKLAB) ToRgba() RGBA {
+	return RGBA{c.ToRgb(), 1.0}
+}
+
+// ToHex converts OKLAB to hexadecimal string
+// Returns:
+//   string: "#RRGGBB" format string
+// Example:
+//   c := OKLAB{0.452, -0.0325, -0.3115} // blue
+//   hex := c.ToHex() // returns "#0000ff"
+func (c *OKLAB) ToHex() string {
+	rgb := c.ToRgb()
+	return rgb.ToHex()
+}
+
+// ToHsl converts OKLAB to HSL representation
+// Returns:
+//   HSL: corresponding HSL color object
+// Example:
+//   c := OKLAB{0.628, 0.2249, 0.1258} // red
+//   hsl := c.ToHsl() // returns HSL{0,100,50}
+func (c *OKLAB) ToHsl() HSL {
+	rgb := c.ToRgb()
+	return rgb.ToHsl()
+}
+
+// ToHsla converts OKLAB to HSLA with full opacity
+// Returns:
+//   HSLA: HSLA object with alpha=1.0
+// Example:
+//   c := OKLAB{0.628, 0.2249, 0.1258} // red
+//   hsla := c.ToHsla() // returns HSLA{HSL{0,100,50},1.0}
+func (c *OKLAB) ToHsla() HSLA {
+	rgb := c.ToRgb()
+	return rgb.ToHsla()
+}
+
+// ToHsv converts OKLAB to HSV representation
+// Returns:
+//   HSV: corresponding HSV color object
+// Example:
+//   c := OKLAB{0.8664, -0.2339, 0.1795} // green
+//   hsv := c.ToHsv() // returns HSV{120,100,100}
+func (c *OKLAB) ToHsv() HSV {
+	rgb := c.ToRgb()
+	return rgb.ToHsv()
+}
+
+// ToCmyk converts OKLAB to CMYK representation
+// Returns:
+//   CMYK: corresponding CMYK color object
+// Example:
+//   c := OKLAB{1, 0, 0} // white
+//   cmyk := c.ToCmyk() // returns CMYK{0,0,0,0}
+func (c *OKLAB) ToCmyk() CMYK {
+	rgb := c.ToRgb()
+	return rgb.ToCmyk()
+}
+
+// ToLab converts OKLAB to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c := OKLAB{1, 0, 0} // white
+//   lab := c.ToLab() // returns LAB{100,0,0}
+func (c *OKLAB) ToLab() LAB {
+	return xyzToLabD50(c.ToXyz())
+}
+
+// ToOklab returns a copy of the OKLAB color
+// Returns:
+//   OKLAB: the same color
+func (c *OKLAB) ToOklab() OKLAB {
+	return *c
+}
+
+// ToOklch converts OKLAB to its polar OKLCH form
+// Returns:
+//   OKLCH: corresponding OKLCH color object, hue 0 for grays
+// Example:
+//   c := OKLAB{0.628, 0.2249, 0.1258} // red
+//   lch := c.ToOklch() // returns OKLCH{0.6280,0.2577,29.23}
+func (c *OKLAB) ToOklch() OKLCH {
+	return oklabToOklch(*c)
+}
+
+// ToHct converts OKLAB to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c := OKLAB{1, 0, 0} // white
+//   hct := c.ToHct() // returns HCT{209.49,2.87,100.00}
+func (c *OKLAB) ToHct() HCT {
+	rgb := c.ToRgb()
+	return rgb.ToHct()
+}
+
+// ToHsluv converts OKLAB to HSLuv representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c := OKLAB{1, 0, 0} // white
+//   hsl := c.ToHsluv() // returns HSLUV{0.00,0.00,100.00}
+func (c *OKLAB) ToHsluv() HSLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHsluv()
+}
+
+// ToHpluv converts OKLAB to HPLuv representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c := OKLAB{1, 0, 0} // white
+//   hpl := c.ToHpluv() // returns HPLUV{0.00,0.00,100.00}
+func (c *OKLAB) ToHpluv() HPLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHpluv()
+}
+
+// StrToOklch converts an oklch() format string to OKLCH object
+// Parameters:
+//   str: string in "oklch(l,c,h)" format
+// Returns:
+//   *OKLCH: pointer to OKLCH object
+//   error: parsing error if format is invalid
+// Example:
+//   c, err := StrToOklch("oklch(0.6280,0.2577,29.23)") // red
+func StrToOklch(str string) (*OKLCH, error) {
+	var l, ch, h float64
+	_, err := fmt.Sscanf(RemoveSpace(str), "oklch(%f,%f,%f)", &l, &ch, &h)
+	if err != nil {
+		return nil, err
+	}
+	return &OKLCH{L: l, C: ch, H: h}, nil
+}
+
+// String converts OKLCH object to oklch() format string
+// Returns:
+//   string: "oklch(l,c,h)" formatted string
+// Example:
+//   c := OKLCH{0.628, 0.2577, 29.23}
+//   fmt.Println(c.String()) // outputs "oklch(0.6280,0.2577,29.23)"
+func (c *OKLCH) String() string {
+	return fmt.Sprintf("oklch(%.4f,%.4f,%.2f)", c.L, c.C, c.H)
+}
+
+// ToOklab converts OKLCH to its rectangular OKLAB form
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c := OKLCH{0.628, 0.2577, 29.23} // red
+//   lab := c.ToOklab() // returns OKLAB{0.6280,0.2249,0.1258}
+func (c *OKLCH) ToOklab() OKLAB {
+	h := c.H * math.Pi / 180
+	return OKLAB{L: c.L, A: c.C * math.Cos(h), B: c.C * math.Sin(h)}
+}
+
+// ToOklch returns a copy of the OKLCH color
+// Returns:
+//   OKLCH: the same color
+func (c *OKLCH) ToOklch() OKLCH {
+	return *c
+}
+
+// ToHct converts OKLCH to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c := OKLCH{0.628, 0.2577, 29.23} // red
+//   hct := c.ToHct() // returns HCT{27.41,113.36,53.24}
+func (c *OKLCH) ToHct() HCT {
+	rgb := c.ToRgb()
+	return rgb.ToHct()
+}
+
+// ToHsluv converts OKLCH to HSLuv representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c := OKLCH{0.628, 0.2577, 29.23} // red
+//   hsl := c.ToHsluv() // returns HSLUV{12.18,100.00,53.24}
+func (c *OKLCH) ToHsluv() HSLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHsluv()
+}
+
+// ToHpluv converts OKLCH to HPLuv representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c := OKLCH{0.628, 0.2577, 29.23} // red
+//   hpl := c.ToHpluv() // returns HPLUV{12.18,426.75,53.24}
+func (c *OKLCH) ToHpluv() HPLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHpluv()
+}
+
+// ToXyz converts OKLCH to CIE XYZ (D65) representation
+// Returns:
+//   XYZ: corresponding XYZ color object
+// Example:
+//   c := OKLCH{1, 0, 0} // white
+//   xyz := c.ToXyz() // returns XYZ{0.9505,1.0000,1.0888}
+func (c *OKLCH) ToXyz() XYZ {
+	lab := c.ToOklab()
+	return lab.ToXyz()
+}
+
+// ToRgb converts OKLCH to sRGB representation, clipping out-of-gamut values
+// Returns:
+//   RGB: corresponding RGB color object
+// Example:
+//   c := OKLCH{0.628, 0.2577, 29.23} // red
+//   rgb := c.ToRgb() // returns RGB{255,0,0}
+func (c *OKLCH) ToRgb() RGB {
+	lab := c.ToOklab()
+	return lab.ToRgb()
+}
+
+// ToRgba converts OKLCH to RGBA with full opacity
+// Returns:
+//   RGBA: RGBA object with alpha=1.0
+// Example:
+//   c := OKLCH{0, 0, 0} // black
+//   rgba := c.ToRgba() // returns RGBA{RGB{0,0,0},1.0}
+func (c *OKLCH) ToRgba() RGBA {
+	return RGBA{c.ToRgb(), 1.0}
+}
+
+// ToHex converts OKLCH to hexadecimal string
+// Returns:
+//   string: "#RRGGBB" format string
+// Example:
+//   c := OKLCH{0.452, 0.3132, 264.05} // blue
+//   hex := c.ToHex() // returns "#0000ff"
+func (c *OKLCH) ToHex() string {
+	rgb := c.ToRgb()
+	return rgb.ToHex()
+}
+
+// ToHsl converts OKLCH to HSL representation
+// Returns:
+//   HSL: corresponding HSL color object
+// Example:
+//   c := OKLCH{0.628, 0.2577, 29.23} // red
+//   hsl := c.ToHsl() // returns HSL{0,100,50}
+func (c *OKLCH) ToHsl() HSL {
+	rgb := c.ToRgb()
+	return rgb.ToHsl()
+}
+
+// ToHsla converts OKLCH to HSLA with full opacity
+// Returns:
+//   HSLA: HSLA object with alpha=1.0
+// Example:
+//   c := OKLCH{0.628, 0.2577, 29.23} // red
+//   hsla := c.ToHsla() // returns HSLA{HSL{0,100,50},1.0}
+func (c *OKLCH) ToHsla() HSLA {
+	rgb := c.ToRgb()
+	return rgb.ToHsla()
+}
+
+// ToHsv converts OKLCH to HSV representation
+// Returns:
+//   HSV: corresponding HSV color object
+// Example:
+//   c := OKLCH{0.8664, 0.2948, 142.5} // green
+//   hsv := c.ToHsv() // returns HSV{120,100,100}
+func (c *OKLCH) ToHsv() HSV {
+	rgb := c.ToRgb()
+	return rgb.ToHsv()
+}
+
+// ToCmyk converts OKLCH to CMYK representation
+// Returns:
+//   CMYK: corresponding CMYK color object
+// Example:
+//   c := OKLCH{1, 0, 0} // white
+//   cmyk := c.ToCmyk() // returns CMYK{0,0,0,0}
+func (c *OKLCH) ToCmyk() CMYK {
+	rgb := c.ToRgb()
+	return rgb.ToCmyk()
+}
+
+// ToLab converts OKLCH to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c := OKLCH{1, 0, 0} // white
+//   lab := c.ToLab() // returns LAB{100,0,0}
+func (c *OKLCH) ToLab() LAB {
+	return xyzToLabD50(c.ToXyz())
+}
+
+// xyzToOklab converts a D65 XYZ value to Oklab
+func xyzToOklab(c XYZ) OKLAB {
+	l, m, s := mulMat3Vec(xyzToOklmsMatrix, c.X, c.Y, c.Z)
+	L, a, b := mulMat3Vec(oklmsToOklabMatrix, math.Cbrt(l), math.Cbrt(m), math.Cbrt(s))
+	return OKLAB{L: L, A: a, B: b}
+}
+
+// oklabToOklch converts Oklab to its polar form with the hue in 0-360
+func oklabToOklch(c OKLAB) OKLCH {
+	ch := math.Hypot(c.A, c.B)
+	if ch < 1e-6 {
+		return OKLCH{L: c.L}
+	}
+	h := math.Atan2(c.B, c.A) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return OKLCH{L: c.L, C: ch, H: h}
+}
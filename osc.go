@@ -0,0 +1,231 @@
+package color
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// OscColorTarget is the OSC code of a dynamic terminal color
+type OscColorTarget int
+
+const (
+	OscPalette    OscColorTarget = 4
+	OscForeground OscColorTarget = 10
+	OscBackground OscColorTarget = 11
+	OscCursor     OscColorTarget = 12
+)
+
+// oscTerminator is the string terminator (ST) appended to built sequences
+const oscTerminator = "\x1b\\"
+
+// OscReply is a color reported by the terminal in answer to a query
+type OscReply struct {
+	Target OscColorTarget
+	Index  int // palette index, only meaningful for OscPalette
+	Color  RGB
+}
+
+// OscSetColor builds the sequence that changes a dynamic terminal color
+// Parameters:
+//   target: OscForeground, OscBackground or OscCursor
+//   c: new color
+// Returns:
+//   string: escape sequence terminated by ST
+// Example:
+//   seq := OscSetColor(OscBackground, RGB{0,43,54}) // returns "\x1b]11;rgb:00/2b/36\x1b\\"
+func OscSetColor(target OscColorTarget, c RGB) string {
+	return fmt.Sprintf("\x1b]%d;%s%s", target, formatOscRgb(c), oscTerminator)
+}
+
+// OscQueryColor builds the sequence that asks the terminal for a dynamic color
+// Parameters:
+//   target: OscForeground, OscBackground or OscCursor
+// Returns:
+//   string: escape sequence terminated by ST
+// Example:
+//   seq := OscQueryColor(OscBackground) // returns "\x1b]11;?\x1b\\"
+func OscQueryColor(target OscColorTarget) string {
+	return fmt.Sprintf("\x1b]%d;?%s", target, oscTerminator)
+}
+
+// OscSetPaletteColor builds the OSC 4 sequence that changes a palette entry
+// Parameters:
+//   index: palette index 0-255
+//   c: new color
+// Returns:
+//   string: escape sequence terminated by ST
+// Example:
+//   seq := OscSetPaletteColor(1, RGB{255,0,0}) // returns "\x1b]4;1;rgb:ff/00/00\x1b\\"
+func OscSetPaletteColor(index uint8, c RGB) string {
+	return fmt.Sprintf("\x1b]4;%d;%s%s", index, formatOscRgb(c), oscTerminator)
+}
+
+// OscQueryPaletteColor builds the OSC 4 sequence that asks for a palette entry
+// Parameters:
+//   index: palette index 0-255
+// Returns:
+//   string: escape sequence terminated by ST
+// Example:
+//   seq := OscQueryPaletteColor(1) // returns "\x1b]4;1;?\x1b\\"
+func OscQueryPaletteColor(index uint8) string {
+	return fmt.Sprintf("\x1b]4;%d;?%s", index, oscTerminator)
+}
+
+// ParseOscReply parses a terminal reply to an OSC 4/10/11/12 color query
+// The leading ESC ] and the BEL or ST terminator are optional.
+// Parameters:
+//   reply: reply such as "\x1b]11;rgb:ffff/8080/0000\x07"
+// Returns:
+//   OscReply: reported target, palette index and color
+//   error: error if the reply is not a color report
+// Example:
+//   r, err := ParseOscReply("\x1b]11;rgb:0000/2b2b/3636\x1b\\") // background RGB{0,43,54}
+func ParseOscReply(reply string) (OscReply, error) {
+	s := strings.TrimPrefix(reply, "\x1b]")
+	s = strings.TrimSuffix(strings.TrimSuffix(s, "\a"), oscTerminator)
+	parts := strings.Split(s, ";")
+	if len(parts) < 2 {
+		return OscReply{}, fmt.Errorf("invalid osc reply: %q", reply)
+	}
+	code, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return OscReply{}, fmt.Errorf("invalid osc reply: %q", reply)
+	}
+	res := OscReply{Target: OscColorTarget(code)}
+	spec := parts[1]
+	switch res.Target {
+	case OscPalette:
+		if len(parts) != 3 {
+			return OscReply{}, fmt.Errorf("invalid osc reply: %q", reply)
+		}
+		res.Index, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return OscReply{}, fmt.Errorf("invalid osc reply: %q", reply)
+		}
+		spec = parts[2]
+	case OscForeground, OscBackground, OscCursor:
+		if len(parts) != 2 {
+			return OscReply{}, fmt.Errorf("invalid osc reply: %q", reply)
+		}
+	default:
+		return OscReply{}, fmt.Errorf("unsupported osc code: %d", code)
+	}
+	res.Color, err = parseRgbSpec(spec)
+	if err != nil {
+		return OscReply{}, err
+	}
+	return res, nil
+}
+
+// QueryOscColor asks the terminal for a dynamic color and waits for its reply
+// The caller is responsible for putting the terminal in raw mode and for
+// arranging a read deadline, since terminals that do not support the query
+// never answer.
+// Parameters:
+//   w: terminal input, the query is written here
+//   r: terminal output, the reply is read from here
+//   target: OscForeground, OscBackground or OscCursor
+// Returns:
+//   RGB: reported color
+//   error: write, read or parse error
+// Example:
+//   bg, err := QueryOscColor(tty, tty, OscBackground)
+func QueryOscColor(w io.Writer, r io.Reader, target OscColorTarget) (RGB, error) {
+	if _, err := io.WriteString(w, OscQueryColor(target)); err != nil {
+		return RGB{}, err
+	}
+	return readOscColor(r, target, 0)
+}
+
+// QueryOscPaletteColor asks the terminal for a palette entry and waits for its reply
+// Parameters:
+//   w: terminal input, the query is written here
+//   r: terminal output, the reply is read from here
+//   index: palette index 0-255
+// Returns:
+//   RGB: reported color
+//   error: write, read or parse error
+// Example:
+//   red, err := QueryOscPaletteColor(tty, tty, 1)
+func QueryOscPaletteColor(w io.Writer, r io.Reader, index uint8) (RGB, error) {
+	if _, err := io.WriteString(w, OscQueryPaletteColor(index)); err != nil {
+		return RGB{}, err
+	}
+	return readOscColor(r, OscPalette, int(index))
+}
+
+// readOscColor reads one OSC reply byte by byte, so nothing past the
+// terminator is consumed from r, and checks it answers the query
+func readOscColor(r io.Reader, target OscColorTarget, index int) (RGB, error) {
+	var buf []byte
+	b := make([]byte, 1)
+	for {
+		if _, err := io.ReadFull(r, b); err != nil {
+			return RGB{}, err
+		}
+		buf = append(buf, b[0])
+		if b[0] == '\a' || strings.HasSuffix(string(buf), oscTerminator) {
+			break
+		}
+	}
+	reply := string(buf)
+	if i := strings.Index(reply, "\x1b]"); i > 0 {
+		reply = reply[i:]
+	}
+	res, err := ParseOscReply(reply)
+	if err != nil {
+		return RGB{}, err
+	}
+	if res.Target != target || res.Index != index {
+		return RGB{}, fmt.Errorf("unexpected osc reply: %q", reply)
+	}
+	return res.Color, nil
+}
+
+// formatOscRgb formats a color as an X11 "rgb:rr/gg/bb" spec
+func formatOscRgb(c RGB) string {
+	return fmt.Sprintf("rgb:%02x/%02x/%02x", c.R, c.G, c.B)
+}
+
+// parseRgbSpec parses an X11 "rgb:r/g/b" or "rgba:r/g/b/a" spec with 1-4 hex
+// digits per channel, scaling each channel to 0-255
+func parseRgbSpec(spec string) (RGB, error) {
+	var body string
+	var n int
+	switch {
+	case strings.HasPrefix(spec, "rgb:"):
+		body, n = spec[4:], 3
+	case strings.HasPrefix(spec, "rgba:"):
+		body, n = spec[5:], 4
+	default:
+		return RGB{}, fmt.Errorf("invalid rgb spec: %s", spec)
+	}
+	parts := strings.Split(body, "/")
+	if len(parts) != n {
+		return RGB{}, fmt.Errorf("invalid rgb spec: %s", spec)
+	}
+	var ch [3]uint8
+	for i := 0; i < 3; i++ {
+		v, err := scaleHexChannel(parts[i])
+		if err != nil {
+			return RGB{}, fmt.Errorf("invalid rgb spec: %s", spec)
+		}
+		ch[i] = v
+	}
+	return RGB{ch[0], ch[1], ch[2]}, nil
+}
+
+// scaleHexChannel scales a 1-4 digit hex channel value to 0-255
+func scaleHexChannel(s string) (uint8, error) {
+	if len(s) < 1 || len(s) > 4 {
+		return 0, fmt.Errorf("invalid hex channel: %s", s)
+	}
+	v, err := strconv.ParseUint(s, 16, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid hex channel: %s", s)
+	}
+	maxVal := uint64(1)<<(4*len(s)) - 1
+	return uint8((v*255 + maxVal/2) / maxVal), nil
+}
@@ -0,0 +1,223 @@
+package color
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// PixelFormat is a packed integer pixel layout; channels are named from
+// the most significant bits down, so ARGB8888 keeps alpha in bits 31-24
+type PixelFormat int
+
+const (
+	RGB565   PixelFormat = iota // 16 bit, red in bits 15-11
+	BGR565                      // 16 bit, blue in bits 15-11
+	RGB555                      // 16 bit, bit 15 unused
+	ARGB1555                    // 16 bit, 1 bit alpha
+	ARGB4444                    // 16 bit, 4 bits per channel
+	RGBA8888                    // 32 bit, alpha in bits 7-0
+	ARGB8888                    // 32 bit, alpha in bits 31-24
+	BGRA8888                    // 32 bit, blue in bits 31-24
+	ABGR8888                    // 32 bit, alpha in bits 31-24, red in bits 7-0
+)
+
+// pixelLayout gives bit widths and shifts of r, g, b, a; a zero alpha width
+// means the format is opaque
+type pixelLayout struct {
+	bits  int
+	width [4]uint
+	shift [4]uint
+}
+
+var pixelLayouts = [...]pixelLayout{
+	RGB565:   {16, [4]uint{5, 6, 5, 0}, [4]uint{11, 5, 0, 0}},
+	BGR565:   {16, [4]uint{5, 6, 5, 0}, [4]uint{0, 5, 11, 0}},
+	RGB555:   {16, [4]uint{5, 5, 5, 0}, [4]uint{10, 5, 0, 0}},
+	ARGB1555: {16, [4]uint{5, 5, 5, 1}, [4]uint{10, 5, 0, 15}},
+	ARGB4444: {16, [4]uint{4, 4, 4, 4}, [4]uint{8, 4, 0, 12}},
+	RGBA8888: {32, [4]uint{8, 8, 8, 8}, [4]uint{24, 16, 8, 0}},
+	ARGB8888: {32, [4]uint{8, 8, 8, 8}, [4]uint{16, 8, 0, 24}},
+	BGRA8888: {32, [4]uint{8, 8, 8, 8}, [4]uint{8, 16, 24, 0}},
+	ABGR8888: {32, [4]uint{8, 8, 8, 8}, [4]uint{0, 8, 16, 24}},
+}
+
+var pixelFormatNames = [...]string{
+	RGB565: "RGB565", BGR565: "BGR565", RGB555: "RGB555", ARGB1555: "ARGB1555", ARGB4444: "ARGB4444",
+	RGBA8888: "RGBA8888", ARGB8888: "ARGB8888", BGRA8888: "BGRA8888", ABGR8888: "ABGR8888",
+}
+
+// String returns the format name
+func (f PixelFormat) String() string {
+	if f >= 0 && int(f) < len(pixelFormatNames) {
+		return pixelFormatNames[f]
+	}
+	return fmt.Sprintf("PixelFormat(%d)", int(f))
+}
+
+// Bits returns the pixel size in bits, 16 or 32
+func (f PixelFormat) Bits() int {
+	return pixelLayouts[f].bits
+}
+
+// HasAlpha reports whether the format stores an alpha channel
+func (f PixelFormat) HasAlpha() bool {
+	return pixelLayouts[f].width[3] > 0
+}
+
+// reduceChannel rounds an 8-bit value to the nearest n-bit value
+func reduceChannel(v uint8, n uint) uint32 {
+	max := uint32(1)<<n - 1
+	return (uint32(v)*max + 127) / 255
+}
+
+// expandChannel rounds an n-bit value to the nearest 8-bit value, so the
+// maximum maps to 255 and every value survives a reduce round trip
+func expandChannel(v uint32, n uint) uint8 {
+	max := uint32(1)<<n - 1
+	return uint8((v*255 + max/2) / max)
+}
+
+// Pack encodes RGBA into a packed pixel; alpha is dropped by opaque formats
+// and is not premultiplied
+// Parameters:
+//   f: target pixel format
+// Returns:
+//   uint32: packed pixel, in the low 16 bits for 16 bit formats
+// Example:
+//   c := RGBA{RGB{255, 128, 0}, 1.0}
+//   v := c.Pack(RGB565) // returns 0xFC00
+func (c *RGBA) Pack(f PixelFormat) uint32 {
+	l := pixelLayouts[f]
+	channels := [4]uint8{c.R, c.G, c.B, unitToUint8(float64(c.A))}
+	var v uint32
+	for i, ch := range channels {
+		if l.width[i] > 0 {
+			v |= reduceChannel(ch, l.width[i]) << l.shift[i]
+		}
+	}
+	return v
+}
+
+// Pack encodes an opaque RGB color into a packed pixel
+// Parameters:
+//   f: target pixel format
+// Returns:
+//   uint32: packed pixel, in the low 16 bits for 16 bit formats
+// Example:
+//   c := RGB{255, 0, 0}
+//   v := c.Pack(ARGB8888) // returns 0xFFFF0000
+func (c *RGB) Pack(f PixelFormat) uint32 {
+	rgba := RGBA{*c, 1.0}
+	return rgba.Pack(f)
+}
+
+// UnpackPixel decodes a packed pixel with rounding bit expansion
+// Parameters:
+//   v: packed pixel
+//   f: pixel format of v
+// Returns:
+//   RGBA: decoded color, opaque for formats without alpha
+// Example:
+//   c := UnpackPixel(0xFC00, RGB565) // returns RGBA{RGB{255,130,0},1.0}
+func UnpackPixel(v uint32, f PixelFormat) RGBA {
+	l := pixelLayouts[f]
+	var ch [4]uint8
+	for i := range ch {
+		if l.width[i] > 0 {
+			ch[i] = expandChannel(v>>l.shift[i]&(1<<l.width[i]-1), l.width[i])
+		}
+	}
+	a := float32(1.0)
+	if l.width[3] > 0 {
+		a = float32(ch[3]) / 255
+	}
+	return RGBA{RGB{ch[0], ch[1], ch[2]}, a}
+}
+
+// EncodePixels appends the packed pixels of src to dst in the given byte order,
+// 2 or 4 bytes per pixel, e.g. for writing a framebuffer
+// Parameters:
+//   dst: buffer to append to, may be nil
+//   src: colors to encode
+//   f: pixel format
+//   order: byte order of each pixel, binary.LittleEndian for most hardware
+// Returns:
+//   []byte: extended buffer
+// Example:
+//   fb := EncodePixels(nil, pixels, RGB565, binary.LittleEndian)
+func EncodePixels(dst []byte, src []RGBA, f PixelFormat, order binary.ByteOrder) []byte {
+	size := f.Bits() / 8
+	start := len(dst)
+	dst = append(dst, make([]byte, len(src)*size)...)
+	for i := range src {
+		v := src[i].Pack(f)
+		if size == 2 {
+			order.PutUint16(dst[start+i*2:], uint16(v))
+		} else {
+			order.PutUint32(dst[start+i*4:], v)
+		}
+	}
+	return dst
+}
+
+// DecodePixels decodes a buffer of packed pixels in the given byte order
+// Parameters:
+//   src: packed pixel data
+//   f: pixel format
+//   order: byte order of each pixel
+// Returns:
+//   []RGBA: decoded colors
+//   error: error if the buffer length is not a multiple of the pixel size
+func DecodePixels(src []byte, f PixelFormat, order binary.ByteOrder) ([]RGBA, error) {
+	size := f.Bits() / 8
+	if len(src)%size != 0 {
+		return nil, fmt.Errorf("%s buffer length %d is not a multiple of %d", f, len(src), size)
+	}
+	colors := make([]RGBA, len(src)/size)
+	for i := range colors {
+		var v uint32
+		if size == 2 {
+			v = uint32(order.Uint16(src[i*2:]))
+		} else {
+			v = order.Uint32(src[i*4:])
+		}
+		colors[i] = UnpackPixel(v, f)
+	}
+	return colors, nil
+}
+
+// PackPixels16 encodes colors into a slice of 16 bit pixels
+// Parameters:
+//   src: colors to encode
+//   f: a 16 bit pixel format
+// Returns:
+//   []uint16: packed pixels
+//   error: error if f is a 32 bit format
+func PackPixels16(src []RGBA, f PixelFormat) ([]uint16, error) {
+	if f.Bits() != 16 {
+		return nil, fmt.Errorf("%s is not a 16 bit format", f)
+	}
+	dst := make([]uint16, len(src))
+	for i := range src {
+		dst[i] = uint16(src[i].Pack(f))
+	}
+	return dst, nil
+}
+
+// PackPixels32 encodes colors into a slice of 32 bit pixels
+// Parameters:
+//   src: colors to encode
+//   f: a 32 bit pixel format
+// Returns:
+//   []uint32: packed pixels
+//   error: error if f is a 16 bit format
+func PackPixels32(src []RGBA, f PixelFormat) ([]uint32, error) {
+	if f.Bits() != 32 {
+		return nil, fmt.Errorf("%s is not a 32 bit format", f)
+	}
+	dst := make([]uint32, len(src))
+	for i := range src {
+		dst[i] = src[i].Pack(f)
+	}
+	return dst, nil
+}
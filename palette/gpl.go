@@ -0,0 +1,97 @@
+package palette
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadGpl reads a GIMP palette (.gpl) file
+// Parameters:
+//   r: reader with the GPL content
+// Returns:
+//   *Palette: palette with name, columns, comments and entries
+//   error: read error, missing header or malformed color line
+// Example:
+//   f, _ := os.Open("pico-8.gpl")
+//   p, err := ReadGpl(f)
+func ReadGpl(r io.Reader) (*Palette, error) {
+	sc := bufio.NewScanner(r)
+	if !sc.Scan() || strings.TrimSpace(sc.Text()) != "GIMP Palette" {
+		return nil, fmt.Errorf("missing GIMP Palette header")
+	}
+	p := &Palette{}
+	line := 1
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		switch {
+		case text == "":
+			continue
+		case strings.HasPrefix(text, "#"):
+			p.Comments = append(p.Comments, strings.TrimSpace(text[1:]))
+			continue
+		case strings.HasPrefix(text, "Name:"):
+			p.Name = strings.TrimSpace(text[5:])
+			continue
+		case strings.HasPrefix(text, "Columns:"):
+			cols, err := strconv.Atoi(strings.TrimSpace(text[8:]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid gpl columns on line %d: %s", line, text)
+			}
+			p.Columns = cols
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("invalid gpl color on line %d: %s", line, text)
+		}
+		var ch [3]uint8
+		for i := 0; i < 3; i++ {
+			v, err := strconv.ParseUint(fields[i], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid gpl color on line %d: %s", line, text)
+			}
+			ch[i] = uint8(v)
+		}
+		p.Entries = append(p.Entries, Entry{
+			Name:  strings.Join(fields[3:], " "),
+			Color: opaque(ch[0], ch[1], ch[2]),
+		})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WriteGpl writes a GIMP palette (.gpl) file; alpha is dropped
+// Parameters:
+//   w: destination for the GPL content
+//   p: palette to write
+// Returns:
+//   error: write error
+func WriteGpl(w io.Writer, p *Palette) error {
+	var b strings.Builder
+	b.WriteString("GIMP Palette\n")
+	if p.Name != "" {
+		fmt.Fprintf(&b, "Name: %s\n", p.Name)
+	}
+	if p.Columns > 0 {
+		fmt.Fprintf(&b, "Columns: %d\n", p.Columns)
+	}
+	for _, c := range p.Comments {
+		fmt.Fprintf(&b, "# %s\n", c)
+	}
+	for _, e := range p.Entries {
+		fmt.Fprintf(&b, "%3d %3d %3d", e.Color.R, e.Color.G, e.Color.B)
+		if e.Name != "" {
+			fmt.Fprintf(&b, "\t%s", e.Name)
+		}
+		b.WriteByte('\n')
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,203 @@
+package palette
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/zjhsd2007/color"
+)
+
+// kplMimetype is the content of the mimetype entry of a Krita palette
+const kplMimetype = "krita/x-colorset"
+
+type kplColorSet struct {
+	XMLName xml.Name   `xml:"ColorSet"`
+	Version string     `xml:"version,attr"`
+	Name    string     `xml:"name,attr"`
+	Columns int        `xml:"columns,attr"`
+	Rows    int        `xml:"rows,attr,omitempty"`
+	Comment string     `xml:"comment,attr"`
+	Entries []kplEntry `xml:"ColorSetEntry"`
+	Groups  []struct {
+		Name    string     `xml:"name,attr"`
+		Entries []kplEntry `xml:"ColorSetEntry"`
+	} `xml:"Group"`
+}
+
+type kplEntry struct {
+	Name     string `xml:"name,attr"`
+	ID       string `xml:"id,attr"`
+	Spot     string `xml:"spot,attr"`
+	Bitdepth string `xml:"bitdepth,attr"`
+	RGB      *struct {
+		R     float64 `xml:"r,attr"`
+		G     float64 `xml:"g,attr"`
+		B     float64 `xml:"b,attr"`
+		Space string  `xml:"space,attr"`
+	} `xml:"RGB"`
+	Gray *struct {
+		G float64 `xml:"g,attr"`
+	} `xml:"Gray"`
+	CMYK *struct {
+		C float64 `xml:"c,attr"`
+		M float64 `xml:"m,attr"`
+		Y float64 `xml:"y,attr"`
+		K float64 `xml:"k,attr"`
+	} `xml:"CMYK"`
+	Position *struct {
+		Row    int `xml:"row,attr"`
+		Column int `xml:"column,attr"`
+	} `xml:"Position"`
+}
+
+// rgb converts the first supported color child of the entry
+func (e *kplEntry) rgb() (color.RGB, error) {
+	unit := func(v float64) uint8 { return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255)) }
+	percent := func(v float64) uint8 { return uint8(math.Round(math.Max(0, math.Min(1, v)) * 100)) }
+	switch {
+	case e.RGB != nil:
+		return color.RGB{R: unit(e.RGB.R), G: unit(e.RGB.G), B: unit(e.RGB.B)}, nil
+	case e.Gray != nil:
+		g := unit(e.Gray.G)
+		return color.RGB{R: g, G: g, B: g}, nil
+	case e.CMYK != nil:
+		c := color.CMYK{C: percent(e.CMYK.C), M: percent(e.CMYK.M), Y: percent(e.CMYK.Y), K: percent(e.CMYK.K)}
+		return c.ToRgb(), nil
+	}
+	return color.RGB{}, fmt.Errorf("unsupported kpl color in entry %q", e.Name)
+}
+
+// readZip loads a whole zip archive from a reader
+func readZip(r io.Reader) (*zip.Reader, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return zip.NewReader(bytes.NewReader(data), int64(len(data)))
+}
+
+// readZipFile returns the content of a named archive entry
+func readZipFile(zr *zip.Reader, name string) ([]byte, error) {
+	for _, f := range zr.File {
+		if strings.EqualFold(f.Name, name) {
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			return io.ReadAll(rc)
+		}
+	}
+	return nil, fmt.Errorf("missing %s in archive", name)
+}
+
+// ReadKpl reads a Krita palette (.kpl), a zip archive with colorset.xml
+// Entries of all groups are flattened into the palette in document order.
+// Parameters:
+//   r: reader with the zip archive
+// Returns:
+//   *Palette: palette with name, columns, comment and entries
+//   error: archive, XML or unsupported color error
+// Example:
+//   f, _ := os.Open("Default.kpl")
+//   p, err := ReadKpl(f)
+func ReadKpl(r io.Reader) (*Palette, error) {
+	zr, err := readZip(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kpl archive: %w", err)
+	}
+	data, err := readZipFile(zr, "colorset.xml")
+	if err != nil {
+		return nil, err
+	}
+	var set kplColorSet
+	if err := xml.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid kpl colorset: %w", err)
+	}
+	p := &Palette{Name: set.Name, Columns: set.Columns}
+	if set.Comment != "" {
+		p.Comments = []string{set.Comment}
+	}
+	entries := set.Entries
+	for _, g := range set.Groups {
+		entries = append(entries, g.Entries...)
+	}
+	for _, e := range entries {
+		c, err := e.rgb()
+		if err != nil {
+			return nil, err
+		}
+		p.Add(e.Name, c)
+	}
+	return p, nil
+}
+
+// WriteKpl writes a Krita palette (.kpl) with 8-bit sRGB entries; alpha is dropped
+// Parameters:
+//   w: destination for the zip archive
+//   p: palette to write
+// Returns:
+//   error: archive or write error
+func WriteKpl(w io.Writer, p *Palette) error {
+	columns := p.Columns
+	if columns <= 0 {
+		columns = 16
+	}
+	set := kplColorSet{
+		Version: "1.0",
+		Name:    p.Name,
+		Columns: columns,
+		Rows:    (len(p.Entries) + columns - 1) / columns,
+		Comment: strings.Join(p.Comments, "\n"),
+	}
+	for i, e := range p.Entries {
+		entry := kplEntry{Name: e.Name, ID: fmt.Sprintf("%d", i), Spot: "false", Bitdepth: "U8"}
+		entry.RGB = &struct {
+			R     float64 `xml:"r,attr"`
+			G     float64 `xml:"g,attr"`
+			B     float64 `xml:"b,attr"`
+			Space string  `xml:"space,attr"`
+		}{float64(e.Color.R) / 255, float64(e.Color.G) / 255, float64(e.Color.B) / 255, "sRGB-elle-V2-srgbtrc.icc"}
+		entry.Position = &struct {
+			Row    int `xml:"row,attr"`
+			Column int `xml:"column,attr"`
+		}{i / columns, i % columns}
+		set.Entries = append(set.Entries, entry)
+	}
+	doc, err := xml.MarshalIndent(set, "", " ")
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	// the mimetype entry must come first and be stored uncompressed
+	mt, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	io.WriteString(mt, kplMimetype)
+	files := []struct {
+		name, content string
+	}{
+		{"colorset.xml", xml.Header + string(doc) + "\n"},
+		{"profiles.xml", xml.Header + "<Profiles/>\n"},
+	}
+	for _, f := range files {
+		fw, err := zw.Create(f.name)
+		if err != nil {
+			return err
+		}
+		io.WriteString(fw, f.content)
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
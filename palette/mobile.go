@@ -0,0 +1,139 @@
+package palette
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/zjhsd2007/color"
+)
+
+type androidResources struct {
+	XMLName xml.Name `xml:"resources"`
+	Colors  []struct {
+		Name  string `xml:"name,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"color"`
+}
+
+// ReadAndroidXml reads the color resources of an Android values XML file
+// "@color/name" references to colors of the same file are resolved.
+// Parameters:
+//   r: reader with the resources XML
+// Returns:
+//   *Palette: named entries in document order
+//   error: XML error, invalid value or unresolved reference
+// Example:
+//   f, _ := os.Open("res/values/colors.xml")
+//   p, err := ReadAndroidXml(f)
+func ReadAndroidXml(r io.Reader) (*Palette, error) {
+	var res androidResources
+	if err := xml.NewDecoder(r).Decode(&res); err != nil {
+		return nil, fmt.Errorf("invalid android resources: %w", err)
+	}
+	values := map[string]string{}
+	for _, c := range res.Colors {
+		values[c.Name] = strings.TrimSpace(c.Value)
+	}
+	p := &Palette{}
+	for _, c := range res.Colors {
+		value := values[c.Name]
+		for hops := 0; strings.HasPrefix(value, "@color/"); hops++ {
+			ref, ok := values[strings.TrimPrefix(value, "@color/")]
+			if !ok || hops > len(values) {
+				return nil, fmt.Errorf("unresolved android color %s: %s", c.Name, value)
+			}
+			value = ref
+		}
+		rgba, err := color.StrToAndroidHex(value)
+		if err != nil {
+			return nil, fmt.Errorf("android color %s: %w", c.Name, err)
+		}
+		p.Entries = append(p.Entries, Entry{Name: c.Name, Color: *rgba})
+	}
+	return p, nil
+}
+
+// WriteAndroidXml writes the palette as Android color resources in #AARRGGBB
+// Entry names are converted to snake_case resource names.
+// Parameters:
+//   w: destination for the resources XML
+//   p: palette to write
+// Returns:
+//   error: write error
+func WriteAndroidXml(w io.Writer, p *Palette) error {
+	var b strings.Builder
+	b.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<resources>\n")
+	for i, name := range variableNames(p) {
+		c := p.Entries[i].Color
+		fmt.Fprintf(&b, "    <color name=\"%s\">%s</color>\n", strings.ReplaceAll(name, "-", "_"), c.ToAndroidHex())
+	}
+	b.WriteString("</resources>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// camelIdent converts a dashed identifier to lowerCamelCase, prefixing
+// names that start with a digit
+func camelIdent(name string) string {
+	parts := strings.Split(name, "-")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	s := strings.Join(parts, "")
+	if s != "" && s[0] >= '0' && s[0] <= '9' {
+		s = "color" + s
+	}
+	return s
+}
+
+// WriteFlutter writes the palette as a Dart class of Color constants
+// Parameters:
+//   w: destination for the Dart source
+//   p: palette to write
+//   class: class name, e.g. "AppColors"
+// Returns:
+//   error: write error
+// Example:
+//   WriteFlutter(os.Stdout, p, "AppColors")
+//   // class AppColors {
+//   //   static const brand500 = Color(0xFFFF0000);
+//   // }
+func WriteFlutter(w io.Writer, p *Palette, class string) error {
+	var b strings.Builder
+	b.WriteString("import 'package:flutter/painting.dart';\n\n")
+	fmt.Fprintf(&b, "class %s {\n  %s._();\n\n", class, class)
+	for i, name := range variableNames(p) {
+		c := p.Entries[i].Color
+		fmt.Fprintf(&b, "  static const %s = %s;\n", camelIdent(name), c.ToFlutter())
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteSwiftUI writes the palette as a SwiftUI Color extension
+// Parameters:
+//   w: destination for the Swift source
+//   p: palette to write
+// Returns:
+//   error: write error
+// Example:
+//   WriteSwiftUI(os.Stdout, p)
+//   // extension Color {
+//   //     static let brand500 = Color(red: 1.000, green: 0.000, blue: 0.000, opacity: 1.000)
+//   // }
+func WriteSwiftUI(w io.Writer, p *Palette) error {
+	var b strings.Builder
+	b.WriteString("import SwiftUI\n\nextension Color {\n")
+	for i, name := range variableNames(p) {
+		c := p.Entries[i].Color
+		fmt.Fprintf(&b, "    static let %s = %s\n", camelIdent(name), c.ToSwiftUI())
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
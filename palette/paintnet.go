@@ -0,0 +1,67 @@
+package palette
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/zjhsd2007/color"
+)
+
+// ReadPaintNet reads a Paint.NET palette (.txt) of AARRGGBB hex lines
+// Lines starting with ";" are kept as comments.
+// Parameters:
+//   r: reader with the palette text
+// Returns:
+//   *Palette: palette entries without names
+//   error: read error or malformed color line
+// Example:
+//   f, _ := os.Open("palette.txt")
+//   p, err := ReadPaintNet(f)
+func ReadPaintNet(r io.Reader) (*Palette, error) {
+	p := &Palette{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" {
+			continue
+		}
+		if strings.HasPrefix(text, ";") {
+			p.Comments = append(p.Comments, strings.TrimSpace(text[1:]))
+			continue
+		}
+		v, err := strconv.ParseUint(text, 16, 32)
+		if err != nil || len(text) != 8 {
+			return nil, fmt.Errorf("invalid paint.net color: %s", text)
+		}
+		p.Entries = append(p.Entries, Entry{Color: color.RGBA{
+			RGB: color.RGB{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v)},
+			A:   float32(v>>24) / 255,
+		}})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// WritePaintNet writes a Paint.NET palette (.txt); names are dropped
+// Paint.NET shows at most 96 colors, longer palettes are still written.
+// Parameters:
+//   w: destination for the palette text
+//   p: palette to write
+// Returns:
+//   error: write error
+func WritePaintNet(w io.Writer, p *Palette) error {
+	var b strings.Builder
+	for _, c := range p.Comments {
+		fmt.Fprintf(&b, "; %s\n", c)
+	}
+	for _, e := range p.Entries {
+		fmt.Fprintf(&b, "%02X%02X%02X%02X\n", alphaToUint8(e.Color.A), e.Color.R, e.Color.G, e.Color.B)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,166 @@
+package palette
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadJasc reads a JASC-PAL text palette (.pal) as written by Paint Shop
+// Pro and Aseprite
+// Parameters:
+//   r: reader with the JASC-PAL content
+// Returns:
+//   *Palette: palette entries without names
+//   error: read error, bad header or malformed color line
+// Example:
+//   f, _ := os.Open("db32.pal")
+//   p, err := ReadJasc(f)
+func ReadJasc(r io.Reader) (*Palette, error) {
+	sc := bufio.NewScanner(r)
+	var header [3]string
+	for i := range header {
+		if !sc.Scan() {
+			return nil, fmt.Errorf("truncated JASC-PAL header")
+		}
+		header[i] = strings.TrimSpace(sc.Text())
+	}
+	if header[0] != "JASC-PAL" || header[1] != "0100" {
+		return nil, fmt.Errorf("invalid JASC-PAL header: %s %s", header[0], header[1])
+	}
+	count, err := strconv.Atoi(header[2])
+	if err != nil || count < 0 {
+		return nil, fmt.Errorf("invalid JASC-PAL color count: %s", header[2])
+	}
+	// the count is not trusted for preallocation, entries grow as lines are read
+	p := &Palette{}
+	for len(p.Entries) < count && sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("invalid JASC-PAL color: %s", sc.Text())
+		}
+		var ch [3]uint8
+		for i := 0; i < 3; i++ {
+			v, err := strconv.ParseUint(fields[i], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid JASC-PAL color: %s", sc.Text())
+			}
+			ch[i] = uint8(v)
+		}
+		p.Entries = append(p.Entries, Entry{Color: opaque(ch[0], ch[1], ch[2])})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if len(p.Entries) != count {
+		return nil, fmt.Errorf("JASC-PAL declares %d colors, found %d", count, len(p.Entries))
+	}
+	return p, nil
+}
+
+// WriteJasc writes a JASC-PAL text palette; names and alpha are dropped
+// Parameters:
+//   w: destination for the JASC-PAL content
+//   p: palette to write
+// Returns:
+//   error: write error
+func WriteJasc(w io.Writer, p *Palette) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "JASC-PAL\r\n0100\r\n%d\r\n", len(p.Entries))
+	for _, e := range p.Entries {
+		fmt.Fprintf(&b, "%d %d %d\r\n", e.Color.R, e.Color.G, e.Color.B)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ReadRiff reads a Microsoft RIFF palette (.pal) with a "PAL " form and
+// a "data" chunk holding a LOGPALETTE
+// Parameters:
+//   r: reader with the binary RIFF content
+// Returns:
+//   *Palette: palette entries without names
+//   error: read error or malformed file
+// Example:
+//   f, _ := os.Open("windows.pal")
+//   p, err := ReadRiff(f)
+func ReadRiff(r io.Reader) (*Palette, error) {
+	var header struct {
+		Riff [4]byte
+		Size uint32
+		Form [4]byte
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("invalid riff header: %w", err)
+	}
+	if string(header.Riff[:]) != "RIFF" || string(header.Form[:]) != "PAL " {
+		return nil, fmt.Errorf("not a RIFF palette: %q %q", header.Riff[:], header.Form[:])
+	}
+	for {
+		var chunk struct {
+			ID   [4]byte
+			Size uint32
+		}
+		if err := binary.Read(r, binary.LittleEndian, &chunk); err != nil {
+			return nil, fmt.Errorf("missing riff data chunk: %w", err)
+		}
+		// chunks are padded to an even size; the buffer grows as data
+		// arrives, so a corrupt size cannot force a large allocation
+		size := int64(chunk.Size) + int64(chunk.Size%2)
+		if string(chunk.ID[:]) != "data" {
+			if _, err := io.CopyN(io.Discard, r, size); err != nil {
+				return nil, fmt.Errorf("invalid riff chunk: %w", err)
+			}
+			continue
+		}
+		var buf bytes.Buffer
+		if _, err := io.CopyN(&buf, r, size); err != nil {
+			return nil, fmt.Errorf("invalid riff chunk: %w", err)
+		}
+		data := buf.Bytes()
+		if len(data) < 4 {
+			return nil, fmt.Errorf("invalid riff data chunk size: %d", chunk.Size)
+		}
+		count := int(binary.LittleEndian.Uint16(data[2:]))
+		if len(data) < 4+count*4 {
+			return nil, fmt.Errorf("riff palette declares %d colors in %d bytes", count, chunk.Size)
+		}
+		p := &Palette{Entries: make([]Entry, count)}
+		for i := range p.Entries {
+			e := data[4+i*4:]
+			p.Entries[i].Color = opaque(e[0], e[1], e[2])
+		}
+		return p, nil
+	}
+}
+
+// WriteRiff writes a Microsoft RIFF palette; names and alpha are dropped
+// Parameters:
+//   w: destination for the binary RIFF content
+//   p: palette with at most 65535 colors
+// Returns:
+//   error: write error or too many colors
+func WriteRiff(w io.Writer, p *Palette) error {
+	if len(p.Entries) > 0xffff {
+		return fmt.Errorf("riff palette has %d colors, at most 65535 allowed", len(p.Entries))
+	}
+	dataSize := uint32(4 + 4*len(p.Entries))
+	var b bytes.Buffer
+	b.WriteString("RIFF")
+	binary.Write(&b, binary.LittleEndian, 4+8+dataSize)
+	b.WriteString("PAL data")
+	binary.Write(&b, binary.LittleEndian, dataSize)
+	binary.Write(&b, binary.LittleEndian, [2]uint16{0x0300, uint16(len(p.Entries))})
+	for _, e := range p.Entries {
+		b.Write([]byte{e.Color.R, e.Color.G, e.Color.B, 0})
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,56 @@
+// Package palette reads and writes palette files of paint and pixel-art
+// applications as lists of named colors.
+package palette
+
+import (
+	"math"
+
+	"github.com/zjhsd2007/color"
+)
+
+// Entry is a named palette color
+type Entry struct {
+	Name  string
+	Color color.RGBA
+}
+
+// Palette is an ordered list of named colors
+// Columns and Comments are only kept by formats that support them.
+type Palette struct {
+	Name     string
+	Columns  int
+	Comments []string
+	Entries  []Entry
+}
+
+// Add appends an opaque color to the palette
+// Parameters:
+//   name: color name, may be empty
+//   c: color to add
+// Example:
+//   var p Palette
+//   p.Add("Red", color.RGB{255,0,0})
+func (p *Palette) Add(name string, c color.RGB) {
+	p.Entries = append(p.Entries, Entry{Name: name, Color: color.RGBA{RGB: c, A: 1.0}})
+}
+
+// Colors returns the palette colors without their names
+// Returns:
+//   []color.RGBA: colors in palette order
+func (p *Palette) Colors() []color.RGBA {
+	colors := make([]color.RGBA, len(p.Entries))
+	for i, e := range p.Entries {
+		colors[i] = e.Color
+	}
+	return colors
+}
+
+// alphaToUint8 converts a 0-1 alpha to 0-255
+func alphaToUint8(a float32) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, float64(a))) * 255))
+}
+
+// opaque wraps an RGB color with full opacity
+func opaque(r, g, b uint8) color.RGBA {
+	return color.RGBA{RGB: color.RGB{R: r, G: g, B: b}, A: 1.0}
+}
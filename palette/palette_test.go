@@ -0,0 +1,308 @@
+package palette
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/zjhsd2007/color"
+)
+
+func samplePalette() *Palette {
+	p := &Palette{Name: "Sample", Columns: 4, Comments: []string{"made for tests"}}
+	p.Add("Black", color.RGB{R: 0, G: 0, B: 0})
+	p.Add("Brick Red", color.RGB{R: 190, G: 38, B: 51})
+	p.Add("Sky", color.RGB{R: 49, G: 162, B: 242})
+	return p
+}
+
+func TestFormats(t *testing.T) {
+	formats := []struct {
+		name  string
+		write func(io.Writer, *Palette) error
+		read  func(io.Reader) (*Palette, error)
+	}{
+		{"gpl", WriteGpl, ReadGpl},
+		{"jasc", WriteJasc, ReadJasc},
+		{"riff", WriteRiff, ReadRiff},
+		{"paint.net", WritePaintNet, ReadPaintNet},
+		{"kpl", WriteKpl, ReadKpl},
+		{"soc", WriteSoc, ReadSoc},
+	}
+	for _, f := range formats {
+		t.Run(f.name+" round trip", func(t *testing.T) {
+			p := samplePalette()
+			var b bytes.Buffer
+			if err := f.write(&b, p); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got, err := f.read(&b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got.Entries) != len(p.Entries) {
+				t.Fatalf("expected %v, got %v", p.Entries, got.Entries)
+			}
+			for i, e := range got.Entries {
+				if e.Color != p.Entries[i].Color {
+					t.Errorf("expected %v, got %v", p.Entries[i].Color, e.Color)
+				}
+			}
+		})
+	}
+}
+
+func TestGpl(t *testing.T) {
+	t.Run("read gpl", func(t *testing.T) {
+		src := "GIMP Palette\nName: Pico\nColumns: 8\n# from lospec\n  0   0   0\tBlack\n255 241 232 Off White\n"
+		p, err := ReadGpl(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if p.Name != "Pico" || p.Columns != 8 || len(p.Comments) != 1 || p.Comments[0] != "from lospec" {
+			t.Errorf("unexpected header %+v", p)
+		}
+		expected := Entry{Name: "Off White", Color: color.RGBA{RGB: color.RGB{R: 255, G: 241, B: 232}, A: 1.0}}
+		if len(p.Entries) != 2 || p.Entries[1] != expected {
+			t.Errorf("expected %v, got %v", expected, p.Entries)
+		}
+	})
+
+	t.Run("invalid gpl", func(t *testing.T) {
+		for _, src := range []string{"JASC-PAL\n", "GIMP Palette\n1 2\n", "GIMP Palette\n300 0 0\n"} {
+			if _, err := ReadGpl(strings.NewReader(src)); err == nil {
+				t.Errorf("%q: expected error", src)
+			}
+		}
+	})
+}
+
+func TestJascAndRiff(t *testing.T) {
+	t.Run("jasc count mismatch", func(t *testing.T) {
+		if _, err := ReadJasc(strings.NewReader("JASC-PAL\n0100\n3\n0 0 0\n")); err == nil {
+			t.Error("expected error for missing colors")
+		}
+	})
+
+	t.Run("riff layout", func(t *testing.T) {
+		var b bytes.Buffer
+		p := &Palette{}
+		p.Add("", color.RGB{R: 1, G: 2, B: 3})
+		if err := WriteRiff(&b, p); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []byte("RIFF\x14\x00\x00\x00PAL data\x08\x00\x00\x00\x00\x03\x01\x00\x01\x02\x03\x00")
+		if !bytes.Equal(b.Bytes(), expected) {
+			t.Errorf("expected % x, got % x", expected, b.Bytes())
+		}
+	})
+	t.Run("truncated input", func(t *testing.T) {
+		// sizes far beyond the input must fail without allocating them
+		riff := "RIFF\x14\x00\x00\x00PAL data\xf0\xff\xff\xff\x00\x03\x01\x00"
+		if _, err := ReadRiff(strings.NewReader(riff)); err == nil {
+			t.Error("expected error for truncated data chunk")
+		}
+		if _, err := ReadJasc(strings.NewReader("JASC-PAL\n0100\n2000000000\n0 0 0\n")); err == nil {
+			t.Error("expected error for missing colors")
+		}
+	})
+}
+
+func TestPaintNet(t *testing.T) {
+	p, err := ReadPaintNet(strings.NewReader("; paint.net Palette File\n80FF0000\nFF00FF00\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(p.Entries) != 2 || p.Entries[0].Color.R != 255 || p.Entries[0].Color.A != float32(128)/255 {
+		t.Errorf("unexpected entries %v", p.Entries)
+	}
+	var b strings.Builder
+	if err := WritePaintNet(&b, p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "; paint.net Palette File\n80FF0000\nFF00FF00\n"
+	if b.String() != expected {
+		t.Errorf("expected %q, got %q", expected, b.String())
+	}
+	if _, err := ReadPaintNet(strings.NewReader("FF0000\n")); err == nil {
+		t.Error("expected error for 6 digit color")
+	}
+}
+
+func TestKplAndSoc(t *testing.T) {
+	t.Run("kpl keeps header", func(t *testing.T) {
+		var b bytes.Buffer
+		if err := WriteKpl(&b, samplePalette()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		p, err := ReadKpl(&b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if p.Name != "Sample" || p.Columns != 4 || p.Entries[1].Name != "Brick Red" {
+			t.Errorf("unexpected palette %+v", p)
+		}
+	})
+
+	t.Run("read soc", func(t *testing.T) {
+		src := `<?xml version="1.0" encoding="UTF-8"?>
+<ooo:color-table xmlns:draw="urn:oasis:names:tc:opendocument:xmlns:drawing:1.0" xmlns:ooo="http://openoffice.org/2004/office">
+<draw:color draw:name="Dark Red &amp; Co" draw:color="#c9211e"/>
+</ooo:color-table>`
+		p, err := ReadSoc(strings.NewReader(src))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := Entry{Name: "Dark Red & Co", Color: opaque(201, 33, 30)}
+		if len(p.Entries) != 1 || p.Entries[0] != expected {
+			t.Errorf("expected %v, got %v", expected, p.Entries)
+		}
+		if _, err := ReadSoc(strings.NewReader(`<draw:color draw:name="x" draw:color="red"/>`)); err == nil {
+			t.Error("expected error for named color value")
+		}
+	})
+}
+
+func TestProcreate(t *testing.T) {
+	p := &Palette{Name: "Primaries"}
+	p.Add("", color.RGB{R: 255, G: 0, B: 0})
+	p.Add("", color.RGB{R: 0, G: 255, B: 255})
+	p.Entries = append(p.Entries, Entry{Color: color.RGBA{RGB: color.RGB{R: 255, G: 255, B: 255}, A: 0.5}})
+	// grays and mixed colors fall between whole degrees and percents
+	p.Add("", color.RGB{R: 128, G: 128, B: 128})
+	p.Add("", color.RGB{R: 12, G: 200, B: 77})
+	p.Add("", color.RGB{R: 49, G: 162, B: 242})
+	p.Add("", color.RGB{R: 1, G: 2, B: 3})
+	p.Add("", color.RGB{R: 250, G: 3, B: 129})
+	var b bytes.Buffer
+	if err := WriteProcreate(&b, p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := ReadProcreate(&b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Name != "Primaries" || len(got.Entries) != len(p.Entries) {
+		t.Fatalf("unexpected palette %+v", got)
+	}
+	for i, e := range got.Entries {
+		if e.Color != p.Entries[i].Color {
+			t.Errorf("expected %v, got %v", p.Entries[i].Color, e.Color)
+		}
+	}
+
+	big := &Palette{}
+	for i := 0; i < 31; i++ {
+		big.Add("", color.RGB{})
+	}
+	if err := WriteProcreate(&bytes.Buffer{}, big); err == nil {
+		t.Error("expected error for more than 30 colors")
+	}
+}
+
+func TestStylesheets(t *testing.T) {
+	p := &Palette{Name: "Brand"}
+	p.Add("Brand 500", color.RGB{R: 255, G: 0, B: 0})
+	p.Add("Brand", color.RGB{R: 0, G: 0, B: 255})
+	p.Entries = append(p.Entries, Entry{Name: "Overlay", Color: color.RGBA{RGB: color.RGB{R: 0, G: 0, B: 0}, A: 0.5}})
+
+	t.Run("notations", func(t *testing.T) {
+		red := color.RGBA{RGB: color.RGB{R: 255, G: 0, B: 0}, A: 1.0}
+		half := color.RGBA{RGB: color.RGB{R: 255, G: 0, B: 0}, A: 0.5}
+		cases := []struct {
+			n        Notation
+			c        color.RGBA
+			expected string
+		}{
+			{NotationHex, red, "#ff0000"},
+			{NotationHex, half, "#ff000080"},
+			{NotationRgb, half, "rgba(255,0,0,0.50)"},
+			{NotationHsl, red, "hsl(0, 100%, 50%)"},
+			{NotationOklch, red, "oklch(62.80% 0.2577 29.23)"},
+			{NotationOklch, half, "oklch(62.80% 0.2577 29.23 / 0.5)"},
+		}
+		for _, c := range cases {
+			if got := c.n.Format(c.c); got != c.expected {
+				t.Errorf("%v: expected %q, got %q", c.n, c.expected, got)
+			}
+		}
+	})
+
+	t.Run("css scss less", func(t *testing.T) {
+		var b strings.Builder
+		if err := WriteCss(&b, p, "", NotationHex); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := ":root {\n  --brand-500: #ff0000;\n  --brand: #0000ff;\n  --overlay: #00000080;\n}\n"
+		if b.String() != expected {
+			t.Errorf("expected %q, got %q", expected, b.String())
+		}
+		b.Reset()
+		WriteScss(&b, p, NotationRgb)
+		if !strings.HasPrefix(b.String(), "$brand-500: rgb(255,0,0);\n") || !strings.Contains(b.String(), "$brand: (\n  \"brand-500\": $brand-500,\n") {
+			t.Errorf("unexpected scss %q", b.String())
+		}
+		b.Reset()
+		WriteLess(&b, p, NotationHsl)
+		if !strings.Contains(b.String(), "@overlay: hsla(0, 0%, 0%, 0.50);\n") {
+			t.Errorf("unexpected less %q", b.String())
+		}
+	})
+
+	t.Run("tailwind", func(t *testing.T) {
+		var b strings.Builder
+		if err := WriteTailwindJSON(&b, p, NotationHex); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := "{\n  \"brand\": {\n    \"500\": \"#ff0000\",\n    \"DEFAULT\": \"#0000ff\"\n  },\n  \"overlay\": \"#00000080\"\n}\n"
+		if b.String() != expected {
+			t.Errorf("expected %q, got %q", expected, b.String())
+		}
+		b.Reset()
+		if err := WriteTailwindConfig(&b, p, NotationHex); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(b.String(), "module.exports = {\n  theme: {\n    extend: {\n      colors: {\n        \"brand\": {") {
+			t.Errorf("unexpected config %q", b.String())
+		}
+	})
+}
+
+func TestMobileFormats(t *testing.T) {
+	src := `<?xml version="1.0" encoding="utf-8"?>
+<resources>
+    <color name="brand_500">#80FF8000</color>
+    <color name="accent">@color/brand_500</color>
+</resources>`
+	p, err := ReadAndroidXml(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := color.RGBA{RGB: color.RGB{R: 255, G: 128, B: 0}, A: float32(128) / 255}
+	if len(p.Entries) != 2 || p.Entries[1].Name != "accent" || p.Entries[1].Color != expected {
+		t.Errorf("unexpected entries %v", p.Entries)
+	}
+
+	var b strings.Builder
+	if err := WriteAndroidXml(&b, p); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(b.String(), `<color name="brand_500">#80FF8000</color>`) {
+		t.Errorf("unexpected xml %q", b.String())
+	}
+	b.Reset()
+	WriteFlutter(&b, p, "AppColors")
+	if !strings.Contains(b.String(), "  static const brand500 = Color(0x80FF8000);\n") {
+		t.Errorf("unexpected dart %q", b.String())
+	}
+	b.Reset()
+	WriteSwiftUI(&b, p)
+	if !strings.Contains(b.String(), "    static let accent = Color(red: 1.000, green: 0.502, blue: 0.000, opacity: 0.502)\n") {
+		t.Errorf("unexpected swift %q", b.String())
+	}
+
+	if _, err := ReadAndroidXml(strings.NewReader(`<resources><color name="a">@color/a</color></resources>`)); err == nil {
+		t.Error("expected error for circular reference")
+	}
+}
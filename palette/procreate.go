@@ -0,0 +1,108 @@
+package palette
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/zjhsd2007/color"
+)
+
+// procreateMaxSwatches is the number of slots of a Procreate palette
+const procreateMaxSwatches = 30
+
+type procreateSwatch struct {
+	Hue        float64 `json:"hue"`
+	Saturation float64 `json:"saturation"`
+	Brightness float64 `json:"brightness"`
+	Alpha      float64 `json:"alpha"`
+	ColorSpace int     `json:"colorSpace"`
+}
+
+type procreatePalette struct {
+	Name     string             `json:"name"`
+	Swatches []*procreateSwatch `json:"swatches"`
+}
+
+// ReadProcreate reads a Procreate palette (.swatches), a zip archive with
+// Swatches.json holding HSB colors; empty slots are skipped
+// Parameters:
+//   r: reader with the zip archive
+// Returns:
+//   *Palette: palette name and unnamed entries
+//   error: archive or JSON error
+// Example:
+//   f, _ := os.Open("Skin.swatches")
+//   p, err := ReadProcreate(f)
+func ReadProcreate(r io.Reader) (*Palette, error) {
+	zr, err := readZip(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid procreate archive: %w", err)
+	}
+	data, err := readZipFile(zr, "Swatches.json")
+	if err != nil {
+		return nil, err
+	}
+	var palettes []procreatePalette
+	if err := json.Unmarshal(data, &palettes); err != nil {
+		var single procreatePalette
+		if err := json.Unmarshal(data, &single); err != nil {
+			return nil, fmt.Errorf("invalid procreate swatches: %w", err)
+		}
+		palettes = []procreatePalette{single}
+	}
+	if len(palettes) == 0 {
+		return nil, fmt.Errorf("procreate archive holds no palette")
+	}
+	p := &Palette{Name: palettes[0].Name}
+	for _, sw := range palettes[0].Swatches {
+		if sw == nil {
+			continue
+		}
+		// HSB is kept in floats, the whole degrees and percents of
+		// color.HSV would shift colors on every round trip
+		rgb := color.HsvFloatToRgb(sw.Hue*360, sw.Saturation, sw.Brightness)
+		p.Entries = append(p.Entries, Entry{Color: color.RGBA{RGB: rgb, A: float32(sw.Alpha)}})
+	}
+	return p, nil
+}
+
+// WriteProcreate writes a Procreate palette (.swatches); names are dropped
+// Parameters:
+//   w: destination for the zip archive
+//   p: palette with at most 30 colors
+// Returns:
+//   error: archive or write error, or too many colors
+func WriteProcreate(w io.Writer, p *Palette) error {
+	if len(p.Entries) > procreateMaxSwatches {
+		return fmt.Errorf("procreate palette has %d colors, at most %d allowed", len(p.Entries), procreateMaxSwatches)
+	}
+	pal := procreatePalette{Name: p.Name, Swatches: make([]*procreateSwatch, len(p.Entries))}
+	for i, e := range p.Entries {
+		h, sat, v := color.RgbToHsvFloat(e.Color.RGB)
+		pal.Swatches[i] = &procreateSwatch{
+			Hue:        h / 360,
+			Saturation: sat,
+			Brightness: v,
+			Alpha:      float64(e.Color.A),
+		}
+	}
+	data, err := json.Marshal([]procreatePalette{pal})
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	fw, err := zw.Create("Swatches.json")
+	if err != nil {
+		return err
+	}
+	fw.Write(data)
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
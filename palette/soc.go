@@ -0,0 +1,75 @@
+package palette
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/zjhsd2007/color"
+)
+
+// ReadSoc reads a LibreOffice color table (.soc) of draw:color elements
+// Parameters:
+//   r: reader with the SOC XML
+// Returns:
+//   *Palette: named entries in document order
+//   error: XML error or invalid color value
+// Example:
+//   f, _ := os.Open("standard.soc")
+//   p, err := ReadSoc(f)
+func ReadSoc(r io.Reader) (*Palette, error) {
+	p := &Palette{}
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return p, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid soc file: %w", err)
+		}
+		el, ok := tok.(xml.StartElement)
+		if !ok || el.Name.Local != "color" {
+			continue
+		}
+		var name, value string
+		for _, attr := range el.Attr {
+			switch attr.Name.Local {
+			case "name":
+				name = attr.Value
+			case "color":
+				value = attr.Value
+			}
+		}
+		hex, err := color.StrToHex(value)
+		if err != nil || len(value) != 7 {
+			return nil, fmt.Errorf("invalid soc color %q: %s", name, value)
+		}
+		p.Add(name, hex.ToRgb())
+	}
+}
+
+// WriteSoc writes a LibreOffice color table (.soc); alpha is dropped
+// Parameters:
+//   w: destination for the SOC XML
+//   p: palette to write
+// Returns:
+//   error: write error
+func WriteSoc(w io.Writer, p *Palette) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<ooo:color-table xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"` +
+		` xmlns:draw="urn:oasis:names:tc:opendocument:xmlns:drawing:1.0"` +
+		` xmlns:xlink="http://www.w3.org/1999/xlink"` +
+		` xmlns:svg="http://www.w3.org/2000/svg"` +
+		` xmlns:ooo="http://openoffice.org/2004/office">` + "\n")
+	for _, e := range p.Entries {
+		var name strings.Builder
+		xml.EscapeText(&name, []byte(e.Name))
+		fmt.Fprintf(&b, "  <draw:color draw:name=\"%s\" draw:color=\"%s\"/>\n", name.String(), e.Color.RGB.ToHex())
+	}
+	b.WriteString("</ooo:color-table>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,282 @@
+package palette
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/zjhsd2007/color"
+)
+
+// Notation selects how stylesheet writers print color values
+type Notation int
+
+const (
+	NotationHex   Notation = iota // #rrggbb, #rrggbbaa with alpha
+	NotationRgb                   // rgb(r,g,b), rgba(r,g,b,a) with alpha
+	NotationHsl                   // hsl(h, s%, l%), hsla(h, s%, l%, a) with alpha
+	NotationOklch                 // oklch(l% c h), oklch(l% c h / a) with alpha
+)
+
+// String returns the notation name
+func (n Notation) String() string {
+	switch n {
+	case NotationHex:
+		return "hex"
+	case NotationRgb:
+		return "rgb"
+	case NotationHsl:
+		return "hsl"
+	case NotationOklch:
+		return "oklch"
+	}
+	return fmt.Sprintf("Notation(%d)", int(n))
+}
+
+// Format prints a color in the notation as a CSS value
+// Parameters:
+//   c: color to print; alpha below 1 selects the translucent form
+// Returns:
+//   string: CSS color value
+// Example:
+//   NotationHsl.Format(color.RGBA{color.RGB{255,0,0}, 1.0}) // returns "hsl(0, 100%, 50%)"
+//   NotationOklch.Format(color.RGBA{color.RGB{255,0,0}, 0.5}) // returns "oklch(62.80% 0.2577 29.23 / 0.5)"
+func (n Notation) Format(c color.RGBA) string {
+	opaque := c.A >= 1
+	switch n {
+	case NotationRgb:
+		if opaque {
+			return c.RGB.String()
+		}
+		return c.String()
+	case NotationHsl:
+		hsla := color.HSLA{HSL: c.RGB.ToHsl(), A: c.A}
+		if opaque {
+			return hsla.HSL.String()
+		}
+		return hsla.String()
+	case NotationOklch:
+		lch := c.RGB.ToOklch()
+		s := fmt.Sprintf("oklch(%.2f%% %.4f %.2f", lch.L*100, lch.C, lch.H)
+		if !opaque {
+			s += fmt.Sprintf(" / %g", math.Round(float64(c.A)*1000)/1000)
+		}
+		return s + ")"
+	}
+	if opaque {
+		return c.RGB.ToHex()
+	}
+	return fmt.Sprintf("%s%02x", c.RGB.ToHex(), alphaToUint8(c.A))
+}
+
+// variableNames returns a CSS identifier per entry, numbering unnamed
+// entries and de-duplicating repeated names
+func variableNames(p *Palette) []string {
+	names := make([]string, len(p.Entries))
+	used := map[string]int{}
+	for i, e := range p.Entries {
+		name := cssIdent(e.Name)
+		if name == "" {
+			name = fmt.Sprintf("color-%d", i+1)
+		}
+		if n := used[name]; n > 0 {
+			used[name]++
+			name = fmt.Sprintf("%s-%d", name, n+1)
+		} else {
+			used[name] = 1
+		}
+		names[i] = name
+	}
+	return names
+}
+
+// cssIdent lowercases a name and joins its letters and digits with dashes
+func cssIdent(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// writeBlockComments writes palette comments as /* */ lines
+func writeBlockComments(b *strings.Builder, p *Palette) {
+	for _, c := range p.Comments {
+		fmt.Fprintf(b, "/* %s */\n", strings.ReplaceAll(c, "*/", "* /"))
+	}
+}
+
+// WriteCss writes the palette as CSS custom properties
+// Parameters:
+//   w: destination for the stylesheet
+//   p: palette to write; entry names become property names
+//   selector: rule selector, ":root" when empty
+//   n: notation of the values
+// Returns:
+//   error: write error
+// Example:
+//   WriteCss(os.Stdout, p, "", NotationOklch)
+//   // :root {
+//   //   --brand-500: oklch(62.80% 0.2577 29.23);
+//   // }
+func WriteCss(w io.Writer, p *Palette, selector string, n Notation) error {
+	if selector == "" {
+		selector = ":root"
+	}
+	var b strings.Builder
+	writeBlockComments(&b, p)
+	fmt.Fprintf(&b, "%s {\n", selector)
+	for i, name := range variableNames(p) {
+		fmt.Fprintf(&b, "  --%s: %s;\n", name, n.Format(p.Entries[i].Color))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteScss writes the palette as SCSS variables followed by a map of all
+// colors named after the palette, "colors" when it has no name
+// Parameters:
+//   w: destination for the stylesheet
+//   p: palette to write
+//   n: notation of the values
+// Returns:
+//   error: write error
+// Example:
+//   WriteScss(os.Stdout, p, NotationHex)
+//   // $brand-500: #ff8000;
+//   //
+//   // $colors: (
+//   //   "brand-500": $brand-500,
+//   // );
+func WriteScss(w io.Writer, p *Palette, n Notation) error {
+	names := variableNames(p)
+	var b strings.Builder
+	writeBlockComments(&b, p)
+	for i, name := range names {
+		fmt.Fprintf(&b, "$%s: %s;\n", name, n.Format(p.Entries[i].Color))
+	}
+	mapName := cssIdent(p.Name)
+	if mapName == "" {
+		mapName = "colors"
+	}
+	fmt.Fprintf(&b, "\n$%s: (\n", mapName)
+	for _, name := range names {
+		fmt.Fprintf(&b, "  \"%s\": $%s,\n", name, name)
+	}
+	b.WriteString(");\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteLess writes the palette as Less variables
+// Parameters:
+//   w: destination for the stylesheet
+//   p: palette to write
+//   n: notation of the values
+// Returns:
+//   error: write error
+// Example:
+//   WriteLess(os.Stdout, p, NotationRgb) // writes "@brand-500: rgb(255,128,0);"
+func WriteLess(w io.Writer, p *Palette, n Notation) error {
+	var b strings.Builder
+	writeBlockComments(&b, p)
+	for i, name := range variableNames(p) {
+		fmt.Fprintf(&b, "@%s: %s;\n", name, n.Format(p.Entries[i].Color))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// tailwindColors builds the theme.colors object; names ending in a
+// numeric shade such as "brand-500" are nested as brand["500"]
+func tailwindColors(p *Palette, n Notation) (map[string]any, error) {
+	colors := map[string]any{}
+	for i, name := range variableNames(p) {
+		value := n.Format(p.Entries[i].Color)
+		key, shade := name, ""
+		if dash := strings.LastIndexByte(name, '-'); dash > 0 && strings.Trim(name[dash+1:], "0123456789") == "" {
+			key, shade = name[:dash], name[dash+1:]
+		}
+		if shade == "" {
+			if group, ok := colors[key].(map[string]any); ok {
+				group["DEFAULT"] = value
+			} else {
+				colors[key] = value
+			}
+			continue
+		}
+		group, ok := colors[key].(map[string]any)
+		if !ok {
+			group = map[string]any{}
+			if v, exists := colors[key]; exists {
+				group["DEFAULT"] = v
+			}
+			colors[key] = group
+		}
+		if _, exists := group[shade]; exists {
+			return nil, fmt.Errorf("duplicate tailwind color %s", name)
+		}
+		group[shade] = value
+	}
+	return colors, nil
+}
+
+// WriteTailwindJSON writes the palette as a Tailwind theme.colors JSON object
+// Names ending in a numeric shade are grouped, "brand-500" becomes
+// {"brand": {"500": ...}}; a plain "brand" entry next to shades becomes DEFAULT.
+// Parameters:
+//   w: destination for the JSON
+//   p: palette to write
+//   n: notation of the values
+// Returns:
+//   error: write error
+func WriteTailwindJSON(w io.Writer, p *Palette, n Notation) error {
+	colors, err := tailwindColors(p, n)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(colors, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// WriteTailwindConfig writes the palette as a tailwind.config.js module
+// extending theme.colors, grouped like WriteTailwindJSON
+// Parameters:
+//   w: destination for the JavaScript
+//   p: palette to write
+//   n: notation of the values
+// Returns:
+//   error: write error
+func WriteTailwindConfig(w io.Writer, p *Palette, n Notation) error {
+	colors, err := tailwindColors(p, n)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(colors, "      ", "  ")
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	writeBlockComments(&b, p)
+	b.WriteString("module.exports = {\n  theme: {\n    extend: {\n      colors: ")
+	b.Write(data)
+	b.WriteString(",\n    },\n  },\n};\n")
+	_, err = io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,157 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// QtNameFormat selects the QColor::name() output form
+type QtNameFormat int
+
+const (
+	QtHexRgb  QtNameFormat = iota // #rrggbb, QColor::HexRgb
+	QtHexArgb                     // #aarrggbb, QColor::HexArgb
+	QtHex12                       // #rrrgggbbb, 12 bits per channel
+)
+
+// ToQtName converts RGBA to a QColor name string
+// Parameters:
+//   format: output form, alpha is only kept by QtHexArgb
+// Returns:
+//   string: lowercase name as QColor::name() prints it
+// Example:
+//   c := RGBA{RGB{255, 128, 0}, 0.5}
+//   c.ToQtName(QtHexArgb) // returns "#80ff8000"
+//   c.ToQtName(QtHex12) // returns "#fff808000"
+func (c *RGBA) ToQtName(format QtNameFormat) string {
+	switch format {
+	case QtHexArgb:
+		return fmt.Sprintf("#%02x%02x%02x%02x", unitToUint8(float64(c.A)), c.R, c.G, c.B)
+	case QtHex12:
+		// QColor keeps 16 bit channels and drops the low 4 bits for this form
+		ch := func(v uint8) uint32 { return uint32(v) * 0x101 >> 4 }
+		return fmt.Sprintf("#%03x%03x%03x", ch(c.R), ch(c.G), ch(c.B))
+	}
+	return c.RGB.ToHex()
+}
+
+// ParseQtColor parses a color the way QColor::fromString() does
+// Parameters:
+//   str: "#rgb", "#rrggbb", "#aarrggbb", "#rrrgggbbb", "#rrrrggggbbbb",
+//        "transparent" or an SVG/X11 color name
+// Returns:
+//   RGBA: parsed color
+//   error: parsing error if the name is unknown or malformed
+// Example:
+//   c, err := ParseQtColor("#80ff8000") // returns RGBA{RGB{255,128,0},0.5}
+//   c, err := ParseQtColor("#fff808000") // returns RGBA{RGB{255,128,0},1.0}
+func ParseQtColor(str string) (RGBA, error) {
+	s := strings.TrimSpace(str)
+	if !strings.HasPrefix(s, "#") {
+		if strings.EqualFold(s, "transparent") {
+			return RGBA{}, nil
+		}
+		rgb, ok := X11ColorNames.Lookup(s)
+		if !ok {
+			return RGBA{}, fmt.Errorf("unknown qt color name: %s", str)
+		}
+		return RGBA{rgb, 1.0}, nil
+	}
+	hex := s[1:]
+	if _, err := strconv.ParseUint(hex, 16, 64); err != nil {
+		return RGBA{}, fmt.Errorf("invalid qt color: %s", str)
+	}
+	switch len(hex) {
+	case 3, 6:
+		r, g, b, _, err := hexToRGBA(hex)
+		return RGBA{RGB{r, g, b}, 1.0}, err
+	case 8:
+		v, _ := strconv.ParseUint(hex, 16, 32)
+		return UnpackPixel(uint32(v), ARGB8888), nil
+	case 9, 12:
+		n := len(hex) / 3
+		max := float64(uint64(1)<<(4*n) - 1)
+		var ch [3]uint8
+		for i := range ch {
+			v, _ := strconv.ParseUint(hex[i*n:(i+1)*n], 16, 16)
+			ch[i] = uint8(math.Round(float64(v) / max * 255))
+		}
+		return RGBA{RGB{ch[0], ch[1], ch[2]}, 1.0}, nil
+	}
+	return RGBA{}, fmt.Errorf("invalid qt color: %s", str)
+}
+
+// ToHslF converts RGBA to QColor::getHslF() floating point components
+// Returns:
+//   h: hue in 0-1, -1 for achromatic colors as in Qt
+//   s, l, a: saturation, lightness and alpha in 0-1
+// Example:
+//   c := RGBA{RGB{255, 0, 0}, 1.0}
+//   h, s, l, a := c.ToHslF() // returns 0, 1, 0.5, 1
+func (c *RGBA) ToHslF() (h, s, l, a float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	hi := math.Max(r, math.Max(g, b))
+	lo := math.Min(r, math.Min(g, b))
+	delta := hi - lo
+	l = (hi + lo) / 2
+	a = float64(c.A)
+	if delta == 0 {
+		return -1, 0, l, a
+	}
+	if l < 0.5 {
+		s = delta / (hi + lo)
+	} else {
+		s = delta / (2 - hi - lo)
+	}
+	switch hi {
+	case r:
+		h = (g - b) / delta
+		if h < 0 {
+			h += 6
+		}
+	case g:
+		h = (b-r)/delta + 2
+	default:
+		h = (r-g)/delta + 4
+	}
+	return h / 6, s, l, a
+}
+
+// FromHslF converts QColor::fromHslF() floating point components to RGBA
+// Parameters:
+//   h: hue in 0-1, negative for achromatic colors
+//   s, l, a: saturation, lightness and alpha in 0-1
+// Returns:
+//   RGBA: corresponding RGBA color object
+// Example:
+//   c := FromHslF(1.0/3, 1, 0.5, 1) // returns RGBA{RGB{0,255,0},1.0}
+func FromHslF(h, s, l, a float64) RGBA {
+	clamp := func(v float64) float64 { return math.Max(0, math.Min(1, v)) }
+	s, l, a = clamp(s), clamp(l), clamp(a)
+	if h < 0 || s == 0 {
+		g := unitToUint8(l)
+		return RGBA{RGB{g, g, g}, float32(a)}
+	}
+	h = math.Mod(h, 1) * 6
+	chroma := (1 - math.Abs(2*l-1)) * s
+	x := chroma * (1 - math.Abs(math.Mod(h, 2)-1))
+	var r, g, b float64
+	switch int(h) {
+	case 0:
+		r, g = chroma, x
+	case 1:
+		r, g = x, chroma
+	case 2:
+		g, b = chroma, x
+	case 3:
+		g, b = x, chroma
+	case 4:
+		r, b = x, chroma
+	default:
+		r, b = chroma, x
+	}
+	m := l - chroma/2
+	return RGBA{RGB{unitToUint8(r + m), unitToUint8(g + m), unitToUint8(b + m)}, float32(a)}
+}
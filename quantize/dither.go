@@ -0,0 +1,229 @@
+package quantize
+
+import (
+	"image"
+	stdcolor "image/color"
+	"image/draw"
+	"math"
+
+	"github.com/zjhsd2007/color"
+)
+
+// Tap is one weighted neighbour of an error diffusion kernel, relative to
+// the current pixel in scan direction
+type Tap struct {
+	DX, DY, Weight int
+}
+
+// Kernel is an error diffusion kernel; each tap receives Weight/Divisor of
+// the quantization error
+type Kernel struct {
+	Taps    []Tap
+	Divisor int
+}
+
+// Common error diffusion kernels
+var (
+	FloydSteinberg = Kernel{[]Tap{
+		{1, 0, 7},
+		{-1, 1, 3}, {0, 1, 5}, {1, 1, 1},
+	}, 16}
+	JarvisJudiceNinke = Kernel{[]Tap{
+		{1, 0, 7}, {2, 0, 5},
+		{-2, 1, 3}, {-1, 1, 5}, {0, 1, 7}, {1, 1, 5}, {2, 1, 3},
+		{-2, 2, 1}, {-1, 2, 3}, {0, 2, 5}, {1, 2, 3}, {2, 2, 1},
+	}, 48}
+	Stucki = Kernel{[]Tap{
+		{1, 0, 8}, {2, 0, 4},
+		{-2, 1, 2}, {-1, 1, 4}, {0, 1, 8}, {1, 1, 4}, {2, 1, 2},
+		{-2, 2, 1}, {-1, 2, 2}, {0, 2, 4}, {1, 2, 2}, {2, 2, 1},
+	}, 42}
+	// Atkinson only spreads 3/4 of the error, which keeps contrast high
+	Atkinson = Kernel{[]Tap{
+		{1, 0, 1}, {2, 0, 1},
+		{-1, 1, 1}, {0, 1, 1}, {1, 1, 1},
+		{0, 2, 1},
+	}, 8}
+	Sierra = Kernel{[]Tap{
+		{1, 0, 5}, {2, 0, 3},
+		{-2, 1, 2}, {-1, 1, 4}, {0, 1, 5}, {1, 1, 4}, {2, 1, 2},
+		{-1, 2, 2}, {0, 2, 3}, {1, 2, 2},
+	}, 32}
+	TwoRowSierra = Kernel{[]Tap{
+		{1, 0, 4}, {2, 0, 3},
+		{-2, 1, 1}, {-1, 1, 2}, {0, 1, 3}, {1, 1, 2}, {2, 1, 1},
+	}, 16}
+	SierraLite = Kernel{[]Tap{
+		{1, 0, 2},
+		{-1, 1, 1}, {0, 1, 1},
+	}, 4}
+)
+
+// ErrorDiffusion is a draw.Drawer that maps each pixel to the nearest
+// palette color and spreads the difference over its unvisited neighbours
+// Example:
+//   d := ErrorDiffusion{Kernel: Atkinson, Serpentine: true, Linear: true}
+//   p, idx := Quantize(img, 16, Wu{}, d)
+type ErrorDiffusion struct {
+	Kernel     Kernel               // FloydSteinberg when empty
+	Serpentine bool                 // alternate the scan direction per row
+	Linear     bool                 // diffuse the error in linear light
+	Metric     color.DistanceMetric // palette matching, Oklab by default
+}
+
+// Draw implements draw.Drawer. A dst that is not an *image.Paletted with a
+// palette is drawn over with draw.Over, as draw.FloydSteinberg does.
+func (e ErrorDiffusion) Draw(dst draw.Image, r image.Rectangle, src image.Image, sp image.Point) {
+	pd, ok := dst.(*image.Paletted)
+	if !ok || len(pd.Palette) == 0 {
+		draw.Draw(dst, r, src, sp, draw.Over)
+		return
+	}
+	r, sp = clip(pd, r, src, sp)
+	if r.Empty() {
+		return
+	}
+	kernel := e.Kernel
+	if len(kernel.Taps) == 0 {
+		kernel = FloydSteinberg
+	}
+	m := newPaletteMatcher(pd.Palette, e.Metric)
+	if len(m.colors) == 0 {
+		return
+	}
+	encode, decode := identity, identity
+	if e.Linear {
+		encode, decode = linearToSrgb8, srgb8ToLinear
+	}
+
+	// rows of accumulated error, one per kernel row, each padded on both
+	// sides so taps never leave the slice
+	pad, rows := 0, 1
+	for _, t := range kernel.Taps {
+		pad = max(pad, abs(t.DX))
+		rows = max(rows, t.DY+1)
+	}
+	w := r.Dx()
+	errs := make([][][3]float64, rows)
+	for i := range errs {
+		errs[i] = make([][3]float64, w+2*pad)
+	}
+	div := float64(kernel.Divisor)
+
+	for y := 0; y < r.Dy(); y++ {
+		dir := 1
+		if e.Serpentine && y%2 == 1 {
+			dir = -1
+		}
+		for i := 0; i < w; i++ {
+			x := i
+			if dir < 0 {
+				x = w - 1 - i
+			}
+			c := stdcolor.NRGBAModel.Convert(src.At(sp.X+x, sp.Y+y)).(stdcolor.NRGBA)
+			if c.A < 0x80 && m.transparent >= 0 {
+				pd.SetColorIndex(r.Min.X+x, r.Min.Y+y, uint8(m.transparent))
+				continue
+			}
+			acc := errs[0][x+pad]
+			var want [3]float64
+			for ch, v := range [3]uint8{c.R, c.G, c.B} {
+				want[ch] = decode(float64(v)) + acc[ch]
+			}
+			target := color.RGB{R: clampUint8(encode(want[0])), G: clampUint8(encode(want[1])), B: clampUint8(encode(want[2]))}
+			idx := m.nearest(target)
+			pd.SetColorIndex(r.Min.X+x, r.Min.Y+y, m.paletteIndex(idx))
+			got := m.colors[idx]
+			for ch, v := range [3]uint8{got.R, got.G, got.B} {
+				diff := want[ch] - decode(float64(v))
+				for _, t := range kernel.Taps {
+					errs[t.DY][x+pad+t.DX*dir][ch] += diff * float64(t.Weight) / div
+				}
+			}
+		}
+		// shift the error rows up and clear the new last row
+		first := errs[0]
+		copy(errs, errs[1:])
+		for i := range first {
+			first[i] = [3]float64{}
+		}
+		errs[rows-1] = first
+	}
+}
+
+// paletteMatcher wraps color.Matcher over the opaque entries of a palette,
+// remembering a fully transparent entry for transparent pixels
+type paletteMatcher struct {
+	matcher     *color.Matcher
+	colors      []color.RGB
+	index       []int
+	transparent int
+}
+
+func newPaletteMatcher(p stdcolor.Palette, metric color.DistanceMetric) *paletteMatcher {
+	m := &paletteMatcher{transparent: -1}
+	for i, c := range p {
+		nc := stdcolor.NRGBAModel.Convert(c).(stdcolor.NRGBA)
+		if nc.A == 0 {
+			if m.transparent < 0 {
+				m.transparent = i
+			}
+			continue
+		}
+		m.index = append(m.index, i)
+		m.colors = append(m.colors, color.RGB{R: nc.R, G: nc.G, B: nc.B})
+	}
+	m.matcher = color.NewMatcher(m.colors, metric)
+	return m
+}
+
+// nearest returns the index into colors of the closest opaque entry
+func (m *paletteMatcher) nearest(c color.RGB) int {
+	return m.matcher.Nearest(c)
+}
+
+// paletteIndex maps an index into colors back to the palette
+func (m *paletteMatcher) paletteIndex(i int) uint8 {
+	return uint8(m.index[i])
+}
+
+// clip restricts r to the destination bounds and the source area
+func clip(dst image.Image, r image.Rectangle, src image.Image, sp image.Point) (image.Rectangle, image.Point) {
+	orig := r.Min
+	r = r.Intersect(dst.Bounds())
+	r = r.Intersect(src.Bounds().Add(orig.Sub(sp)))
+	return r, sp.Add(r.Min.Sub(orig))
+}
+
+func identity(v float64) float64 {
+	return v
+}
+
+// srgb8ToLinear maps an 8 bit sRGB value to linear light scaled to 0-255
+func srgb8ToLinear(v float64) float64 {
+	v /= 255
+	if v <= 0.04045 {
+		return v / 12.92 * 255
+	}
+	return math.Pow((v+0.055)/1.055, 2.4) * 255
+}
+
+// linearToSrgb8 is the inverse of srgb8ToLinear, clamping to 0-255
+func linearToSrgb8(v float64) float64 {
+	v = math.Max(0, math.Min(1, v/255))
+	if v <= 0.0031308 {
+		return v * 12.92 * 255
+	}
+	return (1.055*math.Pow(v, 1/2.4) - 0.055) * 255
+}
+
+func clampUint8(v float64) uint8 {
+	return uint8(math.Max(0, math.Min(255, math.Round(v))))
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
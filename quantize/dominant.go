@@ -0,0 +1,195 @@
+package quantize
+
+import (
+	"image"
+	stdcolor "image/color"
+	"math"
+	"sort"
+
+	"github.com/zjhsd2007/color"
+)
+
+// Swatch is a representative color of an image with its weight
+type Swatch struct {
+	Color      color.RGB
+	Population int     // number of sampled pixels in the cluster
+	Share      float64 // Population over all counted pixels, in 0-1
+}
+
+// Extractor configures dominant color extraction
+type Extractor struct {
+	IgnoreWhite       bool // skip pixels with HSL lightness of 0.95 or more
+	IgnoreBlack       bool // skip pixels with HSL lightness of 0.05 or less
+	IgnoreTransparent bool // skip pixels with alpha below 0.5
+	MaxPixels         int  // sample a grid of at most this many pixels, 0 for all
+	PreferVibrant     bool // rank saturated colors ahead of larger gray areas
+}
+
+// DefaultExtractor skips white, black and transparent backgrounds and
+// samples about 112×112 pixels, like Android's Palette
+var DefaultExtractor = Extractor{
+	IgnoreWhite:       true,
+	IgnoreBlack:       true,
+	IgnoreTransparent: true,
+	MaxPixels:         112 * 112,
+}
+
+// Dominant returns the n most dominant colors of an image with DefaultExtractor
+// Parameters:
+//   img: source image
+//   n: maximum number of colors
+// Returns:
+//   []Swatch: colors ordered by pixel share, largest first
+// Example:
+//   swatches := Dominant(img, 5)
+//   bg := swatches[0].Color
+func Dominant(img image.Image, n int) []Swatch {
+	return DefaultExtractor.Dominant(img, n)
+}
+
+// Dominant returns the n most dominant colors of an image. Colors are
+// found with Wu's quantizer refined by k-means in Oklab.
+// Parameters:
+//   img: source image
+//   n: maximum number of colors
+// Returns:
+//   []Swatch: colors ordered by pixel share, or by share weighted with
+//             chroma when PreferVibrant is set; nil if no pixel is counted
+func (e Extractor) Dominant(img image.Image, n int) []Swatch {
+	b := img.Bounds()
+	step := 1
+	if area := b.Dx() * b.Dy(); e.MaxPixels > 0 && area > e.MaxPixels {
+		step = int(math.Ceil(math.Sqrt(float64(area) / float64(e.MaxPixels))))
+	}
+	bins := histogram(img, step, e.keep)
+	if len(bins) == 0 || n <= 0 {
+		return nil
+	}
+	colors, counts := kmeans(bins, wu(bins, n), 8)
+
+	total := 0
+	for _, c := range bins {
+		total += c.n
+	}
+	var swatches []Swatch
+	for i, c := range colors {
+		if counts[i] == 0 {
+			continue
+		}
+		swatches = append(swatches, Swatch{Color: c, Population: counts[i], Share: float64(counts[i]) / float64(total)})
+	}
+	score := func(s Swatch) float64 {
+		if !e.PreferVibrant {
+			return s.Share
+		}
+		// Oklch chroma of the most saturated sRGB colors is about 0.32
+		lch := s.Color.ToOklch()
+		return s.Share * (0.1 + math.Min(lch.C/0.32, 1))
+	}
+	sort.SliceStable(swatches, func(i, j int) bool { return score(swatches[i]) > score(swatches[j]) })
+	return swatches
+}
+
+func (e Extractor) keep(c stdcolor.NRGBA) bool {
+	if c.A == 0 || (e.IgnoreTransparent && c.A < 0x80) {
+		return false
+	}
+	if e.IgnoreWhite || e.IgnoreBlack {
+		hi := max(c.R, c.G, c.B)
+		lo := min(c.R, c.G, c.B)
+		l := (float64(hi) + float64(lo)) / 510
+		if (e.IgnoreWhite && l >= 0.95) || (e.IgnoreBlack && l <= 0.05) {
+			return false
+		}
+	}
+	return true
+}
+
+// Role is a swatch role of Android's Palette API
+type Role int
+
+const (
+	RoleVibrant Role = iota
+	RoleLightVibrant
+	RoleDarkVibrant
+	RoleMuted
+	RoleLightMuted
+	RoleDarkMuted
+)
+
+// String returns the role name
+func (r Role) String() string {
+	switch r {
+	case RoleVibrant:
+		return "vibrant"
+	case RoleLightVibrant:
+		return "light vibrant"
+	case RoleDarkVibrant:
+		return "dark vibrant"
+	case RoleMuted:
+		return "muted"
+	case RoleLightMuted:
+		return "light muted"
+	case RoleDarkMuted:
+		return "dark muted"
+	}
+	return "unknown"
+}
+
+// roleTarget holds the HSL saturation and lightness ranges of a role,
+// as min, target and max
+type roleTarget struct {
+	s, l [3]float64
+}
+
+// Targets of androidx.palette.graphics.Target
+var roleTargets = []roleTarget{
+	RoleVibrant:      {s: [3]float64{0.35, 1, 1}, l: [3]float64{0.3, 0.5, 0.7}},
+	RoleLightVibrant: {s: [3]float64{0.35, 1, 1}, l: [3]float64{0.55, 0.74, 1}},
+	RoleDarkVibrant:  {s: [3]float64{0.35, 1, 1}, l: [3]float64{0, 0.26, 0.45}},
+	RoleMuted:        {s: [3]float64{0, 0.3, 0.4}, l: [3]float64{0.3, 0.5, 0.7}},
+	RoleLightMuted:   {s: [3]float64{0, 0.3, 0.4}, l: [3]float64{0.55, 0.74, 1}},
+	RoleDarkMuted:    {s: [3]float64{0, 0.3, 0.4}, l: [3]float64{0, 0.26, 0.45}},
+}
+
+// Roles assigns swatches to Android Palette roles. Each swatch fills at
+// most one role, picked by closeness to the role's saturation and
+// lightness targets and by population, as Android does.
+// Parameters:
+//   swatches: candidates, typically Dominant(img, 16)
+// Returns:
+//   map[Role]Swatch: roles that have a matching swatch
+// Example:
+//   roles := Roles(Dominant(img, 16))
+//   if s, ok := roles[RoleVibrant]; ok { accent = s.Color }
+func Roles(swatches []Swatch) map[Role]Swatch {
+	maxPop := 0
+	for _, s := range swatches {
+		maxPop = max(maxPop, s.Population)
+	}
+	used := make([]bool, len(swatches))
+	roles := map[Role]Swatch{}
+	for r, t := range roleTargets {
+		best, bestScore := -1, 0.0
+		for i, s := range swatches {
+			if used[i] {
+				continue
+			}
+			rgba := color.RGBA{RGB: s.Color, A: 1}
+			_, sat, l, _ := rgba.ToHslF()
+			if sat < t.s[0] || sat > t.s[2] || l < t.l[0] || l > t.l[2] {
+				continue
+			}
+			score := 0.24*(1-math.Abs(sat-t.s[1])) + 0.52*(1-math.Abs(l-t.l[1])) +
+				0.24*float64(s.Population)/float64(maxPop)
+			if best < 0 || score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best >= 0 {
+			used[best] = true
+			roles[Role(r)] = swatches[best]
+		}
+	}
+	return roles
+}
@@ -0,0 +1,104 @@
+package quantize
+
+import (
+	"image"
+	stdcolor "image/color"
+	"image/draw"
+	"math"
+
+	"github.com/zjhsd2007/color"
+)
+
+// KMeans refines the palette of another quantizer with Lloyd's k-means
+// iterations in OKLab, where distances follow perceived differences
+type KMeans struct {
+	Init       draw.Quantizer // initial palette, Wu{} when nil
+	Iterations int            // maximum number of passes, 8 when zero
+}
+
+// Quantize appends up to cap(p)-len(p) colors of m to p
+// Parameters:
+//   p: palette to extend, its capacity sets the number of colors
+//   m: source image
+// Returns:
+//   color.Palette: the extended palette
+// Example:
+//   pal := KMeans{Init: MedianCut{}}.Quantize(make(color.Palette, 0, 16), img)
+func (q KMeans) Quantize(p stdcolor.Palette, m image.Image) stdcolor.Palette {
+	return appendColors(p, m, func(bins []bin, k int) []color.RGB {
+		init := q.Init
+		if init == nil {
+			init = Wu{}
+		}
+		// the transparent entry keeps the seed quantizer from reserving one
+		seed := init.Quantize(append(make(stdcolor.Palette, 0, k+1), stdcolor.Transparent), m)
+		iterations := q.Iterations
+		if iterations <= 0 {
+			iterations = 8
+		}
+		centers := make([]color.RGB, 0, len(seed))
+		for _, c := range seed {
+			nc := stdcolor.NRGBAModel.Convert(c).(stdcolor.NRGBA)
+			if nc.A == 0 {
+				continue
+			}
+			centers = append(centers, color.RGB{R: nc.R, G: nc.G, B: nc.B})
+		}
+		colors, _ := kmeans(bins, centers, iterations)
+		return colors
+	})
+}
+
+// kmeans moves the seed colors to the weighted means of their clusters and
+// returns them with the pixel count of each cluster in the last pass
+func kmeans(bins []bin, seed []color.RGB, iterations int) ([]color.RGB, []int) {
+	points := make([]color.OKLAB, len(bins))
+	for i, c := range bins {
+		rgb := color.RGB{R: c.r, G: c.g, B: c.b}
+		points[i] = rgb.ToOklab()
+	}
+	centers := make([]color.OKLAB, len(seed))
+	for i := range seed {
+		centers[i] = seed[i].ToOklab()
+	}
+	sums := make([][4]float64, len(centers))
+	for it := 0; it < iterations; it++ {
+		for i := range sums {
+			sums[i] = [4]float64{}
+		}
+		for i, pt := range points {
+			best, bestDist := 0, math.Inf(1)
+			for j, c := range centers {
+				dl, da, db := pt.L-c.L, pt.A-c.A, pt.B-c.B
+				if d := dl*dl + da*da + db*db; d < bestDist {
+					best, bestDist = j, d
+				}
+			}
+			n := float64(bins[i].n)
+			sums[best][0] += pt.L * n
+			sums[best][1] += pt.A * n
+			sums[best][2] += pt.B * n
+			sums[best][3] += n
+		}
+		moved := 0.0
+		for j, s := range sums {
+			// empty clusters keep their previous center
+			if s[3] == 0 {
+				continue
+			}
+			c := color.OKLAB{L: s[0] / s[3], A: s[1] / s[3], B: s[2] / s[3]}
+			moved = math.Max(moved, math.Abs(c.L-centers[j].L)+math.Abs(c.A-centers[j].A)+math.Abs(c.B-centers[j].B))
+			centers[j] = c
+		}
+		if moved < 1e-5 {
+			break
+		}
+	}
+	colors := make([]color.RGB, len(centers))
+	counts := make([]int, len(centers))
+	for i := range centers {
+		colors[i] = centers[i].ToRgb()
+		counts[i] = int(sums[i][3])
+	}
+	return colors, counts
+}
@@ -0,0 +1,101 @@
+package quantize
+
+import (
+	"image"
+	stdcolor "image/color"
+	"sort"
+
+	"github.com/zjhsd2007/color"
+)
+
+// MedianCut is Heckbert's median cut quantizer: the box with the widest
+// channel range is split at the pixel-weighted median until there are
+// enough boxes, and each box contributes its average color
+type MedianCut struct{}
+
+// Quantize appends up to cap(p)-len(p) colors of m to p
+// Parameters:
+//   p: palette to extend, its capacity sets the number of colors
+//   m: source image
+// Returns:
+//   color.Palette: the extended palette
+func (MedianCut) Quantize(p stdcolor.Palette, m image.Image) stdcolor.Palette {
+	return appendColors(p, m, medianCut)
+}
+
+// channel returns the value of channel ch (0 red, 1 green, 2 blue)
+func (c bin) channel(ch int) uint8 {
+	switch ch {
+	case 0:
+		return c.r
+	case 1:
+		return c.g
+	}
+	return c.b
+}
+
+// widest returns the channel with the largest range and that range
+func widest(bins []bin) (int, int) {
+	lo := [3]uint8{255, 255, 255}
+	var hi [3]uint8
+	for _, c := range bins {
+		for ch := 0; ch < 3; ch++ {
+			v := c.channel(ch)
+			if v < lo[ch] {
+				lo[ch] = v
+			}
+			if v > hi[ch] {
+				hi[ch] = v
+			}
+		}
+	}
+	best := 0
+	for ch := 1; ch < 3; ch++ {
+		if int(hi[ch])-int(lo[ch]) > int(hi[best])-int(lo[best]) {
+			best = ch
+		}
+	}
+	return best, int(hi[best]) - int(lo[best])
+}
+
+func medianCut(bins []bin, k int) []color.RGB {
+	boxes := [][]bin{bins}
+	for len(boxes) < k {
+		split, splitRange := -1, 0
+		for i, box := range boxes {
+			if len(box) < 2 {
+				continue
+			}
+			if _, r := widest(box); r > splitRange {
+				split, splitRange = i, r
+			}
+		}
+		if split < 0 {
+			break
+		}
+		box := boxes[split]
+		ch, _ := widest(box)
+		sort.Slice(box, func(i, j int) bool { return box[i].channel(ch) < box[j].channel(ch) })
+		total := 0
+		for _, c := range box {
+			total += c.n
+		}
+		// cut after the bin where half of the pixels are reached, keeping
+		// both halves non-empty
+		cut, acc := 1, 0
+		for i := 0; i < len(box)-1; i++ {
+			acc += box[i].n
+			cut = i + 1
+			if acc*2 >= total {
+				break
+			}
+		}
+		boxes[split] = box[:cut]
+		boxes = append(boxes, box[cut:])
+	}
+	colors := make([]color.RGB, len(boxes))
+	for i, box := range boxes {
+		colors[i] = mean(box)
+	}
+	return colors
+}
@@ -0,0 +1,126 @@
+package quantize
+
+import (
+	"image"
+	stdcolor "image/color"
+
+	"github.com/zjhsd2007/color"
+)
+
+// Octree is Gervautz and Purgathofer's octree quantizer: colors are
+// inserted into an 8 level tree and the least populated deepest nodes are
+// merged until the number of leaves fits the palette
+type Octree struct{}
+
+// Quantize appends up to cap(p)-len(p) colors of m to p
+// Parameters:
+//   p: palette to extend, its capacity sets the number of colors
+//   m: source image
+// Returns:
+//   color.Palette: the extended palette
+func (Octree) Quantize(p stdcolor.Palette, m image.Image) stdcolor.Palette {
+	return appendColors(p, m, octree)
+}
+
+type octNode struct {
+	children [8]*octNode
+	leaf     bool
+	n        int
+	r, g, b  int
+}
+
+type octTree struct {
+	root      *octNode
+	levels    [8][]*octNode // inner nodes per depth
+	leafCount int
+}
+
+func (t *octTree) insert(c bin) {
+	node := t.root
+	for depth := 0; ; depth++ {
+		node.n += c.n
+		if node.leaf {
+			node.r += int(c.r) * c.n
+			node.g += int(c.g) * c.n
+			node.b += int(c.b) * c.n
+			return
+		}
+		shift := 7 - depth
+		i := (c.r>>shift&1)<<2 | (c.g>>shift&1)<<1 | c.b>>shift&1
+		if node.children[i] == nil {
+			child := &octNode{leaf: depth == 7}
+			if child.leaf {
+				t.leafCount++
+			} else {
+				t.levels[depth+1] = append(t.levels[depth+1], child)
+			}
+			node.children[i] = child
+		}
+		node = node.children[i]
+	}
+}
+
+// reduce merges the least populated inner node of the deepest level
+func (t *octTree) reduce() bool {
+	for depth := 7; depth >= 0; depth-- {
+		nodes := t.levels[depth]
+		if len(nodes) == 0 {
+			continue
+		}
+		best := 0
+		for i, node := range nodes {
+			if node.n < nodes[best].n {
+				best = i
+			}
+		}
+		node := nodes[best]
+		t.levels[depth] = append(nodes[:best], nodes[best+1:]...)
+		merged := 0
+		for i, child := range node.children {
+			if child == nil {
+				continue
+			}
+			node.r += child.r
+			node.g += child.g
+			node.b += child.b
+			node.children[i] = nil
+			merged++
+		}
+		node.leaf = true
+		t.leafCount += 1 - merged
+		return true
+	}
+	return false
+}
+
+func (t *octTree) leaves(node *octNode, out []color.RGB) []color.RGB {
+	if node.leaf {
+		if node.n == 0 {
+			return out
+		}
+		return append(out, color.RGB{
+			R: uint8((node.r + node.n/2) / node.n),
+			G: uint8((node.g + node.n/2) / node.n),
+			B: uint8((node.b + node.n/2) / node.n),
+		})
+	}
+	for _, child := range node.children {
+		if child != nil {
+			out = t.leaves(child, out)
+		}
+	}
+	return out
+}
+
+func octree(bins []bin, k int) []color.RGB {
+	t := &octTree{root: &octNode{}}
+	t.levels[0] = []*octNode{t.root}
+	for _, c := range bins {
+		t.insert(c)
+	}
+	// reducing after all colors are inserted picks the merges by the final
+	// pixel counts instead of the insertion order
+	for t.leafCount > k && t.reduce() {
+	}
+	return t.leaves(t.root, nil)
+}
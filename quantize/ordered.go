@@ -0,0 +1,213 @@
+package quantize
+
+import (
+	"fmt"
+	"image"
+	stdcolor "image/color"
+	"image/draw"
+	"math"
+	"math/rand"
+
+	"github.com/zjhsd2007/color"
+)
+
+// Ordered is a draw.Drawer that offsets every pixel by a tiled threshold
+// matrix before matching it to the palette; unlike error diffusion the
+// result of each pixel does not depend on its neighbours
+// Example:
+//   m, _ := Bayer(8)
+//   p, idx := Quantize(img, 16, Wu{}, Ordered{Matrix: m})
+type Ordered struct {
+	Matrix [][]float64          // thresholds in 0-1, Bayer(8) when nil
+	Spread float64              // offset amplitude in 8 bit units, 255/∛n for n colors when zero
+	Metric color.DistanceMetric // palette matching, Oklab by default
+}
+
+// Draw implements draw.Drawer. A dst that is not an *image.Paletted with a
+// palette is drawn over with draw.Over.
+func (o Ordered) Draw(dst draw.Image, r image.Rectangle, src image.Image, sp image.Point) {
+	pd, ok := dst.(*image.Paletted)
+	if !ok || len(pd.Palette) == 0 {
+		draw.Draw(dst, r, src, sp, draw.Over)
+		return
+	}
+	r, sp = clip(pd, r, src, sp)
+	m := newPaletteMatcher(pd.Palette, o.Metric)
+	if r.Empty() || len(m.colors) == 0 {
+		return
+	}
+	matrix := o.Matrix
+	if len(matrix) == 0 {
+		matrix = bayer(8)
+	}
+	spread := o.Spread
+	if spread == 0 {
+		spread = 255 / math.Cbrt(float64(len(m.colors)))
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		row := matrix[mod(y, len(matrix))]
+		for x := r.Min.X; x < r.Max.X; x++ {
+			c := stdcolor.NRGBAModel.Convert(src.At(sp.X+x-r.Min.X, sp.Y+y-r.Min.Y)).(stdcolor.NRGBA)
+			if c.A < 0x80 && m.transparent >= 0 {
+				pd.SetColorIndex(x, y, uint8(m.transparent))
+				continue
+			}
+			d := (row[mod(x, len(row))] - 0.5) * spread
+			target := color.RGB{
+				R: clampUint8(float64(c.R) + d),
+				G: clampUint8(float64(c.G) + d),
+				B: clampUint8(float64(c.B) + d),
+			}
+			pd.SetColorIndex(x, y, m.paletteIndex(m.nearest(target)))
+		}
+	}
+}
+
+// Bayer returns the normalized Bayer threshold matrix of size n
+// Parameters:
+//   n: 2, 4, 8 or 16
+// Returns:
+//   [][]float64: n×n thresholds (rank+0.5)/n² in 0-1
+//   error: n is not a supported size
+// Example:
+//   m, err := Bayer(2) // returns [[0.125 0.625] [0.875 0.375]]
+func Bayer(n int) ([][]float64, error) {
+	if n < 2 || n > 16 || n&(n-1) != 0 {
+		return nil, fmt.Errorf("invalid bayer matrix size: %d", n)
+	}
+	return bayer(n), nil
+}
+
+// bayer builds the Bayer matrix for a size already checked by Bayer
+func bayer(n int) [][]float64 {
+	rank := [][]int{{0}}
+	for size := 1; size < n; size *= 2 {
+		next := make([][]int, size*2)
+		for y := range next {
+			next[y] = make([]int, size*2)
+			for x := range next[y] {
+				v := 4 * rank[y%size][x%size]
+				switch {
+				case y < size && x >= size:
+					v += 2
+				case y >= size && x < size:
+					v += 3
+				case y >= size && x >= size:
+					v++
+				}
+				next[y][x] = v
+			}
+		}
+		rank = next
+	}
+	return normalizeRanks(rank)
+}
+
+// BlueNoise returns a blue noise threshold matrix made with Ulichney's
+// void-and-cluster method; the matrix tiles without visible seams
+// Parameters:
+//   n: matrix size, 16 to 64 is typical
+//   seed: seed of the initial random pattern
+// Returns:
+//   [][]float64: n×n thresholds (rank+0.5)/n² in 0-1
+//   error: n is smaller than 2
+// Example:
+//   m, err := BlueNoise(32, 1)
+//   d := Ordered{Matrix: m}
+func BlueNoise(n int, seed int64) ([][]float64, error) {
+	if n < 2 {
+		return nil, fmt.Errorf("invalid blue noise matrix size: %d", n)
+	}
+	size := n * n
+	// energy filter: a toroidal gaussian with sigma 1.5
+	filter := make([]float64, size)
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			dx, dy := float64(min(x, n-x)), float64(min(y, n-y))
+			filter[y*n+x] = math.Exp(-(dx*dx + dy*dy) / (2 * 1.5 * 1.5))
+		}
+	}
+	pattern := make([]bool, size)
+	energy := make([]float64, size)
+	toggle := func(p int, on bool) {
+		pattern[p] = on
+		s := 1.0
+		if !on {
+			s = -1
+		}
+		px, py := p%n, p/n
+		for y := 0; y < n; y++ {
+			for x := 0; x < n; x++ {
+				energy[y*n+x] += s * filter[((y-py+n)%n)*n+(x-px+n)%n]
+			}
+		}
+	}
+	// tightest cluster is the set pixel with the most energy, largest void
+	// the empty pixel with the least
+	extreme := func(set bool) int {
+		best := -1
+		for p := range pattern {
+			if pattern[p] != set {
+				continue
+			}
+			if best < 0 || (set && energy[p] > energy[best]) || (!set && energy[p] < energy[best]) {
+				best = p
+			}
+		}
+		return best
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	ones := max(1, size/10)
+	for _, p := range rng.Perm(size)[:ones] {
+		toggle(p, true)
+	}
+	for i := 0; i < size; i++ {
+		c := extreme(true)
+		toggle(c, false)
+		v := extreme(false)
+		toggle(v, true)
+		if v == c {
+			break
+		}
+	}
+	prototype := append([]bool(nil), pattern...)
+	protoEnergy := append([]float64(nil), energy...)
+
+	rank := make([]int, size)
+	for r := ones - 1; r >= 0; r-- {
+		c := extreme(true)
+		toggle(c, false)
+		rank[c] = r
+	}
+	copy(pattern, prototype)
+	copy(energy, protoEnergy)
+	for r := ones; r < size; r++ {
+		v := extreme(false)
+		toggle(v, true)
+		rank[v] = r
+	}
+
+	ranks := make([][]int, n)
+	for y := range ranks {
+		ranks[y] = rank[y*n : (y+1)*n]
+	}
+	return normalizeRanks(ranks), nil
+}
+
+func normalizeRanks(rank [][]int) [][]float64 {
+	n := float64(len(rank) * len(rank[0]))
+	m := make([][]float64, len(rank))
+	for y, row := range rank {
+		m[y] = make([]float64, len(row))
+		for x, v := range row {
+			m[y][x] = (float64(v) + 0.5) / n
+		}
+	}
+	return m
+}
+
+// mod returns a non-negative remainder for negative image coordinates
+func mod(a, n int) int {
+	return (a%n + n) % n
+}
@@ -0,0 +1,129 @@
+// Package quantize reduces images to small palettes. The quantizers
+// implement image/draw.Quantizer, so they plug into gif.Options, and the
+// index image can be produced with any draw.Drawer, including the error
+// diffusion and ordered dithering drawers of this package.
+package quantize
+
+import (
+	"image"
+	stdcolor "image/color"
+	"image/draw"
+
+	"github.com/zjhsd2007/color"
+	"github.com/zjhsd2007/color/palette"
+)
+
+// bin is a distinct opaque color of an image with its pixel count
+type bin struct {
+	r, g, b uint8
+	n       int
+}
+
+// histogram counts the distinct colors of every step-th pixel in both
+// directions that keep accepts; alpha is removed
+func histogram(m image.Image, step int, keep func(stdcolor.NRGBA) bool) []bin {
+	counts := map[uint32]int{}
+	b := m.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y += step {
+		for x := b.Min.X; x < b.Max.X; x += step {
+			c := stdcolor.NRGBAModel.Convert(m.At(x, y)).(stdcolor.NRGBA)
+			if !keep(c) {
+				continue
+			}
+			counts[uint32(c.R)<<16|uint32(c.G)<<8|uint32(c.B)]++
+		}
+	}
+	bins := make([]bin, 0, len(counts))
+	for k, n := range counts {
+		bins = append(bins, bin{uint8(k >> 16), uint8(k >> 8), uint8(k), n})
+	}
+	return bins
+}
+
+// appendColors implements the draw.Quantizer contract: it computes up to
+// cap(p)-len(p) colors of the visible pixels of m with build and appends
+// them to p. A fully transparent entry is reserved when m has pixels with
+// alpha below 0.5, and is the only entry when no pixel is visible.
+func appendColors(p stdcolor.Palette, m image.Image, build func([]bin, int) []color.RGB) stdcolor.Palette {
+	k := cap(p) - len(p)
+	if k <= 0 {
+		return p
+	}
+	transparent := false
+	bins := histogram(m, 1, func(c stdcolor.NRGBA) bool {
+		if c.A < 0x80 {
+			transparent = true
+		}
+		return c.A > 0
+	})
+	if (transparent && k > 1 || len(bins) == 0) && !hasTransparent(p) {
+		p = append(p, stdcolor.Transparent)
+		k--
+	}
+	if len(bins) == 0 || k == 0 {
+		return p
+	}
+	for _, c := range build(bins, k) {
+		p = append(p, stdcolor.RGBA{R: c.R, G: c.G, B: c.B, A: 0xff})
+	}
+	return p
+}
+
+// hasTransparent reports whether p has a fully transparent entry
+func hasTransparent(p stdcolor.Palette) bool {
+	for _, c := range p {
+		if _, _, _, a := c.RGBA(); a == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Quantize reduces an image to at most n colors
+// Parameters:
+//   img: source image
+//   n: maximum palette size, 256 for GIF
+//   q: quantizer such as MedianCut{}, Octree{}, Wu{} or KMeans{}
+//   d: drawer mapping pixels to the palette, draw.Src (nearest color) when nil
+// Returns:
+//   *palette.Palette: the colors without names, with a transparent entry
+//                     when the image has transparent pixels
+//   *image.Paletted: index image using the same colors
+// Example:
+//   p, idx := Quantize(img, 16, Wu{}, draw.FloydSteinberg)
+//   gif.Encode(w, idx, nil)
+func Quantize(img image.Image, n int, q draw.Quantizer, d draw.Drawer) (*palette.Palette, *image.Paletted) {
+	pal := q.Quantize(make(stdcolor.Palette, 0, n), img)
+	if len(pal) == 0 {
+		// drawers need at least one entry to map pixels to
+		pal = stdcolor.Palette{stdcolor.Transparent}
+	}
+	b := img.Bounds()
+	dst := image.NewPaletted(b, pal)
+	if d == nil {
+		d = draw.Src
+	}
+	d.Draw(dst, b, img, b.Min)
+
+	p := &palette.Palette{}
+	for _, c := range pal {
+		nc := stdcolor.NRGBAModel.Convert(c).(stdcolor.NRGBA)
+		p.Entries = append(p.Entries, palette.Entry{Color: color.RGBA{
+			RGB: color.RGB{R: nc.R, G: nc.G, B: nc.B},
+			A:   float32(nc.A) / 255,
+		}})
+	}
+	return p, dst
+}
+
+// mean returns the count-weighted average color of bins
+func mean(bins []bin) color.RGB {
+	var r, g, b, n int
+	for _, c := range bins {
+		r += int(c.r) * c.n
+		g += int(c.g) * c.n
+		b += int(c.b) * c.n
+		n += c.n
+	}
+	return color.RGB{R: uint8((r + n/2) / n), G: uint8((g + n/2) / n), B: uint8((b + n/2) / n)}
+}
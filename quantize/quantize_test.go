@@ -0,0 +1,349 @@
+package quantize
+
+import (
+	"bytes"
+	"image"
+	stdcolor "image/color"
+	"image/draw"
+	"image/gif"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/zjhsd2007/color"
+)
+
+// sampleImage returns four flat quadrants with a gradient strip below them
+func sampleImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 80))
+	quads := []stdcolor.NRGBA{
+		{R: 200, G: 30, B: 40, A: 255},
+		{R: 20, G: 160, B: 60, A: 255},
+		{R: 30, G: 60, B: 220, A: 255},
+		{R: 240, G: 230, B: 200, A: 255},
+	}
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			img.SetNRGBA(x, y, quads[y/32*2+x/32])
+		}
+	}
+	for y := 64; y < 80; y++ {
+		for x := 0; x < 64; x++ {
+			v := uint8(x * 4)
+			img.SetNRGBA(x, y, stdcolor.NRGBA{R: v, G: v, B: 255 - v, A: 255})
+		}
+	}
+	return img
+}
+
+func quantizers() map[string]draw.Quantizer {
+	return map[string]draw.Quantizer{
+		"median cut": MedianCut{},
+		"octree":     Octree{},
+		"wu":         Wu{},
+		"k-means":    KMeans{},
+	}
+}
+
+func TestExactColors(t *testing.T) {
+	img := sampleImage().SubImage(image.Rect(0, 0, 64, 64))
+	for name, q := range quantizers() {
+		p, idx := Quantize(img, 4, q, nil)
+		if len(p.Entries) != 4 {
+			t.Fatalf("%s: got %d colors", name, len(p.Entries))
+		}
+		b := idx.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if idx.At(x, y) != stdcolor.RGBAModel.Convert(img.At(x, y)) {
+					t.Fatalf("%s: pixel %d,%d is %v, want %v", name, x, y, idx.At(x, y), img.At(x, y))
+				}
+			}
+		}
+	}
+}
+
+func TestPaletteSize(t *testing.T) {
+	img := sampleImage()
+	for name, q := range quantizers() {
+		for _, n := range []int{2, 8, 16} {
+			pal := q.Quantize(make(stdcolor.Palette, 0, n), img)
+			if len(pal) == 0 || len(pal) > n {
+				t.Errorf("%s: %d colors for n=%d", name, len(pal), n)
+			}
+		}
+		// colors already in the palette are kept
+		pal := q.Quantize(append(make(stdcolor.Palette, 0, 8), stdcolor.Transparent), img)
+		if len(pal) > 8 || pal[0] != stdcolor.Transparent {
+			t.Errorf("%s: existing colors not kept: %v", name, pal)
+		}
+	}
+}
+
+func TestKMeansError(t *testing.T) {
+	img := sampleImage()
+	sse := func(pal stdcolor.Palette) float64 {
+		var sum float64
+		b := img.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				c := img.NRGBAAt(x, y)
+				p := pal[pal.Index(c)].(stdcolor.RGBA)
+				dr, dg, db := float64(c.R)-float64(p.R), float64(c.G)-float64(p.G), float64(c.B)-float64(p.B)
+				sum += dr*dr + dg*dg + db*db
+			}
+		}
+		return sum
+	}
+	base := sse(MedianCut{}.Quantize(make(stdcolor.Palette, 0, 6), img))
+	refined := sse(KMeans{Init: MedianCut{}}.Quantize(make(stdcolor.Palette, 0, 6), img))
+	if refined > base*1.05 {
+		t.Errorf("k-means error %.0f, median cut %.0f", refined, base)
+	}
+}
+
+func TestGif(t *testing.T) {
+	img := sampleImage()
+	for name, q := range quantizers() {
+		var buf bytes.Buffer
+		err := gif.Encode(&buf, img, &gif.Options{NumColors: 16, Quantizer: q, Drawer: draw.FloydSteinberg})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		out, err := gif.Decode(&buf)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if n := len(out.(*image.Paletted).Palette); n > 16 {
+			t.Errorf("%s: %d colors in gif", name, n)
+		}
+	}
+}
+
+func TestTransparency(t *testing.T) {
+	blank := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	cutout := sampleImage()
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 16; x++ {
+			cutout.SetNRGBA(x, y, stdcolor.NRGBA{})
+		}
+	}
+	for name, q := range quantizers() {
+		p, idx := Quantize(blank, 16, q, draw.FloydSteinberg)
+		if len(p.Entries) != 1 || p.Entries[0].Color.A != 0 {
+			t.Errorf("%s: blank image palette %v", name, p.Entries)
+		}
+		if _, _, _, a := idx.At(0, 0).RGBA(); a != 0 {
+			t.Errorf("%s: blank pixel alpha %d", name, a)
+		}
+
+		p, idx = Quantize(cutout, 8, q, draw.FloydSteinberg)
+		transparent := 0
+		for _, e := range p.Entries {
+			if e.Color.A == 0 {
+				transparent++
+			}
+		}
+		if len(p.Entries) > 8 || transparent != 1 {
+			t.Errorf("%s: palette %v", name, p.Entries)
+		}
+		if _, _, _, a := idx.At(4, 4).RGBA(); a != 0 {
+			t.Errorf("%s: cutout pixel alpha %d", name, a)
+		}
+		if _, _, _, a := idx.At(40, 40).RGBA(); a != 0xffff {
+			t.Errorf("%s: opaque pixel alpha %d", name, a)
+		}
+
+		var buf bytes.Buffer
+		if err := gif.Encode(&buf, cutout, &gif.Options{NumColors: 16, Quantizer: q}); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		out, err := gif.Decode(&buf)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if _, _, _, a := out.At(4, 4).RGBA(); a != 0 {
+			t.Errorf("%s: gif lost transparency", name)
+		}
+	}
+}
+
+// whiteShare dithers a flat gray to black and white and returns the share
+// of white pixels
+func whiteShare(d draw.Drawer, gray uint8) float64 {
+	src := image.NewUniform(stdcolor.Gray{Y: gray})
+	dst := image.NewPaletted(image.Rect(0, 0, 64, 64), stdcolor.Palette{stdcolor.Black, stdcolor.White})
+	d.Draw(dst, dst.Bounds(), src, image.Point{})
+	white := 0
+	for _, i := range dst.Pix {
+		white += int(i)
+	}
+	return float64(white) / float64(len(dst.Pix))
+}
+
+func TestErrorDiffusion(t *testing.T) {
+	kernels := map[string]Kernel{
+		"floyd-steinberg": FloydSteinberg,
+		"jjn":             JarvisJudiceNinke,
+		"stucki":          Stucki,
+		"sierra":          Sierra,
+		"two-row sierra":  TwoRowSierra,
+		"sierra lite":     SierraLite,
+	}
+	for name, k := range kernels {
+		for _, serpentine := range []bool{false, true} {
+			d := ErrorDiffusion{Kernel: k, Serpentine: serpentine}
+			if s := whiteShare(d, 64); math.Abs(s-0.25) > 0.02 {
+				t.Errorf("%s serpentine=%v: white share %.3f", name, serpentine, s)
+			}
+		}
+	}
+	// in linear light sRGB 128 is about 21.6% of white
+	if s := whiteShare(ErrorDiffusion{Linear: true}, 128); math.Abs(s-0.216) > 0.02 {
+		t.Errorf("linear white share %.3f", s)
+	}
+	// Atkinson drops a quarter of the error, so light dots vanish on dark gray
+	if s := whiteShare(ErrorDiffusion{Kernel: Atkinson}, 24); s > 0.05 {
+		t.Errorf("atkinson white share %.3f", s)
+	}
+	// flat palette colors are kept
+	img := sampleImage().SubImage(image.Rect(0, 0, 64, 64))
+	_, exact := Quantize(img, 4, Wu{}, nil)
+	_, dithered := Quantize(img, 4, Wu{}, ErrorDiffusion{Metric: color.DistanceCIEDE2000})
+	if !bytes.Equal(exact.Pix, dithered.Pix) {
+		t.Error("dithering changed exactly matching pixels")
+	}
+}
+
+func TestOrdered(t *testing.T) {
+	if got, err := Bayer(2); err != nil || !reflect.DeepEqual(got, [][]float64{{0.125, 0.625}, {0.875, 0.375}}) {
+		t.Errorf("Bayer(2) = %v, %v", got, err)
+	}
+	for _, n := range []int{0, 3, 32} {
+		if _, err := Bayer(n); err == nil {
+			t.Errorf("Bayer(%d) should fail", n)
+		}
+	}
+	if _, err := BlueNoise(1, 1); err == nil {
+		t.Error("BlueNoise(1) should fail")
+	}
+	b4, _ := Bayer(4)
+	b16, _ := Bayer(16)
+	noise, err := BlueNoise(16, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range [][][]float64{b4, b16, noise} {
+		seen := map[float64]bool{}
+		for _, row := range m {
+			for _, v := range row {
+				seen[v] = true
+			}
+		}
+		if len(seen) != len(m)*len(m) {
+			t.Errorf("%dx%d matrix has repeated thresholds", len(m), len(m))
+		}
+		d := Ordered{Matrix: m, Spread: 255, Metric: color.DistanceRGB}
+		for _, gray := range []uint8{64, 128, 192} {
+			if s := whiteShare(d, gray); math.Abs(s-float64(gray)/255) > 0.03 {
+				t.Errorf("%dx%d matrix: white share %.3f for gray %d", len(m), len(m), s, gray)
+			}
+		}
+	}
+}
+
+// productImage is a product shot: white background, a red item with a
+// dark blue label and a gray shadow, and a transparent corner
+func productImage() *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, 100, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 100; x++ {
+			c := stdcolor.NRGBA{R: 255, G: 255, B: 255, A: 255}
+			switch {
+			case x < 10 && y < 10:
+				c = stdcolor.NRGBA{R: 0, G: 255, B: 0, A: 0}
+			case y >= 90:
+				c = stdcolor.NRGBA{R: 128, G: 128, B: 128, A: 255}
+			case x >= 20 && x < 70 && y >= 20 && y < 70:
+				c = stdcolor.NRGBA{R: 220, G: 20, B: 30, A: 255}
+				if y >= 55 {
+					c = stdcolor.NRGBA{R: 20, G: 30, B: 90, A: 255}
+				}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestDominant(t *testing.T) {
+	img := productImage()
+	swatches := Dominant(img, 3)
+	if len(swatches) != 3 {
+		t.Fatalf("got %d swatches: %v", len(swatches), swatches)
+	}
+	want := []struct {
+		c     color.RGB
+		share float64
+	}{
+		{color.RGB{R: 220, G: 20, B: 30}, 1750.0 / 3500},
+		{color.RGB{R: 128, G: 128, B: 128}, 1000.0 / 3500},
+		{color.RGB{R: 20, G: 30, B: 90}, 750.0 / 3500},
+	}
+	for i, w := range want {
+		if swatches[i].Color != w.c || math.Abs(swatches[i].Share-w.share) > 0.03 {
+			t.Errorf("swatch %d = %+v, want %v %.3f", i, swatches[i], w.c, w.share)
+		}
+	}
+
+	all := Extractor{}.Dominant(img, 5)
+	if all[0].Color != (color.RGB{R: 255, G: 255, B: 255}) {
+		t.Errorf("white background not dominant: %+v", all[0])
+	}
+	for _, s := range all {
+		if s.Color == (color.RGB{R: 0, G: 255, B: 0}) {
+			t.Error("fully transparent pixels counted")
+		}
+	}
+
+	vibrant := Extractor{IgnoreWhite: true, PreferVibrant: true}.Dominant(img, 3)
+	if vibrant[0].Color != (color.RGB{R: 220, G: 20, B: 30}) {
+		t.Errorf("vibrant first = %+v", vibrant[0])
+	}
+	gray := Extractor{IgnoreWhite: true}.Dominant(img.SubImage(image.Rect(0, 50, 100, 100)), 3)
+	vibrant = Extractor{IgnoreWhite: true, PreferVibrant: true}.Dominant(img.SubImage(image.Rect(0, 50, 100, 100)), 3)
+	if gray[0].Color != (color.RGB{R: 128, G: 128, B: 128}) || vibrant[0].Color == gray[0].Color {
+		t.Errorf("PreferVibrant did not reorder: %+v / %+v", gray, vibrant)
+	}
+
+	sampled := Extractor{MaxPixels: 400, IgnoreWhite: true}.Dominant(img, 3)
+	if len(sampled) != 3 || sampled[0].Color != (color.RGB{R: 220, G: 20, B: 30}) || sampled[0].Population > 400 {
+		t.Errorf("downsampled swatches %+v", sampled)
+	}
+}
+
+func TestRoles(t *testing.T) {
+	swatches := []Swatch{
+		{Color: color.RGB{R: 220, G: 20, B: 30}, Population: 50},
+		{Color: color.RGB{R: 255, G: 170, B: 180}, Population: 10},
+		{Color: color.RGB{R: 20, G: 30, B: 90}, Population: 30},
+		{Color: color.RGB{R: 120, G: 110, B: 100}, Population: 40},
+		{Color: color.RGB{R: 200, G: 195, B: 185}, Population: 20},
+	}
+	roles := Roles(swatches)
+	want := map[Role]color.RGB{
+		RoleVibrant:      {R: 220, G: 20, B: 30},
+		RoleLightVibrant: {R: 255, G: 170, B: 180},
+		RoleDarkVibrant:  {R: 20, G: 30, B: 90},
+		RoleMuted:        {R: 120, G: 110, B: 100},
+		RoleLightMuted:   {R: 200, G: 195, B: 185},
+	}
+	for r, c := range want {
+		if roles[r].Color != c {
+			t.Errorf("%s = %v, want %v", r, roles[r].Color, c)
+		}
+	}
+	if _, ok := roles[RoleDarkMuted]; ok {
+		t.Errorf("dark muted should be empty, got %v", roles[RoleDarkMuted])
+	}
+}
@@ -0,0 +1,198 @@
+package quantize
+
+import (
+	"image"
+	stdcolor "image/color"
+
+	"github.com/zjhsd2007/color"
+)
+
+// Wu is Xiaolin Wu's variance minimizing quantizer: cumulative moments of
+// a 32x32x32 histogram let every box be split at the plane that reduces
+// the color variance the most
+type Wu struct{}
+
+// Quantize appends up to cap(p)-len(p) colors of m to p
+// Parameters:
+//   p: palette to extend, its capacity sets the number of colors
+//   m: source image
+// Returns:
+//   color.Palette: the extended palette
+func (Wu) Quantize(p stdcolor.Palette, m image.Image) stdcolor.Palette {
+	return appendColors(p, m, wu)
+}
+
+const wuSide = 33
+
+func wuIndex(r, g, b int) int {
+	return r*wuSide*wuSide + g*wuSide + b
+}
+
+type wuBox struct {
+	r0, r1, g0, g1, b0, b1 int
+}
+
+// wuMoments holds the cumulative histogram moments, indexed by wuIndex
+type wuMoments struct {
+	w, r, g, b []float64
+	m2         []float64
+}
+
+func newWuMoments(bins []bin) *wuMoments {
+	size := wuSide * wuSide * wuSide
+	m := &wuMoments{
+		w:  make([]float64, size),
+		r:  make([]float64, size),
+		g:  make([]float64, size),
+		b:  make([]float64, size),
+		m2: make([]float64, size),
+	}
+	for _, c := range bins {
+		i := wuIndex(int(c.r>>3)+1, int(c.g>>3)+1, int(c.b>>3)+1)
+		n := float64(c.n)
+		r, g, b := float64(c.r), float64(c.g), float64(c.b)
+		m.w[i] += n
+		m.r[i] += r * n
+		m.g[i] += g * n
+		m.b[i] += b * n
+		m.m2[i] += (r*r + g*g + b*b) * n
+	}
+	// turn the histogram into prefix sums over all three axes
+	for _, t := range [][]float64{m.w, m.r, m.g, m.b, m.m2} {
+		for r := 1; r < wuSide; r++ {
+			for g := 1; g < wuSide; g++ {
+				for b := 1; b < wuSide; b++ {
+					t[wuIndex(r, g, b)] += t[wuIndex(r-1, g, b)] + t[wuIndex(r, g-1, b)] + t[wuIndex(r, g, b-1)] -
+						t[wuIndex(r-1, g-1, b)] - t[wuIndex(r-1, g, b-1)] - t[wuIndex(r, g-1, b-1)] +
+						t[wuIndex(r-1, g-1, b-1)]
+				}
+			}
+		}
+	}
+	return m
+}
+
+// volume sums a moment over a box
+func volume(b wuBox, t []float64) float64 {
+	return t[wuIndex(b.r1, b.g1, b.b1)] - t[wuIndex(b.r1, b.g1, b.b0)] -
+		t[wuIndex(b.r1, b.g0, b.b1)] + t[wuIndex(b.r1, b.g0, b.b0)] -
+		t[wuIndex(b.r0, b.g1, b.b1)] + t[wuIndex(b.r0, b.g1, b.b0)] +
+		t[wuIndex(b.r0, b.g0, b.b1)] - t[wuIndex(b.r0, b.g0, b.b0)]
+}
+
+// variance returns the sum of squared distances to the box mean
+func (m *wuMoments) variance(b wuBox) float64 {
+	w := volume(b, m.w)
+	if w == 0 {
+		return 0
+	}
+	r, g, bb := volume(b, m.r), volume(b, m.g), volume(b, m.b)
+	return volume(b, m.m2) - (r*r+g*g+bb*bb)/w
+}
+
+// lower returns the box from its start to pos along axis dir
+func (b wuBox) lower(dir, pos int) wuBox {
+	switch dir {
+	case 0:
+		b.r1 = pos
+	case 1:
+		b.g1 = pos
+	default:
+		b.b1 = pos
+	}
+	return b
+}
+
+// bounds returns the open interval of cut positions along axis dir
+func (b wuBox) bounds(dir int) (int, int) {
+	switch dir {
+	case 0:
+		return b.r0, b.r1
+	case 1:
+		return b.g0, b.g1
+	}
+	return b.b0, b.b1
+}
+
+// maximize finds the cut along dir with the largest between-class variance
+func (m *wuMoments) maximize(b wuBox, dir int) (float64, int) {
+	wholeW, wholeR := volume(b, m.w), volume(b, m.r)
+	wholeG, wholeB := volume(b, m.g), volume(b, m.b)
+	best, cut := 0.0, -1
+	lo, hi := b.bounds(dir)
+	for pos := lo + 1; pos < hi; pos++ {
+		half := b.lower(dir, pos)
+		w := volume(half, m.w)
+		if w == 0 || w == wholeW {
+			continue
+		}
+		r, g, bb := volume(half, m.r), volume(half, m.g), volume(half, m.b)
+		score := (r*r + g*g + bb*bb) / w
+		r, g, bb, w = wholeR-r, wholeG-g, wholeB-bb, wholeW-w
+		score += (r*r + g*g + bb*bb) / w
+		if score > best {
+			best, cut = score, pos
+		}
+	}
+	return best, cut
+}
+
+// split cuts b in two, returning false if it cannot be divided
+func (m *wuMoments) split(b wuBox) (wuBox, wuBox, bool) {
+	dir, best, cut := -1, 0.0, -1
+	for d := 0; d < 3; d++ {
+		if score, pos := m.maximize(b, d); pos >= 0 && score > best {
+			dir, best, cut = d, score, pos
+		}
+	}
+	if dir < 0 {
+		return b, b, false
+	}
+	first, second := b.lower(dir, cut), b
+	switch dir {
+	case 0:
+		second.r0 = cut
+	case 1:
+		second.g0 = cut
+	default:
+		second.b0 = cut
+	}
+	return first, second, true
+}
+
+func wu(bins []bin, k int) []color.RGB {
+	m := newWuMoments(bins)
+	boxes := []wuBox{{0, wuSide - 1, 0, wuSide - 1, 0, wuSide - 1}}
+	vars := []float64{m.variance(boxes[0])}
+	for len(boxes) < k {
+		next := 0
+		for i, v := range vars {
+			if v > vars[next] {
+				next = i
+			}
+		}
+		if vars[next] <= 0 {
+			break
+		}
+		first, second, ok := m.split(boxes[next])
+		if !ok {
+			vars[next] = 0
+			continue
+		}
+		boxes[next], vars[next] = first, m.variance(first)
+		boxes, vars = append(boxes, second), append(vars, m.variance(second))
+	}
+	colors := make([]color.RGB, 0, len(boxes))
+	for _, b := range boxes {
+		w := volume(b, m.w)
+		if w == 0 {
+			continue
+		}
+		colors = append(colors, color.RGB{
+			R: uint8(volume(b, m.r)/w + 0.5),
+			G: uint8(volume(b, m.g)/w + 0.5),
+			B: uint8(volume(b, m.b)/w + 0.5),
+		})
+	}
+	return colors
+}
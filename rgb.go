@@ -99,4 +99,77 @@ func (c *RGB) ToHsv() HSV {
 func (c *RGB) ToCmyk() CMYK {
 	cv, m, y, k := rgbToCmyk(c.R, c.G, c.B)
 	return CMYK{C: cv, M: m, Y: y, K: k}
+}
+
+// ToXyz converts RGB to CIE XYZ (D65) representation
+// Returns:
+//   XYZ: corresponding XYZ color object
+// Example:
+//   c := RGB{255,255,255} // white
+//   xyz := c.ToXyz() // returns XYZ{0.9505,1.0000,1.0888}
+func (c *RGB) ToXyz() XYZ {
+	x, y, z := rgbToXyz(c.R, c.G, c.B)
+	return XYZ{X: x, Y: y, Z: z}
+}
+
+// ToLab converts RGB to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c := RGB{255,0,0} // red
+//   lab := c.ToLab() // returns LAB{54.29,80.81,69.89}
+func (c *RGB) ToLab() LAB {
+	xyz := c.ToXyz()
+	return xyzToLabD50(xyz)
+}
+
+// ToOklab converts RGB to Oklab representation
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c := RGB{0,0,255} // blue
+//   lab := c.ToOklab() // returns OKLAB{0.4520,-0.0325,-0.3115}
+func (c *RGB) ToOklab() OKLAB {
+	return xyzToOklab(c.ToXyz())
+}
+
+// ToOklch converts RGB to OKLCH representation
+// Returns:
+//   OKLCH: corresponding OKLCH color object
+// Example:
+//   c := RGB{255,0,0} // red
+//   lch := c.ToOklch() // returns OKLCH{0.6280,0.2577,29.23}
+func (c *RGB) ToOklch() OKLCH {
+	lab := c.ToOklab()
+	return lab.ToOklch()
+}
+
+// ToHct converts RGB to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c := RGB{255,0,0} // red
+//   hct := c.ToHct() // returns HCT{27.41,113.36,53.24}
+func (c *RGB) ToHct() HCT {
+	return xyzToHct(c.ToXyz())
+}
+
+// ToHsluv converts RGB to HSLuv representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c := RGB{255,0,0} // red
+//   hsl := c.ToHsluv() // returns HSLUV{12.18,100.00,53.24}
+func (c *RGB) ToHsluv() HSLUV {
+	return xyzToHsluv(rgbToHsluvXyz(c))
+}
+
+// ToHpluv converts RGB to HPLuv representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c := RGB{255,0,0} // red
+//   hpl := c.ToHpluv() // returns HPLUV{12.18,426.75,53.24}
+func (c *RGB) ToHpluv() HPLUV {
+	return xyzToHpluv(rgbToHsluvXyz(c))
 }
\ No newline at end of file
@@ -105,4 +105,81 @@ func (c *RGBA) ToCmyk() CMYK {
 	b1 := calcRgbWithAlpha(c.B, c.A)
 	cv, m, y, k := rgbToCmyk(r1, g1, b1)
 	return CMYK{cv, m, y, k}
+}
+
+// ToXyz converts RGBA to CIE XYZ (D65) representation
+// Returns:
+//   XYZ: corresponding XYZ color object
+// Example:
+//   c := RGBA{RGB{255, 0, 0}, 1.0} // red
+//   xyz := c.ToXyz() // returns XYZ{0.4124,0.2126,0.0193}
+func (c *RGBA) ToXyz() XYZ {
+	rgb := c.ToRgb()
+	return rgb.ToXyz()
+}
+
+// ToLab converts RGBA to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c := RGBA{RGB{0, 0, 255}, 1.0} // blue
+//   lab := c.ToLab() // returns LAB{29.57,68.30,-112.03}
+func (c *RGBA) ToLab() LAB {
+	rgb := c.ToRgb()
+	return rgb.ToLab()
+}
+
+// ToOklab converts RGBA to Oklab representation
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c := RGBA{RGB{0, 255, 0}, 1.0} // green
+//   lab := c.ToOklab() // returns OKLAB{0.8664,-0.2339,0.1795}
+func (c *RGBA) ToOklab() OKLAB {
+	rgb := c.ToRgb()
+	return rgb.ToOklab()
+}
+
+// ToOklch converts RGBA to OKLCH representation
+// Returns:
+//   OKLCH: corresponding OKLCH color object
+// Example:
+//   c := RGBA{RGB{255, 0, 0}, 1.0} // red
+//   lch := c.ToOklch() // returns OKLCH{0.6280,0.2577,29.23}
+func (c *RGBA) ToOklch() OKLCH {
+	rgb := c.ToRgb()
+	return rgb.ToOklch()
+}
+
+// ToHct converts RGBA to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c := RGBA{RGB{0, 255, 0}, 1.0} // green
+//   hct := c.ToHct() // returns HCT{142.14,108.41,87.73}
+func (c *RGBA) ToHct() HCT {
+	rgb := c.ToRgb()
+	return rgb.ToHct()
+}
+
+// ToHsluv converts RGBA to HSLuv representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c := RGBA{RGB{0, 255, 0}, 1.0} // green
+//   hsl := c.ToHsluv() // returns HSLUV{127.72,100.00,87.74}
+func (c *RGBA) ToHsluv() HSLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHsluv()
+}
+
+// ToHpluv converts RGBA to HPLuv representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c := RGBA{RGB{0, 255, 0}, 1.0} // green
+//   hpl := c.ToHpluv() // returns HPLUV{127.72,490.15,87.74}
+func (c *RGBA) ToHpluv() HPLUV {
+	rgb := c.ToRgb()
+	return rgb.ToHpluv()
 }
\ No newline at end of file
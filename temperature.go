@@ -0,0 +1,168 @@
+package color
+
+import "math"
+
+// robertsonIsotherms holds Robertson's isotemperature lines:
+// reciprocal megakelvin, CIE 1960 u, v and slope t
+var robertsonIsotherms = [31][4]float64{
+	{0, 0.18006, 0.26352, -0.24341},
+	{10, 0.18066, 0.26589, -0.25479},
+	{20, 0.18133, 0.26846, -0.26876},
+	{30, 0.18208, 0.27119, -0.28539},
+	{40, 0.18293, 0.27407, -0.30470},
+	{50, 0.18388, 0.27709, -0.32675},
+	{60, 0.18494, 0.28021, -0.35156},
+	{70, 0.18611, 0.28342, -0.37915},
+	{80, 0.18740, 0.28668, -0.40955},
+	{90, 0.18880, 0.28997, -0.44278},
+	{100, 0.19032, 0.29326, -0.47888},
+	{125, 0.19462, 0.30141, -0.58204},
+	{150, 0.19962, 0.30921, -0.70471},
+	{175, 0.20525, 0.31647, -0.84901},
+	{200, 0.21142, 0.32312, -1.0182},
+	{225, 0.21807, 0.32909, -1.2168},
+	{250, 0.22511, 0.33439, -1.4512},
+	{275, 0.23247, 0.33904, -1.7298},
+	{300, 0.24010, 0.34308, -2.0637},
+	{325, 0.24792, 0.34655, -2.4681},
+	{350, 0.25591, 0.34951, -2.9641},
+	{375, 0.26400, 0.35200, -3.5814},
+	{400, 0.27218, 0.35407, -4.3633},
+	{425, 0.28039, 0.35577, -5.3762},
+	{450, 0.28863, 0.35714, -6.7262},
+	{475, 0.29685, 0.35823, -8.5955},
+	{500, 0.30505, 0.35907, -11.324},
+	{525, 0.31320, 0.35968, -15.628},
+	{550, 0.32129, 0.36011, -23.325},
+	{575, 0.32931, 0.36038, -40.770},
+	{600, 0.33724, 0.36051, -116.45},
+}
+
+// FromKelvin converts a color temperature to an sRGB white
+// Temperatures below 4000K follow the Planckian locus, temperatures from
+// 4000K upward follow the CIE daylight locus. The input is clamped to
+// 1667K-25000K and the result is scaled so its brightest channel is 255.
+// Parameters:
+//   k: correlated color temperature in kelvin
+// Returns:
+//   RGB: white of the given temperature
+// Example:
+//   c := FromKelvin(6504) // returns RGB{255,255,255} (D65)
+func FromKelvin(k float64) RGB {
+	x, y := kelvinToXy(k)
+	lr, lg, lb := mulMat3Vec(xyzToSrgbMatrix, x/y, 1.0, (1-x-y)/y)
+	lr, lg, lb = math.Max(lr, 0), math.Max(lg, 0), math.Max(lb, 0)
+	m := math.Max(math.Max(lr, lg), lb)
+	return RGB{
+		R: unitToUint8(linearToSrgb(lr / m)),
+		G: unitToUint8(linearToSrgb(lg / m)),
+		B: unitToUint8(linearToSrgb(lb / m)),
+	}
+}
+
+// CCT computes the correlated color temperature of the XYZ value
+// The temperature is found with Robertson's method and the distance from
+// the Planckian locus with Ohno's (2013) polynomial approximation.
+// Returns:
+//   float64: correlated color temperature in kelvin, 0 if out of range
+//   float64: Duv, positive above the Planckian locus (greenish)
+// Example:
+//   c := IlluminantD65
+//   cct, duv := c.CCT() // returns about 6504, 0.0032
+func (c *XYZ) CCT() (float64, float64) {
+	d := c.X + 15*c.Y + 3*c.Z
+	if d == 0 {
+		return 0, 0
+	}
+	u := 4 * c.X / d
+	v := 6 * c.Y / d
+	return robertsonCct(u, v), ohnoDuv(u, v)
+}
+
+// CCT computes the correlated color temperature of the RGB color
+// Returns:
+//   float64: correlated color temperature in kelvin, 0 if out of range
+//   float64: Duv, positive above the Planckian locus (greenish)
+// Example:
+//   c := RGB{255,255,255} // white
+//   cct, duv := c.CCT() // returns about 6504, 0.0032
+func (c *RGB) CCT() (float64, float64) {
+	xyz := c.ToXyz()
+	return xyz.CCT()
+}
+
+// CCT computes the correlated color temperature of the HSV color
+// Returns:
+//   float64: correlated color temperature in kelvin, 0 if out of range
+//   float64: Duv, positive above the Planckian locus (greenish)
+// Example:
+//   c := HSV{30,20,100} // warm white
+//   cct, duv := c.CCT() // returns about 4907, 0.0003
+func (c *HSV) CCT() (float64, float64) {
+	xyz := c.ToXyz()
+	return xyz.CCT()
+}
+
+// kelvinToXy returns the CIE 1931 chromaticity of a white of temperature k
+func kelvinToXy(k float64) (float64, float64) {
+	t := math.Max(1667, math.Min(25000, k))
+	t2 := t * t
+	t3 := t2 * t
+
+	var x, y float64
+	switch {
+	case t < 4000:
+		// Kim et al. cubic spline approximation of the Planckian locus
+		x = -0.2661239e9/t3 - 0.2343589e6/t2 + 0.8776956e3/t + 0.179910
+		if t < 2222 {
+			y = -1.1063814*x*x*x - 1.34811020*x*x + 2.18555832*x - 0.20219683
+		} else {
+			y = -0.9549476*x*x*x - 1.37418593*x*x + 2.09137015*x - 0.16748867
+		}
+	default:
+		// CIE daylight locus
+		if t <= 7000 {
+			x = -4.6070e9/t3 + 2.9678e6/t2 + 0.09911e3/t + 0.244063
+		} else {
+			x = -2.0064e9/t3 + 1.9018e6/t2 + 0.24748e3/t + 0.237040
+		}
+		y = -3.0*x*x + 2.870*x - 0.275
+	}
+	return x, y
+}
+
+// robertsonCct interpolates between the isotemperature lines that bracket (u, v)
+func robertsonCct(u, v float64) float64 {
+	var dm float64
+	for i, line := range robertsonIsotherms {
+		di := (v - line[2]) - line[3]*(u-line[1])
+		if i > 0 && (di < 0) != (dm < 0) {
+			prev := robertsonIsotherms[i-1]
+			dm /= math.Sqrt(1 + prev[3]*prev[3])
+			di /= math.Sqrt(1 + line[3]*line[3])
+			p := dm / (dm - di)
+			mired := prev[0] + p*(line[0]-prev[0])
+			if mired == 0 {
+				return 0
+			}
+			return 1e6 / mired
+		}
+		dm = di
+	}
+	return 0
+}
+
+// ohnoDuv approximates the signed distance of (u, v) from the Planckian locus
+func ohnoDuv(u, v float64) float64 {
+	du := u - 0.292
+	dv := v - 0.24
+	lfp := math.Sqrt(du*du + dv*dv)
+	if lfp == 0 {
+		return 0
+	}
+	a := math.Acos(du / lfp)
+	lbb := -0.00616793*math.Pow(a, 6) + 0.0893944*math.Pow(a, 5) -
+		0.5179722*math.Pow(a, 4) + 1.5317403*math.Pow(a, 3) -
+		2.4243787*a*a + 1.925865*a - 0.471106
+	return lfp - lbb
+}
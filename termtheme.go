@@ -0,0 +1,533 @@
+package color
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TerminalTheme is a terminal emulator color scheme
+type TerminalTheme struct {
+	Name       string
+	Ansi       [16]RGB
+	Foreground RGB
+	Background RGB
+	Cursor     RGB
+	Selection  RGBA
+}
+
+// ansiColorNames are the names of the 8 basic ANSI colors in config files
+var ansiColorNames = [8]string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// AnsiTheme converts the terminal theme to a theme for AnsiToHTML and AnsiToSVG
+// Returns:
+//   AnsiTheme: palette, foreground and background of the theme
+func (t *TerminalTheme) AnsiTheme() AnsiTheme {
+	return AnsiTheme{Palette: t.Ansi, Foreground: t.Foreground, Background: t.Background}
+}
+
+// parseThemeColor parses "#rgb", "#rrggbb", "0xrrggbb" or any X11 color spec
+func parseThemeColor(s string) (RGB, error) {
+	s = strings.Trim(strings.TrimSpace(s), `"'`)
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		s = "#" + s[2:]
+	}
+	if strings.HasPrefix(s, "#") && (len(s) == 4 || len(s) == 7 || len(s) == 9) {
+		r, g, b, _, err := hexToRGBA(s)
+		if err != nil {
+			return RGB{}, err
+		}
+		return RGB{r, g, b}, nil
+	}
+	return ParseX11Color(s)
+}
+
+// ReadAlacrittyTheme reads the [colors.*] tables of an Alacritty TOML config
+// Parameters:
+//   r: reader with the TOML content
+// Returns:
+//   *TerminalTheme: parsed theme
+//   error: read error or invalid color value
+// Example:
+//   f, _ := os.Open("alacritty.toml")
+//   theme, err := ReadAlacrittyTheme(f)
+func ReadAlacrittyTheme(r io.Reader) (*TerminalTheme, error) {
+	t := &TerminalTheme{}
+	sc := bufio.NewScanner(r)
+	section := ""
+	for sc.Scan() {
+		line := stripTomlComment(sc.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			section = strings.Trim(line, "[] ")
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || !strings.HasPrefix(section, "colors.") {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		var dst *RGB
+		switch name := section + "." + key; name {
+		case "colors.primary.foreground":
+			dst = &t.Foreground
+		case "colors.primary.background":
+			dst = &t.Background
+		case "colors.cursor.cursor":
+			dst = &t.Cursor
+		case "colors.selection.background":
+			dst = &t.Selection.RGB
+		default:
+			for i, color := range ansiColorNames {
+				switch name {
+				case "colors.normal." + color:
+					dst = &t.Ansi[i]
+				case "colors.bright." + color:
+					dst = &t.Ansi[i+8]
+				}
+			}
+		}
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+		// other keys and the cell color keywords have no place in the theme
+		if dst == nil || value == "CellForeground" || value == "CellBackground" {
+			continue
+		}
+		c, err := parseThemeColor(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid alacritty color %s.%s: %w", section, key, err)
+		}
+		*dst = c
+		if dst == &t.Selection.RGB {
+			t.Selection.A = 1.0
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// stripTomlComment trims a line and cuts a # comment that is outside
+// single or double quoted strings
+func stripTomlComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return strings.TrimSpace(line[:i])
+		}
+	}
+	return strings.TrimSpace(line)
+}
+
+// WriteAlacrittyTheme writes the theme as Alacritty TOML color tables
+// Parameters:
+//   w: destination for the TOML content
+//   t: theme to write
+// Returns:
+//   error: write error
+func WriteAlacrittyTheme(w io.Writer, t *TerminalTheme) error {
+	var b strings.Builder
+	if t.Name != "" {
+		fmt.Fprintf(&b, "# %s\n", t.Name)
+	}
+	fmt.Fprintf(&b, "[colors.primary]\nforeground = %q\nbackground = %q\n\n", t.Foreground.ToHex(), t.Background.ToHex())
+	fmt.Fprintf(&b, "[colors.cursor]\ncursor = %q\n\n", t.Cursor.ToHex())
+	fmt.Fprintf(&b, "[colors.selection]\nbackground = %q\n", t.Selection.RGB.ToHex())
+	for i, table := range []string{"normal", "bright"} {
+		fmt.Fprintf(&b, "\n[colors.%s]\n", table)
+		for j, name := range ansiColorNames {
+			fmt.Fprintf(&b, "%s = %q\n", name, t.Ansi[i*8+j].ToHex())
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ReadKittyTheme reads the color settings of a kitty .conf file
+// Parameters:
+//   r: reader with the conf content
+// Returns:
+//   *TerminalTheme: parsed theme
+//   error: read error or invalid color value
+// Example:
+//   f, _ := os.Open("theme.conf")
+//   theme, err := ReadKittyTheme(f)
+func ReadKittyTheme(r io.Reader) (*TerminalTheme, error) {
+	t := &TerminalTheme{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		key := fields[0]
+		set := func(dst *RGB) error {
+			// "none" leaves the color to the terminal's default
+			if fields[1] == "none" {
+				return nil
+			}
+			c, err := parseThemeColor(fields[1])
+			if err != nil {
+				return fmt.Errorf("invalid kitty color %s: %w", key, err)
+			}
+			*dst = c
+			return nil
+		}
+		var err error
+		switch {
+		case key == "foreground":
+			err = set(&t.Foreground)
+		case key == "background":
+			err = set(&t.Background)
+		case key == "cursor":
+			err = set(&t.Cursor)
+		case key == "selection_background":
+			if err = set(&t.Selection.RGB); err == nil && fields[1] != "none" {
+				t.Selection.A = 1.0
+			}
+		case strings.HasPrefix(key, "color"):
+			i, convErr := strconv.Atoi(key[5:])
+			if convErr == nil && i >= 0 && i < 16 {
+				err = set(&t.Ansi[i])
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// WriteKittyTheme writes the theme as kitty .conf color settings
+// Parameters:
+//   w: destination for the conf content
+//   t: theme to write
+// Returns:
+//   error: write error
+func WriteKittyTheme(w io.Writer, t *TerminalTheme) error {
+	var b strings.Builder
+	if t.Name != "" {
+		fmt.Fprintf(&b, "# %s\n", t.Name)
+	}
+	fmt.Fprintf(&b, "foreground %s\nbackground %s\ncursor %s\nselection_background %s\n",
+		t.Foreground.ToHex(), t.Background.ToHex(), t.Cursor.ToHex(), t.Selection.RGB.ToHex())
+	for i, c := range t.Ansi {
+		fmt.Fprintf(&b, "color%d %s\n", i, c.ToHex())
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// windowsTerminalAnsiKeys are the Windows Terminal scheme keys of the 16 ANSI colors
+var windowsTerminalAnsiKeys = [16]string{
+	"black", "red", "green", "yellow", "blue", "purple", "cyan", "white",
+	"brightBlack", "brightRed", "brightGreen", "brightYellow",
+	"brightBlue", "brightPurple", "brightCyan", "brightWhite",
+}
+
+// ReadWindowsTerminalScheme reads a Windows Terminal JSON color scheme object
+// Parameters:
+//   r: reader with the JSON scheme
+// Returns:
+//   *TerminalTheme: parsed theme
+//   error: JSON syntax error or invalid color value
+// Example:
+//   theme, err := ReadWindowsTerminalScheme(strings.NewReader(`{"name":"Campbell",...}`))
+func ReadWindowsTerminalScheme(r io.Reader) (*TerminalTheme, error) {
+	var scheme map[string]string
+	if err := json.NewDecoder(r).Decode(&scheme); err != nil {
+		return nil, err
+	}
+	t := &TerminalTheme{Name: scheme["name"]}
+	targets := map[string]*RGB{
+		"foreground":          &t.Foreground,
+		"background":          &t.Background,
+		"cursorColor":         &t.Cursor,
+		"selectionBackground": &t.Selection.RGB,
+	}
+	for i, key := range windowsTerminalAnsiKeys {
+		targets[key] = &t.Ansi[i]
+	}
+	for key, dst := range targets {
+		value, ok := scheme[key]
+		if !ok {
+			continue
+		}
+		c, err := parseThemeColor(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid windows terminal color %s: %w", key, err)
+		}
+		*dst = c
+	}
+	if _, ok := scheme["selectionBackground"]; ok {
+		t.Selection.A = 1.0
+	}
+	return t, nil
+}
+
+// WriteWindowsTerminalScheme writes the theme as a Windows Terminal JSON scheme object
+// Parameters:
+//   w: destination for the JSON scheme
+//   t: theme to write
+// Returns:
+//   error: write error
+func WriteWindowsTerminalScheme(w io.Writer, t *TerminalTheme) error {
+	scheme := map[string]string{
+		"name":                t.Name,
+		"foreground":          strings.ToUpper(t.Foreground.ToHex()),
+		"background":          strings.ToUpper(t.Background.ToHex()),
+		"cursorColor":         strings.ToUpper(t.Cursor.ToHex()),
+		"selectionBackground": strings.ToUpper(t.Selection.RGB.ToHex()),
+	}
+	for i, key := range windowsTerminalAnsiKeys {
+		scheme[key] = strings.ToUpper(t.Ansi[i].ToHex())
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "    ")
+	return enc.Encode(scheme)
+}
+
+// iTerm2 color entry keys
+const (
+	itermForeground = "Foreground Color"
+	itermBackground = "Background Color"
+	itermCursor     = "Cursor Color"
+	itermSelection  = "Selection Color"
+)
+
+// ReadITerm2Colors reads an iTerm2 .itermcolors property list
+// Parameters:
+//   r: reader with the plist XML
+// Returns:
+//   *TerminalTheme: parsed theme
+//   error: XML syntax error or malformed color entry
+// Example:
+//   f, _ := os.Open("Solarized Dark.itermcolors")
+//   theme, err := ReadITerm2Colors(f)
+func ReadITerm2Colors(r io.Reader) (*TerminalTheme, error) {
+	entries, err := readPlistColorDicts(xml.NewDecoder(r))
+	if err != nil {
+		return nil, err
+	}
+	t := &TerminalTheme{}
+	for key, comps := range entries {
+		var ch [4]float64
+		ch[3] = 1.0
+		for i, name := range []string{"Red Component", "Green Component", "Blue Component", "Alpha Component"} {
+			if v, ok := comps[name]; ok {
+				f, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid iterm2 component %s of %s: %s", name, key, v)
+				}
+				ch[i] = f
+			}
+		}
+		c := RGBA{RGB{unitToUint8(ch[0]), unitToUint8(ch[1]), unitToUint8(ch[2])}, float32(ch[3])}
+		switch key {
+		case itermForeground:
+			t.Foreground = c.RGB
+		case itermBackground:
+			t.Background = c.RGB
+		case itermCursor:
+			t.Cursor = c.RGB
+		case itermSelection:
+			t.Selection = c
+		default:
+			var i int
+			if _, err := fmt.Sscanf(key, "Ansi %d Color", &i); err == nil && i >= 0 && i < 16 {
+				t.Ansi[i] = c.RGB
+			}
+		}
+	}
+	return t, nil
+}
+
+// readPlistColorDicts collects the <dict> values of the top level plist
+// dictionary as maps of component key to raw value text
+func readPlistColorDicts(dec *xml.Decoder) (map[string]map[string]string, error) {
+	entries := map[string]map[string]string{}
+	depth := 0
+	var outerKey, innerKey string
+	var text strings.Builder
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch el := tok.(type) {
+		case xml.StartElement:
+			text.Reset()
+			if el.Name.Local == "dict" {
+				depth++
+				if depth == 2 {
+					entries[outerKey] = map[string]string{}
+				}
+			}
+		case xml.CharData:
+			text.Write(el)
+		case xml.EndElement:
+			value := strings.TrimSpace(text.String())
+			switch {
+			case el.Name.Local == "dict":
+				depth--
+			case el.Name.Local == "key" && depth == 1:
+				outerKey = value
+			case el.Name.Local == "key" && depth == 2:
+				innerKey = value
+			case depth == 2:
+				entries[outerKey][innerKey] = value
+			}
+		}
+	}
+}
+
+// WriteITerm2Colors writes the theme as an iTerm2 .itermcolors property list
+// Parameters:
+//   w: destination for the plist XML
+//   t: theme to write
+// Returns:
+//   error: write error
+func WriteITerm2Colors(w io.Writer, t *TerminalTheme) error {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">` + "\n")
+	b.WriteString("<plist version=\"1.0\">\n<dict>\n")
+	entry := func(key string, c RGB, a float32) {
+		comp := func(v float64) string { return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64) }
+		fmt.Fprintf(&b, "\t<key>%s</key>\n\t<dict>\n", key)
+		fmt.Fprintf(&b, "\t\t<key>Alpha Component</key>\n\t\t<real>%s</real>\n", comp(float64(a)))
+		fmt.Fprintf(&b, "\t\t<key>Blue Component</key>\n\t\t<real>%s</real>\n", comp(float64(c.B)/255))
+		b.WriteString("\t\t<key>Color Space</key>\n\t\t<string>sRGB</string>\n")
+		fmt.Fprintf(&b, "\t\t<key>Green Component</key>\n\t\t<real>%s</real>\n", comp(float64(c.G)/255))
+		fmt.Fprintf(&b, "\t\t<key>Red Component</key>\n\t\t<real>%s</real>\n", comp(float64(c.R)/255))
+		b.WriteString("\t</dict>\n")
+	}
+	for i, c := range t.Ansi {
+		entry(fmt.Sprintf("Ansi %d Color", i), c, 1)
+	}
+	entry(itermBackground, t.Background, 1)
+	entry(itermCursor, t.Cursor, 1)
+	entry(itermForeground, t.Foreground, 1)
+	entry(itermSelection, t.Selection.RGB, t.Selection.A)
+	b.WriteString("</dict>\n</plist>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// ReadXresources reads terminal colors from an Xresources file
+// Resources are matched by their last component (foreground, background,
+// cursorColor, color0-color15) regardless of the class or instance prefix,
+// and simple "#define NAME value" macros are expanded.
+// Parameters:
+//   r: reader with the Xresources content
+// Returns:
+//   *TerminalTheme: parsed theme
+//   error: read error or invalid color value
+// Example:
+//   f, _ := os.Open(".Xresources")
+//   theme, err := ReadXresources(f)
+func ReadXresources(r io.Reader) (*TerminalTheme, error) {
+	t := &TerminalTheme{}
+	defines := map[string]string{}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "!") {
+			continue
+		}
+		if strings.HasPrefix(line, "#define") {
+			fields := strings.Fields(line)
+			if len(fields) >= 3 {
+				defines[fields[1]] = fields[2]
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if v, ok := defines[value]; ok {
+			value = v
+		}
+		if i := strings.LastIndexAny(name, ".*"); i >= 0 {
+			name = name[i+1:]
+		}
+		var dst *RGB
+		switch {
+		case name == "foreground":
+			dst = &t.Foreground
+		case name == "background":
+			dst = &t.Background
+		case name == "cursorColor":
+			dst = &t.Cursor
+		case name == "highlightColor":
+			dst = &t.Selection.RGB
+			t.Selection.A = 1.0
+		case strings.HasPrefix(name, "color"):
+			if i, err := strconv.Atoi(name[5:]); err == nil && i >= 0 && i < 16 {
+				dst = &t.Ansi[i]
+			}
+		}
+		if dst == nil {
+			continue
+		}
+		c, err := parseThemeColor(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid xresources color %s: %w", name, err)
+		}
+		*dst = c
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// WriteXresources writes the theme as "*." wildcard Xresources
+// Parameters:
+//   w: destination for the Xresources content
+//   t: theme to write
+// Returns:
+//   error: write error
+func WriteXresources(w io.Writer, t *TerminalTheme) error {
+	var b strings.Builder
+	if t.Name != "" {
+		fmt.Fprintf(&b, "! %s\n", t.Name)
+	}
+	fmt.Fprintf(&b, "*.foreground: %s\n*.background: %s\n*.cursorColor: %s\n*.highlightColor: %s\n",
+		t.Foreground.ToHex(), t.Background.ToHex(), t.Cursor.ToHex(), t.Selection.RGB.ToHex())
+	for i, c := range t.Ansi {
+		fmt.Fprintf(&b, "*.color%d: %s\n", i, c.ToHex())
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
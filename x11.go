@@ -0,0 +1,373 @@
+package color
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// X11Notation selects the output syntax of RGB.ToX11
+type X11Notation int
+
+const (
+	X11Hex    X11Notation = iota // #rrggbb
+	X11Hex12                     // #rrrgggbbb
+	X11Hex16                     // #rrrrggggbbbb
+	X11Rgb                       // rgb:rr/gg/bb
+	X11Rgb16                     // rgb:rrrr/gggg/bbbb
+	X11Rgbi                      // rgbi:r/g/b
+	X11CIEXYZ                    // CIEXYZ:X/Y/Z
+	X11CIExyY                    // CIExyY:x/y/Y
+	X11CIEuvY                    // CIEuvY:u/v/Y
+	X11CIELab                    // CIELab:L/a/b
+	X11CIELuv                    // CIELuv:L/u/v
+	X11TekHVC                    // TekHVC:H/V/C
+)
+
+// ColorNames is a case and space insensitive name to RGB registry in the
+// style of the X11 rgb.txt database
+type ColorNames struct {
+	colors map[string]RGB
+	names  []string
+}
+
+// X11ColorNames holds the built-in X.Org rgb.txt database
+var X11ColorNames = func() *ColorNames {
+	n := NewColorNames()
+	for _, e := range x11ColorTable {
+		n.Add(e.name, e.rgb)
+	}
+	return n
+}()
+
+// NewColorNames creates an empty name registry
+// Returns:
+//   *ColorNames: empty registry
+// Example:
+//   names := NewColorNames()
+//   names.Add("Brand Blue", RGB{0,82,204})
+func NewColorNames() *ColorNames {
+	return &ColorNames{colors: map[string]RGB{}}
+}
+
+// LoadRgbTxt reads an rgb.txt file into a name registry
+// Lines have the form "r g b<tab>name"; empty lines and lines starting
+// with "!" or "#" are skipped.
+// Parameters:
+//   r: reader with the rgb.txt content
+// Returns:
+//   *ColorNames: registry with every name in the file
+//   error: read error or malformed line
+// Example:
+//   f, _ := os.Open("/usr/share/X11/rgb.txt")
+//   names, err := LoadRgbTxt(f)
+func LoadRgbTxt(r io.Reader) (*ColorNames, error) {
+	n := NewColorNames()
+	sc := bufio.NewScanner(r)
+	line := 0
+	for sc.Scan() {
+		line++
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || text[0] == '!' || text[0] == '#' {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("invalid rgb.txt line %d: %s", line, text)
+		}
+		var ch [3]uint8
+		for i := 0; i < 3; i++ {
+			v, err := strconv.ParseUint(fields[i], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid rgb.txt line %d: %s", line, text)
+			}
+			ch[i] = uint8(v)
+		}
+		n.Add(strings.Join(fields[3:], " "), RGB{ch[0], ch[1], ch[2]})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// normalizeColorName folds case and drops spaces the way X11 name lookups do
+func normalizeColorName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, " ", ""))
+}
+
+// Add registers a name, replacing the color of an existing equivalent name
+// Parameters:
+//   name: color name, matched case and space insensitively
+//   c: color of the name
+func (n *ColorNames) Add(name string, c RGB) {
+	key := normalizeColorName(name)
+	if _, ok := n.colors[key]; !ok {
+		n.names = append(n.names, name)
+	}
+	n.colors[key] = c
+}
+
+// Lookup finds the color of a name
+// Parameters:
+//   name: color name, matched case and space insensitively
+// Returns:
+//   RGB: color of the name
+//   bool: false if the name is unknown
+// Example:
+//   c, ok := X11ColorNames.Lookup("dark slate gray 4") // returns RGB{82,139,139}, true
+func (n *ColorNames) Lookup(name string) (RGB, bool) {
+	c, ok := n.colors[normalizeColorName(name)]
+	return c, ok
+}
+
+// Name finds the first registered name of a color
+// Parameters:
+//   c: color to look up
+// Returns:
+//   string: name in its registered spelling
+//   bool: false if no name has exactly this color
+// Example:
+//   name, ok := X11ColorNames.Name(RGB{255,250,250}) // returns "snow", true
+func (n *ColorNames) Name(c RGB) (string, bool) {
+	for _, name := range n.names {
+		if n.colors[normalizeColorName(name)] == c {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Names lists the registered names in registration order
+// Returns:
+//   []string: names in their registered spelling
+func (n *ColorNames) Names() []string {
+	return append([]string(nil), n.names...)
+}
+
+// ParseX11Color parses any XParseColor notation, resolving names against
+// the built-in X11 database
+// Parameters:
+//   spec: "#rgb" to "#rrrrggggbbbb", "rgb:", "rgbi:", "CIEXYZ:", "CIEuvY:",
+//         "CIExyY:", "CIELab:", "CIELuv:", "TekHVC:" or a color name
+// Returns:
+//   RGB: parsed color, clipped to the sRGB gamut
+//   error: error if the spec is invalid or the name is unknown
+// Example:
+//   c, err := ParseX11Color("rgbi:1.0/0.5/0") // returns RGB{255,128,0}
+func ParseX11Color(spec string) (RGB, error) {
+	return X11ColorNames.Parse(spec)
+}
+
+// Parse parses any XParseColor notation, resolving names against the registry
+// Parameters:
+//   spec: X11 color specification, see ParseX11Color
+// Returns:
+//   RGB: parsed color, clipped to the sRGB gamut
+//   error: error if the spec is invalid or the name is unknown
+// Example:
+//   c, err := names.Parse("DarkSlateGray4")
+func (n *ColorNames) Parse(spec string) (RGB, error) {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "#") {
+		return parseX11Hex(spec)
+	}
+	prefix, body, ok := strings.Cut(spec, ":")
+	if !ok {
+		if c, ok := n.Lookup(spec); ok {
+			return c, nil
+		}
+		return RGB{}, fmt.Errorf("unknown color name: %s", spec)
+	}
+	prefix = strings.ToLower(prefix)
+	if prefix == "rgb" || prefix == "rgba" {
+		return parseRgbSpec(prefix + ":" + body)
+	}
+	parts := strings.Split(body, "/")
+	if len(parts) != 3 {
+		return RGB{}, fmt.Errorf("invalid x11 color spec: %s", spec)
+	}
+	var v [3]float64
+	for i, p := range parts {
+		f, err := strconv.ParseFloat(p, 64)
+		if err != nil {
+			return RGB{}, fmt.Errorf("invalid x11 color spec: %s", spec)
+		}
+		v[i] = f
+	}
+	var x, y, z float64
+	switch prefix {
+	case "rgbi":
+		for _, f := range v {
+			if f < 0 || f > 1 {
+				return RGB{}, fmt.Errorf("invalid x11 color spec: %s", spec)
+			}
+		}
+		return RGB{unitToUint8(v[0]), unitToUint8(v[1]), unitToUint8(v[2])}, nil
+	case "ciexyz":
+		x, y, z = v[0], v[1], v[2]
+	case "ciexyy":
+		if v[1] == 0 {
+			return RGB{}, nil
+		}
+		x, y, z = v[0]*v[2]/v[1], v[2], (1-v[0]-v[1])*v[2]/v[1]
+	case "cieuvy":
+		x, y, z = uvYToXyz(v[0], v[1], v[2])
+	case "cielab":
+		x, y, z = labToXyz(v[0], v[1], v[2], IlluminantD65)
+	case "cieluv":
+		x, y, z = luvToXyz(v[0], v[1], v[2], IlluminantD65)
+	case "tekhvc":
+		x, y, z = tekHvcToXyz(v[0], v[1], v[2], IlluminantD65)
+	default:
+		return RGB{}, fmt.Errorf("unsupported x11 color space: %s", spec)
+	}
+	r, g, b := xyzToRgb(x, y, z)
+	return RGB{r, g, b}, nil
+}
+
+// parseX11Hex parses the "#" notation, whose digits are the most
+// significant bits of each 16-bit channel rather than a scaled value
+func parseX11Hex(spec string) (RGB, error) {
+	digits := spec[1:]
+	if len(digits) == 0 || len(digits)%3 != 0 || len(digits) > 12 {
+		return RGB{}, fmt.Errorf("invalid x11 color spec: %s", spec)
+	}
+	n := len(digits) / 3
+	var ch [3]uint8
+	for i := 0; i < 3; i++ {
+		v, err := strconv.ParseUint(digits[i*n:(i+1)*n], 16, 16)
+		if err != nil {
+			return RGB{}, fmt.Errorf("invalid x11 color spec: %s", spec)
+		}
+		ch[i] = uint8((v << (16 - 4*n)) >> 8)
+	}
+	return RGB{ch[0], ch[1], ch[2]}, nil
+}
+
+// ToX11 formats the RGB color in an XParseColor notation
+// Parameters:
+//   notation: output syntax
+// Returns:
+//   string: X11 color specification
+// Example:
+//   c := RGB{255,128,0}
+//   c.ToX11(X11Rgb)   // returns "rgb:ff/80/00"
+//   c.ToX11(X11Hex12) // returns "#fff808000"
+func (c *RGB) ToX11(notation X11Notation) string {
+	to12 := func(v uint8) uint32 { return (uint32(v)*4095 + 127) / 255 }
+	to16 := func(v uint8) uint32 { return uint32(v) * 257 }
+	format := func(prefix string, a, b, c float64) string {
+		f := func(v float64) string { return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64) }
+		return prefix + ":" + f(a) + "/" + f(b) + "/" + f(c)
+	}
+	x, y, z := rgbToXyz(c.R, c.G, c.B)
+	switch notation {
+	case X11Hex12:
+		return fmt.Sprintf("#%03x%03x%03x", to12(c.R), to12(c.G), to12(c.B))
+	case X11Hex16:
+		return fmt.Sprintf("#%04x%04x%04x", to16(c.R), to16(c.G), to16(c.B))
+	case X11Rgb:
+		return formatOscRgb(*c)
+	case X11Rgb16:
+		return fmt.Sprintf("rgb:%04x/%04x/%04x", to16(c.R), to16(c.G), to16(c.B))
+	case X11Rgbi:
+		return format("rgbi", float64(c.R)/255, float64(c.G)/255, float64(c.B)/255)
+	case X11CIEXYZ:
+		return format("CIEXYZ", x, y, z)
+	case X11CIExyY:
+		if s := x + y + z; s != 0 {
+			return format("CIExyY", x/s, y/s, y)
+		}
+		return format("CIExyY", 0, 0, 0)
+	case X11CIEuvY:
+		u, v := xyzToUv(x, y, z)
+		return format("CIEuvY", u, v, y)
+	case X11CIELab:
+		l, a, b := xyzToLab(x, y, z, IlluminantD65)
+		return format("CIELab", l, a, b)
+	case X11CIELuv:
+		l, u, v := xyzToLuv(x, y, z, IlluminantD65)
+		return format("CIELuv", l, u, v)
+	case X11TekHVC:
+		h, v, cv := xyzToTekHvc(x, y, z, IlluminantD65)
+		return format("TekHVC", h, v, cv)
+	}
+	return c.ToHex()
+}
+
+// xyzToUv returns the CIE 1976 u'v' chromaticity of an XYZ value
+func xyzToUv(x, y, z float64) (float64, float64) {
+	d := x + 15*y + 3*z
+	if d == 0 {
+		return 0, 0
+	}
+	return 4 * x / d, 9 * y / d
+}
+
+func uvYToXyz(u, v, y float64) (float64, float64, float64) {
+	if v == 0 {
+		return 0, 0, 0
+	}
+	return y * 9 * u / (4 * v), y, y * (12 - 3*u - 20*v) / (4 * v)
+}
+
+// xyzToLuv converts XYZ to CIE L*u*v* relative to the given white
+func xyzToLuv(x, y, z float64, white XYZ) (float64, float64, float64) {
+	l := 116*labF(y/white.Y) - 16
+	u, v := xyzToUv(x, y, z)
+	uw, vw := xyzToUv(white.X, white.Y, white.Z)
+	if x+15*y+3*z == 0 {
+		return l, 0, 0
+	}
+	return l, 13 * l * (u - uw), 13 * l * (v - vw)
+}
+
+// luvToXyz converts CIE L*u*v* relative to the given white back to XYZ
+func luvToXyz(l, u, v float64, white XYZ) (float64, float64, float64) {
+	if l <= 0 {
+		return 0, 0, 0
+	}
+	uw, vw := xyzToUv(white.X, white.Y, white.Z)
+	y := white.Y * labFInv((l+16)/116)
+	return uvYToXyz(u/(13*l)+uw, v/(13*l)+vw, y)
+}
+
+// Tektronix HVC constants from the Xcms implementation
+const (
+	tekUBestRed      = 0.7127
+	tekVBestRed      = 0.4931
+	tekChromaScaling = 7.50725
+)
+
+func xyzToTekHvc(x, y, z float64, white XYZ) (float64, float64, float64) {
+	u, v := xyzToUv(x, y, z)
+	uw, vw := xyzToUv(white.X, white.Y, white.Z)
+	offset := math.Atan2(tekVBestRed-vw, tekUBestRed-uw) * 180 / math.Pi
+	value := 116*labF(y/white.Y) - 16
+	du, dv := u-uw, v-vw
+	if x+15*y+3*z == 0 {
+		du, dv = 0, 0
+	}
+	chroma := tekChromaScaling * value * math.Sqrt(du*du+dv*dv)
+	hue := 0.0
+	if chroma > 0 {
+		hue = math.Mod(math.Atan2(dv, du)*180/math.Pi-offset+720, 360)
+	}
+	return hue, value, chroma
+}
+
+func tekHvcToXyz(h, value, chroma float64, white XYZ) (float64, float64, float64) {
+	if value <= 0 {
+		return 0, 0, 0
+	}
+	uw, vw := xyzToUv(white.X, white.Y, white.Z)
+	offset := math.Atan2(tekVBestRed-vw, tekUBestRed-uw) * 180 / math.Pi
+	rad := (h + offset) * math.Pi / 180
+	d := chroma / (value * tekChromaScaling)
+	y := white.Y * labFInv((value+16)/116)
+	return uvYToXyz(uw+d*math.Cos(rad), vw+d*math.Sin(rad), y)
+}
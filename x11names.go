@@ -0,0 +1,761 @@
+package color
+
+// x11ColorTable is the X.Org rgb.txt color name database
+var x11ColorTable = []struct {
+	name string
+	rgb  RGB
+}{
+	{"snow", RGB{255, 250, 250}},
+	{"ghost white", RGB{248, 248, 255}},
+	{"GhostWhite", RGB{248, 248, 255}},
+	{"white smoke", RGB{245, 245, 245}},
+	{"WhiteSmoke", RGB{245, 245, 245}},
+	{"gainsboro", RGB{220, 220, 220}},
+	{"floral white", RGB{255, 250, 240}},
+	{"FloralWhite", RGB{255, 250, 240}},
+	{"old lace", RGB{253, 245, 230}},
+	{"OldLace", RGB{253, 245, 230}},
+	{"linen", RGB{250, 240, 230}},
+	{"antique white", RGB{250, 235, 215}},
+	{"AntiqueWhite", RGB{250, 235, 215}},
+	{"papaya whip", RGB{255, 239, 213}},
+	{"PapayaWhip", RGB{255, 239, 213}},
+	{"blanched almond", RGB{255, 235, 205}},
+	{"BlanchedAlmond", RGB{255, 235, 205}},
+	{"bisque", RGB{255, 228, 196}},
+	{"peach puff", RGB{255, 218, 185}},
+	{"PeachPuff", RGB{255, 218, 185}},
+	{"navajo white", RGB{255, 222, 173}},
+	{"NavajoWhite", RGB{255, 222, 173}},
+	{"moccasin", RGB{255, 228, 181}},
+	{"cornsilk", RGB{255, 248, 220}},
+	{"ivory", RGB{255, 255, 240}},
+	{"lemon chiffon", RGB{255, 250, 205}},
+	{"LemonChiffon", RGB{255, 250, 205}},
+	{"seashell", RGB{255, 245, 238}},
+	{"honeydew", RGB{240, 255, 240}},
+	{"mint cream", RGB{245, 255, 250}},
+	{"MintCream", RGB{245, 255, 250}},
+	{"azure", RGB{240, 255, 255}},
+	{"alice blue", RGB{240, 248, 255}},
+	{"AliceBlue", RGB{240, 248, 255}},
+	{"lavender", RGB{230, 230, 250}},
+	{"lavender blush", RGB{255, 240, 245}},
+	{"LavenderBlush", RGB{255, 240, 245}},
+	{"misty rose", RGB{255, 228, 225}},
+	{"MistyRose", RGB{255, 228, 225}},
+	{"white", RGB{255, 255, 255}},
+	{"black", RGB{0, 0, 0}},
+	{"dark slate gray", RGB{47, 79, 79}},
+	{"DarkSlateGray", RGB{47, 79, 79}},
+	{"dark slate grey", RGB{47, 79, 79}},
+	{"DarkSlateGrey", RGB{47, 79, 79}},
+	{"dim gray", RGB{105, 105, 105}},
+	{"DimGray", RGB{105, 105, 105}},
+	{"dim grey", RGB{105, 105, 105}},
+	{"DimGrey", RGB{105, 105, 105}},
+	{"slate gray", RGB{112, 128, 144}},
+	{"SlateGray", RGB{112, 128, 144}},
+	{"slate grey", RGB{112, 128, 144}},
+	{"SlateGrey", RGB{112, 128, 144}},
+	{"light slate gray", RGB{119, 136, 153}},
+	{"LightSlateGray", RGB{119, 136, 153}},
+	{"light slate grey", RGB{119, 136, 153}},
+	{"LightSlateGrey", RGB{119, 136, 153}},
+	{"gray", RGB{190, 190, 190}},
+	{"grey", RGB{190, 190, 190}},
+	{"light grey", RGB{211, 211, 211}},
+	{"LightGrey", RGB{211, 211, 211}},
+	{"light gray", RGB{211, 211, 211}},
+	{"LightGray", RGB{211, 211, 211}},
+	{"midnight blue", RGB{25, 25, 112}},
+	{"MidnightBlue", RGB{25, 25, 112}},
+	{"navy", RGB{0, 0, 128}},
+	{"navy blue", RGB{0, 0, 128}},
+	{"NavyBlue", RGB{0, 0, 128}},
+	{"cornflower blue", RGB{100, 149, 237}},
+	{"CornflowerBlue", RGB{100, 149, 237}},
+	{"dark slate blue", RGB{72, 61, 139}},
+	{"DarkSlateBlue", RGB{72, 61, 139}},
+	{"slate blue", RGB{106, 90, 205}},
+	{"SlateBlue", RGB{106, 90, 205}},
+	{"medium slate blue", RGB{123, 104, 238}},
+	{"MediumSlateBlue", RGB{123, 104, 238}},
+	{"light slate blue", RGB{132, 112, 255}},
+	{"LightSlateBlue", RGB{132, 112, 255}},
+	{"medium blue", RGB{0, 0, 205}},
+	{"MediumBlue", RGB{0, 0, 205}},
+	{"royal blue", RGB{65, 105, 225}},
+	{"RoyalBlue", RGB{65, 105, 225}},
+	{"blue", RGB{0, 0, 255}},
+	{"dodger blue", RGB{30, 144, 255}},
+	{"DodgerBlue", RGB{30, 144, 255}},
+	{"deep sky blue", RGB{0, 191, 255}},
+	{"DeepSkyBlue", RGB{0, 191, 255}},
+	{"sky blue", RGB{135, 206, 235}},
+	{"SkyBlue", RGB{135, 206, 235}},
+	{"light sky blue", RGB{135, 206, 250}},
+	{"LightSkyBlue", RGB{135, 206, 250}},
+	{"steel blue", RGB{70, 130, 180}},
+	{"SteelBlue", RGB{70, 130, 180}},
+	{"light steel blue", RGB{176, 196, 222}},
+	{"LightSteelBlue", RGB{176, 196, 222}},
+	{"light blue", RGB{173, 216, 230}},
+	{"LightBlue", RGB{173, 216, 230}},
+	{"powder blue", RGB{176, 224, 230}},
+	{"PowderBlue", RGB{176, 224, 230}},
+	{"pale turquoise", RGB{175, 238, 238}},
+	{"PaleTurquoise", RGB{175, 238, 238}},
+	{"dark turquoise", RGB{0, 206, 209}},
+	{"DarkTurquoise", RGB{0, 206, 209}},
+	{"medium turquoise", RGB{72, 209, 204}},
+	{"MediumTurquoise", RGB{72, 209, 204}},
+	{"turquoise", RGB{64, 224, 208}},
+	{"cyan", RGB{0, 255, 255}},
+	{"light cyan", RGB{224, 255, 255}},
+	{"LightCyan", RGB{224, 255, 255}},
+	{"cadet blue", RGB{95, 158, 160}},
+	{"CadetBlue", RGB{95, 158, 160}},
+	{"medium aquamarine", RGB{102, 205, 170}},
+	{"MediumAquamarine", RGB{102, 205, 170}},
+	{"aquamarine", RGB{127, 255, 212}},
+	{"dark green", RGB{0, 100, 0}},
+	{"DarkGreen", RGB{0, 100, 0}},
+	{"dark olive green", RGB{85, 107, 47}},
+	{"DarkOliveGreen", RGB{85, 107, 47}},
+	{"dark sea green", RGB{143, 188, 143}},
+	{"DarkSeaGreen", RGB{143, 188, 143}},
+	{"sea green", RGB{46, 139, 87}},
+	{"SeaGreen", RGB{46, 139, 87}},
+	{"medium sea green", RGB{60, 179, 113}},
+	{"MediumSeaGreen", RGB{60, 179, 113}},
+	{"light sea green", RGB{32, 178, 170}},
+	{"LightSeaGreen", RGB{32, 178, 170}},
+	{"pale green", RGB{152, 251, 152}},
+	{"PaleGreen", RGB{152, 251, 152}},
+	{"spring green", RGB{0, 255, 127}},
+	{"SpringGreen", RGB{0, 255, 127}},
+	{"lawn green", RGB{124, 252, 0}},
+	{"LawnGreen", RGB{124, 252, 0}},
+	{"green", RGB{0, 255, 0}},
+	{"chartreuse", RGB{127, 255, 0}},
+	{"medium spring green", RGB{0, 250, 154}},
+	{"MediumSpringGreen", RGB{0, 250, 154}},
+	{"green yellow", RGB{173, 255, 47}},
+	{"GreenYellow", RGB{173, 255, 47}},
+	{"lime green", RGB{50, 205, 50}},
+	{"LimeGreen", RGB{50, 205, 50}},
+	{"yellow green", RGB{154, 205, 50}},
+	{"YellowGreen", RGB{154, 205, 50}},
+	{"forest green", RGB{34, 139, 34}},
+	{"ForestGreen", RGB{34, 139, 34}},
+	{"olive drab", RGB{107, 142, 35}},
+	{"OliveDrab", RGB{107, 142, 35}},
+	{"dark khaki", RGB{189, 183, 107}},
+	{"DarkKhaki", RGB{189, 183, 107}},
+	{"khaki", RGB{240, 230, 140}},
+	{"pale goldenrod", RGB{238, 232, 170}},
+	{"PaleGoldenrod", RGB{238, 232, 170}},
+	{"light goldenrod yellow", RGB{250, 250, 210}},
+	{"LightGoldenrodYellow", RGB{250, 250, 210}},
+	{"light yellow", RGB{255, 255, 224}},
+	{"LightYellow", RGB{255, 255, 224}},
+	{"yellow", RGB{255, 255, 0}},
+	{"gold", RGB{255, 215, 0}},
+	{"light goldenrod", RGB{238, 221, 130}},
+	{"LightGoldenrod", RGB{238, 221, 130}},
+	{"goldenrod", RGB{218, 165, 32}},
+	{"dark goldenrod", RGB{184, 134, 11}},
+	{"DarkGoldenrod", RGB{184, 134, 11}},
+	{"rosy brown", RGB{188, 143, 143}},
+	{"RosyBrown", RGB{188, 143, 143}},
+	{"indian red", RGB{205, 92, 92}},
+	{"IndianRed", RGB{205, 92, 92}},
+	{"saddle brown", RGB{139, 69, 19}},
+	{"SaddleBrown", RGB{139, 69, 19}},
+	{"sienna", RGB{160, 82, 45}},
+	{"peru", RGB{205, 133, 63}},
+	{"burlywood", RGB{222, 184, 135}},
+	{"beige", RGB{245, 245, 220}},
+	{"wheat", RGB{245, 222, 179}},
+	{"sandy brown", RGB{244, 164, 96}},
+	{"SandyBrown", RGB{244, 164, 96}},
+	{"tan", RGB{210, 180, 140}},
+	{"chocolate", RGB{210, 105, 30}},
+	{"firebrick", RGB{178, 34, 34}},
+	{"brown", RGB{165, 42, 42}},
+	{"dark salmon", RGB{233, 150, 122}},
+	{"DarkSalmon", RGB{233, 150, 122}},
+	{"salmon", RGB{250, 128, 114}},
+	{"light salmon", RGB{255, 160, 122}},
+	{"LightSalmon", RGB{255, 160, 122}},
+	{"orange", RGB{255, 165, 0}},
+	{"dark orange", RGB{255, 140, 0}},
+	{"DarkOrange", RGB{255, 140, 0}},
+	{"coral", RGB{255, 127, 80}},
+	{"light coral", RGB{240, 128, 128}},
+	{"LightCoral", RGB{240, 128, 128}},
+	{"tomato", RGB{255, 99, 71}},
+	{"orange red", RGB{255, 69, 0}},
+	{"OrangeRed", RGB{255, 69, 0}},
+	{"red", RGB{255, 0, 0}},
+	{"hot pink", RGB{255, 105, 180}},
+	{"HotPink", RGB{255, 105, 180}},
+	{"deep pink", RGB{255, 20, 147}},
+	{"DeepPink", RGB{255, 20, 147}},
+	{"pink", RGB{255, 192, 203}},
+	{"light pink", RGB{255, 182, 193}},
+	{"LightPink", RGB{255, 182, 193}},
+	{"pale violet red", RGB{219, 112, 147}},
+	{"PaleVioletRed", RGB{219, 112, 147}},
+	{"maroon", RGB{176, 48, 96}},
+	{"medium violet red", RGB{199, 21, 133}},
+	{"MediumVioletRed", RGB{199, 21, 133}},
+	{"violet red", RGB{208, 32, 144}},
+	{"VioletRed", RGB{208, 32, 144}},
+	{"magenta", RGB{255, 0, 255}},
+	{"violet", RGB{238, 130, 238}},
+	{"plum", RGB{221, 160, 221}},
+	{"orchid", RGB{218, 112, 214}},
+	{"medium orchid", RGB{186, 85, 211}},
+	{"MediumOrchid", RGB{186, 85, 211}},
+	{"dark orchid", RGB{153, 50, 204}},
+	{"DarkOrchid", RGB{153, 50, 204}},
+	{"dark violet", RGB{148, 0, 211}},
+	{"DarkViolet", RGB{148, 0, 211}},
+	{"blue violet", RGB{138, 43, 226}},
+	{"BlueViolet", RGB{138, 43, 226}},
+	{"purple", RGB{160, 32, 240}},
+	{"medium purple", RGB{147, 112, 219}},
+	{"MediumPurple", RGB{147, 112, 219}},
+	{"thistle", RGB{216, 191, 216}},
+	{"snow1", RGB{255, 250, 250}},
+	{"snow2", RGB{238, 233, 233}},
+	{"snow3", RGB{205, 201, 201}},
+	{"snow4", RGB{139, 137, 137}},
+	{"seashell1", RGB{255, 245, 238}},
+	{"seashell2", RGB{238, 229, 222}},
+	{"seashell3", RGB{205, 197, 191}},
+	{"seashell4", RGB{139, 134, 130}},
+	{"AntiqueWhite1", RGB{255, 239, 219}},
+	{"AntiqueWhite2", RGB{238, 223, 204}},
+	{"AntiqueWhite3", RGB{205, 192, 176}},
+	{"AntiqueWhite4", RGB{139, 131, 120}},
+	{"bisque1", RGB{255, 228, 196}},
+	{"bisque2", RGB{238, 213, 183}},
+	{"bisque3", RGB{205, 183, 158}},
+	{"bisque4", RGB{139, 125, 107}},
+	{"PeachPuff1", RGB{255, 218, 185}},
+	{"PeachPuff2", RGB{238, 203, 173}},
+	{"PeachPuff3", RGB{205, 175, 149}},
+	{"PeachPuff4", RGB{139, 119, 101}},
+	{"NavajoWhite1", RGB{255, 222, 173}},
+	{"NavajoWhite2", RGB{238, 207, 161}},
+	{"NavajoWhite3", RGB{205, 179, 139}},
+	{"NavajoWhite4", RGB{139, 121, 94}},
+	{"LemonChiffon1", RGB{255, 250, 205}},
+	{"LemonChiffon2", RGB{238, 233, 191}},
+	{"LemonChiffon3", RGB{205, 201, 165}},
+	{"LemonChiffon4", RGB{139, 137, 112}},
+	{"cornsilk1", RGB{255, 248, 220}},
+	{"cornsilk2", RGB{238, 232, 205}},
+	{"cornsilk3", RGB{205, 200, 177}},
+	{"cornsilk4", RGB{139, 136, 120}},
+	{"ivory1", RGB{255, 255, 240}},
+	{"ivory2", RGB{238, 238, 224}},
+	{"ivory3", RGB{205, 205, 193}},
+	{"ivory4", RGB{139, 139, 131}},
+	{"honeydew1", RGB{240, 255, 240}},
+	{"honeydew2", RGB{224, 238, 224}},
+	{"honeydew3", RGB{193, 205, 193}},
+	{"honeydew4", RGB{131, 139, 131}},
+	{"LavenderBlush1", RGB{255, 240, 245}},
+	{"LavenderBlush2", RGB{238, 224, 229}},
+	{"LavenderBlush3", RGB{205, 193, 197}},
+	{"LavenderBlush4", RGB{139, 131, 134}},
+	{"MistyRose1", RGB{255, 228, 225}},
+	{"MistyRose2", RGB{238, 213, 210}},
+	{"MistyRose3", RGB{205, 183, 181}},
+	{"MistyRose4", RGB{139, 125, 123}},
+	{"azure1", RGB{240, 255, 255}},
+	{"azure2", RGB{224, 238, 238}},
+	{"azure3", RGB{193, 205, 205}},
+	{"azure4", RGB{131, 139, 139}},
+	{"SlateBlue1", RGB{131, 111, 255}},
+	{"SlateBlue2", RGB{122, 103, 238}},
+	{"SlateBlue3", RGB{105, 89, 205}},
+	{"SlateBlue4", RGB{71, 60, 139}},
+	{"RoyalBlue1", RGB{72, 118, 255}},
+	{"RoyalBlue2", RGB{67, 110, 238}},
+	{"RoyalBlue3", RGB{58, 95, 205}},
+	{"RoyalBlue4", RGB{39, 64, 139}},
+	{"blue1", RGB{0, 0, 255}},
+	{"blue2", RGB{0, 0, 238}},
+	{"blue3", RGB{0, 0, 205}},
+	{"blue4", RGB{0, 0, 139}},
+	{"DodgerBlue1", RGB{30, 144, 255}},
+	{"DodgerBlue2", RGB{28, 134, 238}},
+	{"DodgerBlue3", RGB{24, 116, 205}},
+	{"DodgerBlue4", RGB{16, 78, 139}},
+	{"SteelBlue1", RGB{99, 184, 255}},
+	{"SteelBlue2", RGB{92, 172, 238}},
+	{"SteelBlue3", RGB{79, 148, 205}},
+	{"SteelBlue4", RGB{54, 100, 139}},
+	{"DeepSkyBlue1", RGB{0, 191, 255}},
+	{"DeepSkyBlue2", RGB{0, 178, 238}},
+	{"DeepSkyBlue3", RGB{0, 154, 205}},
+	{"DeepSkyBlue4", RGB{0, 104, 139}},
+	{"SkyBlue1", RGB{135, 206, 255}},
+	{"SkyBlue2", RGB{126, 192, 238}},
+	{"SkyBlue3", RGB{108, 166, 205}},
+	{"SkyBlue4", RGB{74, 112, 139}},
+	{"LightSkyBlue1", RGB{176, 226, 255}},
+	{"LightSkyBlue2", RGB{164, 211, 238}},
+	{"LightSkyBlue3", RGB{141, 182, 205}},
+	{"LightSkyBlue4", RGB{96, 123, 139}},
+	{"SlateGray1", RGB{198, 226, 255}},
+	{"SlateGray2", RGB{185, 211, 238}},
+	{"SlateGray3", RGB{159, 182, 205}},
+	{"SlateGray4", RGB{108, 123, 139}},
+	{"LightSteelBlue1", RGB{202, 225, 255}},
+	{"LightSteelBlue2", RGB{188, 210, 238}},
+	{"LightSteelBlue3", RGB{162, 181, 205}},
+	{"LightSteelBlue4", RGB{110, 123, 139}},
+	{"LightBlue1", RGB{191, 239, 255}},
+	{"LightBlue2", RGB{178, 223, 238}},
+	{"LightBlue3", RGB{154, 192, 205}},
+	{"LightBlue4", RGB{104, 131, 139}},
+	{"LightCyan1", RGB{224, 255, 255}},
+	{"LightCyan2", RGB{209, 238, 238}},
+	{"LightCyan3", RGB{180, 205, 205}},
+	{"LightCyan4", RGB{122, 139, 139}},
+	{"PaleTurquoise1", RGB{187, 255, 255}},
+	{"PaleTurquoise2", RGB{174, 238, 238}},
+	{"PaleTurquoise3", RGB{150, 205, 205}},
+	{"PaleTurquoise4", RGB{102, 139, 139}},
+	{"CadetBlue1", RGB{152, 245, 255}},
+	{"CadetBlue2", RGB{142, 229, 238}},
+	{"CadetBlue3", RGB{122, 197, 205}},
+	{"CadetBlue4", RGB{83, 134, 139}},
+	{"turquoise1", RGB{0, 245, 255}},
+	{"turquoise2", RGB{0, 229, 238}},
+	{"turquoise3", RGB{0, 197, 205}},
+	{"turquoise4", RGB{0, 134, 139}},
+	{"cyan1", RGB{0, 255, 255}},
+	{"cyan2", RGB{0, 238, 238}},
+	{"cyan3", RGB{0, 205, 205}},
+	{"cyan4", RGB{0, 139, 139}},
+	{"DarkSlateGray1", RGB{151, 255, 255}},
+	{"DarkSlateGray2", RGB{141, 238, 238}},
+	{"DarkSlateGray3", RGB{121, 205, 205}},
+	{"DarkSlateGray4", RGB{82, 139, 139}},
+	{"aquamarine1", RGB{127, 255, 212}},
+	{"aquamarine2", RGB{118, 238, 198}},
+	{"aquamarine3", RGB{102, 205, 170}},
+	{"aquamarine4", RGB{69, 139, 116}},
+	{"DarkSeaGreen1", RGB{193, 255, 193}},
+	{"DarkSeaGreen2", RGB{180, 238, 180}},
+	{"DarkSeaGreen3", RGB{155, 205, 155}},
+	{"DarkSeaGreen4", RGB{105, 139, 105}},
+	{"SeaGreen1", RGB{84, 255, 159}},
+	{"SeaGreen2", RGB{78, 238, 148}},
+	{"SeaGreen3", RGB{67, 205, 128}},
+	{"SeaGreen4", RGB{46, 139, 87}},
+	{"PaleGreen1", RGB{154, 255, 154}},
+	{"PaleGreen2", RGB{144, 238, 144}},
+	{"PaleGreen3", RGB{124, 205, 124}},
+	{"PaleGreen4", RGB{84, 139, 84}},
+	{"SpringGreen1", RGB{0, 255, 127}},
+	{"SpringGreen2", RGB{0, 238, 118}},
+	{"SpringGreen3", RGB{0, 205, 102}},
+	{"SpringGreen4", RGB{0, 139, 69}},
+	{"green1", RGB{0, 255, 0}},
+	{"green2", RGB{0, 238, 0}},
+	{"green3", RGB{0, 205, 0}},
+	{"green4", RGB{0, 139, 0}},
+	{"chartreuse1", RGB{127, 255, 0}},
+	{"chartreuse2", RGB{118, 238, 0}},
+	{"chartreuse3", RGB{102, 205, 0}},
+	{"chartreuse4", RGB{69, 139, 0}},
+	{"OliveDrab1", RGB{192, 255, 62}},
+	{"OliveDrab2", RGB{179, 238, 58}},
+	{"OliveDrab3", RGB{154, 205, 50}},
+	{"OliveDrab4", RGB{105, 139, 34}},
+	{"DarkOliveGreen1", RGB{202, 255, 112}},
+	{"DarkOliveGreen2", RGB{188, 238, 104}},
+	{"DarkOliveGreen3", RGB{162, 205, 90}},
+	{"DarkOliveGreen4", RGB{110, 139, 61}},
+	{"khaki1", RGB{255, 246, 143}},
+	{"khaki2", RGB{238, 230, 133}},
+	{"khaki3", RGB{205, 198, 115}},
+	{"khaki4", RGB{139, 134, 78}},
+	{"LightGoldenrod1", RGB{255, 236, 139}},
+	{"LightGoldenrod2", RGB{238, 220, 130}},
+	{"LightGoldenrod3", RGB{205, 190, 112}},
+	{"LightGoldenrod4", RGB{139, 129, 76}},
+	{"LightYellow1", RGB{255, 255, 224}},
+	{"LightYellow2", RGB{238, 238, 209}},
+	{"LightYellow3", RGB{205, 205, 180}},
+	{"LightYellow4", RGB{139, 139, 122}},
+	{"yellow1", RGB{255, 255, 0}},
+	{"yellow2", RGB{238, 238, 0}},
+	{"yellow3", RGB{205, 205, 0}},
+	{"yellow4", RGB{139, 139, 0}},
+	{"gold1", RGB{255, 215, 0}},
+	{"gold2", RGB{238, 201, 0}},
+	{"gold3", RGB{205, 173, 0}},
+	{"gold4", RGB{139, 117, 0}},
+	{"goldenrod1", RGB{255, 193, 37}},
+	{"goldenrod2", RGB{238, 180, 34}},
+	{"goldenrod3", RGB{205, 155, 29}},
+	{"goldenrod4", RGB{139, 105, 20}},
+	{"DarkGoldenrod1", RGB{255, 185, 15}},
+	{"DarkGoldenrod2", RGB{238, 173, 14}},
+	{"DarkGoldenrod3", RGB{205, 149, 12}},
+	{"DarkGoldenrod4", RGB{139, 101, 8}},
+	{"RosyBrown1", RGB{255, 193, 193}},
+	{"RosyBrown2", RGB{238, 180, 180}},
+	{"RosyBrown3", RGB{205, 155, 155}},
+	{"RosyBrown4", RGB{139, 105, 105}},
+	{"IndianRed1", RGB{255, 106, 106}},
+	{"IndianRed2", RGB{238, 99, 99}},
+	{"IndianRed3", RGB{205, 85, 85}},
+	{"IndianRed4", RGB{139, 58, 58}},
+	{"sienna1", RGB{255, 130, 71}},
+	{"sienna2", RGB{238, 121, 66}},
+	{"sienna3", RGB{205, 104, 57}},
+	{"sienna4", RGB{139, 71, 38}},
+	{"burlywood1", RGB{255, 211, 155}},
+	{"burlywood2", RGB{238, 197, 145}},
+	{"burlywood3", RGB{205, 170, 125}},
+	{"burlywood4", RGB{139, 115, 85}},
+	{"wheat1", RGB{255, 231, 186}},
+	{"wheat2", RGB{238, 216, 174}},
+	{"wheat3", RGB{205, 186, 150}},
+	{"wheat4", RGB{139, 126, 102}},
+	{"tan1", RGB{255, 165, 79}},
+	{"tan2", RGB{238, 154, 73}},
+	{"tan3", RGB{205, 133, 63}},
+	{"tan4", RGB{139, 90, 43}},
+	{"chocolate1", RGB{255, 127, 36}},
+	{"chocolate2", RGB{238, 118, 33}},
+	{"chocolate3", RGB{205, 102, 29}},
+	{"chocolate4", RGB{139, 69, 19}},
+	{"firebrick1", RGB{255, 48, 48}},
+	{"firebrick2", RGB{238, 44, 44}},
+	{"firebrick3", RGB{205, 38, 38}},
+	{"firebrick4", RGB{139, 26, 26}},
+	{"brown1", RGB{255, 64, 64}},
+	{"brown2", RGB{238, 59, 59}},
+	{"brown3", RGB{205, 51, 51}},
+	{"brown4", RGB{139, 35, 35}},
+	{"salmon1", RGB{255, 140, 105}},
+	{"salmon2", RGB{238, 130, 98}},
+	{"salmon3", RGB{205, 112, 84}},
+	{"salmon4", RGB{139, 76, 57}},
+	{"LightSalmon1", RGB{255, 160, 122}},
+	{"LightSalmon2", RGB{238, 149, 114}},
+	{"LightSalmon3", RGB{205, 129, 98}},
+	{"LightSalmon4", RGB{139, 87, 66}},
+	{"orange1", RGB{255, 165, 0}},
+	{"orange2", RGB{238, 154, 0}},
+	{"orange3", RGB{205, 133, 0}},
+	{"orange4", RGB{139, 90, 0}},
+	{"DarkOrange1", RGB{255, 127, 0}},
+	{"DarkOrange2", RGB{238, 118, 0}},
+	{"DarkOrange3", RGB{205, 102, 0}},
+	{"DarkOrange4", RGB{139, 69, 0}},
+	{"coral1", RGB{255, 114, 86}},
+	{"coral2", RGB{238, 106, 80}},
+	{"coral3", RGB{205, 91, 69}},
+	{"coral4", RGB{139, 62, 47}},
+	{"tomato1", RGB{255, 99, 71}},
+	{"tomato2", RGB{238, 92, 66}},
+	{"tomato3", RGB{205, 79, 57}},
+	{"tomato4", RGB{139, 54, 38}},
+	{"OrangeRed1", RGB{255, 69, 0}},
+	{"OrangeRed2", RGB{238, 64, 0}},
+	{"OrangeRed3", RGB{205, 55, 0}},
+	{"OrangeRed4", RGB{139, 37, 0}},
+	{"red1", RGB{255, 0, 0}},
+	{"red2", RGB{238, 0, 0}},
+	{"red3", RGB{205, 0, 0}},
+	{"red4", RGB{139, 0, 0}},
+	{"DebianRed", RGB{215, 7, 81}},
+	{"DeepPink1", RGB{255, 20, 147}},
+	{"DeepPink2", RGB{238, 18, 137}},
+	{"DeepPink3", RGB{205, 16, 118}},
+	{"DeepPink4", RGB{139, 10, 80}},
+	{"HotPink1", RGB{255, 110, 180}},
+	{"HotPink2", RGB{238, 106, 167}},
+	{"HotPink3", RGB{205, 96, 144}},
+	{"HotPink4", RGB{139, 58, 98}},
+	{"pink1", RGB{255, 181, 197}},
+	{"pink2", RGB{238, 169, 184}},
+	{"pink3", RGB{205, 145, 158}},
+	{"pink4", RGB{139, 99, 108}},
+	{"LightPink1", RGB{255, 174, 185}},
+	{"LightPink2", RGB{238, 162, 173}},
+	{"LightPink3", RGB{205, 140, 149}},
+	{"LightPink4", RGB{139, 95, 101}},
+	{"PaleVioletRed1", RGB{255, 130, 171}},
+	{"PaleVioletRed2", RGB{238, 121, 159}},
+	{"PaleVioletRed3", RGB{205, 104, 137}},
+	{"PaleVioletRed4", RGB{139, 71, 93}},
+	{"maroon1", RGB{255, 52, 179}},
+	{"maroon2", RGB{238, 48, 167}},
+	{"maroon3", RGB{205, 41, 144}},
+	{"maroon4", RGB{139, 28, 98}},
+	{"VioletRed1", RGB{255, 62, 150}},
+	{"VioletRed2", RGB{238, 58, 140}},
+	{"VioletRed3", RGB{205, 50, 120}},
+	{"VioletRed4", RGB{139, 34, 82}},
+	{"magenta1", RGB{255, 0, 255}},
+	{"magenta2", RGB{238, 0, 238}},
+	{"magenta3", RGB{205, 0, 205}},
+	{"magenta4", RGB{139, 0, 139}},
+	{"orchid1", RGB{255, 131, 250}},
+	{"orchid2", RGB{238, 122, 233}},
+	{"orchid3", RGB{205, 105, 201}},
+	{"orchid4", RGB{139, 71, 137}},
+	{"plum1", RGB{255, 187, 255}},
+	{"plum2", RGB{238, 174, 238}},
+	{"plum3", RGB{205, 150, 205}},
+	{"plum4", RGB{139, 102, 139}},
+	{"MediumOrchid1", RGB{224, 102, 255}},
+	{"MediumOrchid2", RGB{209, 95, 238}},
+	{"MediumOrchid3", RGB{180, 82, 205}},
+	{"MediumOrchid4", RGB{122, 55, 139}},
+	{"DarkOrchid1", RGB{191, 62, 255}},
+	{"DarkOrchid2", RGB{178, 58, 238}},
+	{"DarkOrchid3", RGB{154, 50, 205}},
+	{"DarkOrchid4", RGB{104, 34, 139}},
+	{"purple1", RGB{155, 48, 255}},
+	{"purple2", RGB{145, 44, 238}},
+	{"purple3", RGB{125, 38, 205}},
+	{"purple4", RGB{85, 26, 139}},
+	{"MediumPurple1", RGB{171, 130, 255}},
+	{"MediumPurple2", RGB{159, 121, 238}},
+	{"MediumPurple3", RGB{137, 104, 205}},
+	{"MediumPurple4", RGB{93, 71, 139}},
+	{"thistle1", RGB{255, 225, 255}},
+	{"thistle2", RGB{238, 210, 238}},
+	{"thistle3", RGB{205, 181, 205}},
+	{"thistle4", RGB{139, 123, 139}},
+	{"gray0", RGB{0, 0, 0}},
+	{"grey0", RGB{0, 0, 0}},
+	{"gray1", RGB{3, 3, 3}},
+	{"grey1", RGB{3, 3, 3}},
+	{"gray2", RGB{5, 5, 5}},
+	{"grey2", RGB{5, 5, 5}},
+	{"gray3", RGB{8, 8, 8}},
+	{"grey3", RGB{8, 8, 8}},
+	{"gray4", RGB{10, 10, 10}},
+	{"grey4", RGB{10, 10, 10}},
+	{"gray5", RGB{13, 13, 13}},
+	{"grey5", RGB{13, 13, 13}},
+	{"gray6", RGB{15, 15, 15}},
+	{"grey6", RGB{15, 15, 15}},
+	{"gray7", RGB{18, 18, 18}},
+	{"grey7", RGB{18, 18, 18}},
+	{"gray8", RGB{20, 20, 20}},
+	{"grey8", RGB{20, 20, 20}},
+	{"gray9", RGB{23, 23, 23}},
+	{"grey9", RGB{23, 23, 23}},
+	{"gray10", RGB{26, 26, 26}},
+	{"grey10", RGB{26, 26, 26}},
+	{"gray11", RGB{28, 28, 28}},
+	{"grey11", RGB{28, 28, 28}},
+	{"gray12", RGB{31, 31, 31}},
+	{"grey12", RGB{31, 31, 31}},
+	{"gray13", RGB{33, 33, 33}},
+	{"grey13", RGB{33, 33, 33}},
+	{"gray14", RGB{36, 36, 36}},
+	{"grey14", RGB{36, 36, 36}},
+	{"gray15", RGB{38, 38, 38}},
+	{"grey15", RGB{38, 38, 38}},
+	{"gray16", RGB{41, 41, 41}},
+	{"grey16", RGB{41, 41, 41}},
+	{"gray17", RGB{43, 43, 43}},
+	{"grey17", RGB{43, 43, 43}},
+	{"gray18", RGB{46, 46, 46}},
+	{"grey18", RGB{46, 46, 46}},
+	{"gray19", RGB{48, 48, 48}},
+	{"grey19", RGB{48, 48, 48}},
+	{"gray20", RGB{51, 51, 51}},
+	{"grey20", RGB{51, 51, 51}},
+	{"gray21", RGB{54, 54, 54}},
+	{"grey21", RGB{54, 54, 54}},
+	{"gray22", RGB{56, 56, 56}},
+	{"grey22", RGB{56, 56, 56}},
+	{"gray23", RGB{59, 59, 59}},
+	{"grey23", RGB{59, 59, 59}},
+	{"gray24", RGB{61, 61, 61}},
+	{"grey24", RGB{61, 61, 61}},
+	{"gray25", RGB{64, 64, 64}},
+	{"grey25", RGB{64, 64, 64}},
+	{"gray26", RGB{66, 66, 66}},
+	{"grey26", RGB{66, 66, 66}},
+	{"gray27", RGB{69, 69, 69}},
+	{"grey27", RGB{69, 69, 69}},
+	{"gray28", RGB{71, 71, 71}},
+	{"grey28", RGB{71, 71, 71}},
+	{"gray29", RGB{74, 74, 74}},
+	{"grey29", RGB{74, 74, 74}},
+	{"gray30", RGB{77, 77, 77}},
+	{"grey30", RGB{77, 77, 77}},
+	{"gray31", RGB{79, 79, 79}},
+	{"grey31", RGB{79, 79, 79}},
+	{"gray32", RGB{82, 82, 82}},
+	{"grey32", RGB{82, 82, 82}},
+	{"gray33", RGB{84, 84, 84}},
+	{"grey33", RGB{84, 84, 84}},
+	{"gray34", RGB{87, 87, 87}},
+	{"grey34", RGB{87, 87, 87}},
+	{"gray35", RGB{89, 89, 89}},
+	{"grey35", RGB{89, 89, 89}},
+	{"gray36", RGB{92, 92, 92}},
+	{"grey36", RGB{92, 92, 92}},
+	{"gray37", RGB{94, 94, 94}},
+	{"grey37", RGB{94, 94, 94}},
+	{"gray38", RGB{97, 97, 97}},
+	{"grey38", RGB{97, 97, 97}},
+	{"gray39", RGB{99, 99, 99}},
+	{"grey39", RGB{99, 99, 99}},
+	{"gray40", RGB{102, 102, 102}},
+	{"grey40", RGB{102, 102, 102}},
+	{"gray41", RGB{105, 105, 105}},
+	{"grey41", RGB{105, 105, 105}},
+	{"gray42", RGB{107, 107, 107}},
+	{"grey42", RGB{107, 107, 107}},
+	{"gray43", RGB{110, 110, 110}},
+	{"grey43", RGB{110, 110, 110}},
+	{"gray44", RGB{112, 112, 112}},
+	{"grey44", RGB{112, 112, 112}},
+	{"gray45", RGB{115, 115, 115}},
+	{"grey45", RGB{115, 115, 115}},
+	{"gray46", RGB{117, 117, 117}},
+	{"grey46", RGB{117, 117, 117}},
+	{"gray47", RGB{120, 120, 120}},
+	{"grey47", RGB{120, 120, 120}},
+	{"gray48", RGB{122, 122, 122}},
+	{"grey48", RGB{122, 122, 122}},
+	{"gray49", RGB{125, 125, 125}},
+	{"grey49", RGB{125, 125, 125}},
+	{"gray50", RGB{127, 127, 127}},
+	{"grey50", RGB{127, 127, 127}},
+	{"gray51", RGB{130, 130, 130}},
+	{"grey51", RGB{130, 130, 130}},
+	{"gray52", RGB{133, 133, 133}},
+	{"grey52", RGB{133, 133, 133}},
+	{"gray53", RGB{135, 135, 135}},
+	{"grey53", RGB{135, 135, 135}},
+	{"gray54", RGB{138, 138, 138}},
+	{"grey54", RGB{138, 138, 138}},
+	{"gray55", RGB{140, 140, 140}},
+	{"grey55", RGB{140, 140, 140}},
+	{"gray56", RGB{143, 143, 143}},
+	{"grey56", RGB{143, 143, 143}},
+	{"gray57", RGB{145, 145, 145}},
+	{"grey57", RGB{145, 145, 145}},
+	{"gray58", RGB{148, 148, 148}},
+	{"grey58", RGB{148, 148, 148}},
+	{"gray59", RGB{150, 150, 150}},
+	{"grey59", RGB{150, 150, 150}},
+	{"gray60", RGB{153, 153, 153}},
+	{"grey60", RGB{153, 153, 153}},
+	{"gray61", RGB{156, 156, 156}},
+	{"grey61", RGB{156, 156, 156}},
+	{"gray62", RGB{158, 158, 158}},
+	{"grey62", RGB{158, 158, 158}},
+	{"gray63", RGB{161, 161, 161}},
+	{"grey63", RGB{161, 161, 161}},
+	{"gray64", RGB{163, 163, 163}},
+	{"grey64", RGB{163, 163, 163}},
+	{"gray65", RGB{166, 166, 166}},
+	{"grey65", RGB{166, 166, 166}},
+	{"gray66", RGB{168, 168, 168}},
+	{"grey66", RGB{168, 168, 168}},
+	{"gray67", RGB{171, 171, 171}},
+	{"grey67", RGB{171, 171, 171}},
+	{"gray68", RGB{173, 173, 173}},
+	{"grey68", RGB{173, 173, 173}},
+	{"gray69", RGB{176, 176, 176}},
+	{"grey69", RGB{176, 176, 176}},
+	{"gray70", RGB{179, 179, 179}},
+	{"grey70", RGB{179, 179, 179}},
+	{"gray71", RGB{181, 181, 181}},
+	{"grey71", RGB{181, 181, 181}},
+	{"gray72", RGB{184, 184, 184}},
+	{"grey72", RGB{184, 184, 184}},
+	{"gray73", RGB{186, 186, 186}},
+	{"grey73", RGB{186, 186, 186}},
+	{"gray74", RGB{189, 189, 189}},
+	{"grey74", RGB{189, 189, 189}},
+	{"gray75", RGB{191, 191, 191}},
+	{"grey75", RGB{191, 191, 191}},
+	{"gray76", RGB{194, 194, 194}},
+	{"grey76", RGB{194, 194, 194}},
+	{"gray77", RGB{196, 196, 196}},
+	{"grey77", RGB{196, 196, 196}},
+	{"gray78", RGB{199, 199, 199}},
+	{"grey78", RGB{199, 199, 199}},
+	{"gray79", RGB{201, 201, 201}},
+	{"grey79", RGB{201, 201, 201}},
+	{"gray80", RGB{204, 204, 204}},
+	{"grey80", RGB{204, 204, 204}},
+	{"gray81", RGB{207, 207, 207}},
+	{"grey81", RGB{207, 207, 207}},
+	{"gray82", RGB{209, 209, 209}},
+	{"grey82", RGB{209, 209, 209}},
+	{"gray83", RGB{212, 212, 212}},
+	{"grey83", RGB{212, 212, 212}},
+	{"gray84", RGB{214, 214, 214}},
+	{"grey84", RGB{214, 214, 214}},
+	{"gray85", RGB{217, 217, 217}},
+	{"grey85", RGB{217, 217, 217}},
+	{"gray86", RGB{219, 219, 219}},
+	{"grey86", RGB{219, 219, 219}},
+	{"gray87", RGB{222, 222, 222}},
+	{"grey87", RGB{222, 222, 222}},
+	{"gray88", RGB{224, 224, 224}},
+	{"grey88", RGB{224, 224, 224}},
+	{"gray89", RGB{227, 227, 227}},
+	{"grey89", RGB{227, 227, 227}},
+	{"gray90", RGB{229, 229, 229}},
+	{"grey90", RGB{229, 229, 229}},
+	{"gray91", RGB{232, 232, 232}},
+	{"grey91", RGB{232, 232, 232}},
+	{"gray92", RGB{235, 235, 235}},
+	{"grey92", RGB{235, 235, 235}},
+	{"gray93", RGB{237, 237, 237}},
+	{"grey93", RGB{237, 237, 237}},
+	{"gray94", RGB{240, 240, 240}},
+	{"grey94", RGB{240, 240, 240}},
+	{"gray95", RGB{242, 242, 242}},
+	{"grey95", RGB{242, 242, 242}},
+	{"gray96", RGB{245, 245, 245}},
+	{"grey96", RGB{245, 245, 245}},
+	{"gray97", RGB{247, 247, 247}},
+	{"grey97", RGB{247, 247, 247}},
+	{"gray98", RGB{250, 250, 250}},
+	{"grey98", RGB{250, 250, 250}},
+	{"gray99", RGB{252, 252, 252}},
+	{"grey99", RGB{252, 252, 252}},
+	{"gray100", RGB{255, 255, 255}},
+	{"grey100", RGB{255, 255, 255}},
+	{"dark grey", RGB{169, 169, 169}},
+	{"DarkGrey", RGB{169, 169, 169}},
+	{"dark gray", RGB{169, 169, 169}},
+	{"DarkGray", RGB{169, 169, 169}},
+	{"dark blue", RGB{0, 0, 139}},
+	{"DarkBlue", RGB{0, 0, 139}},
+	{"dark cyan", RGB{0, 139, 139}},
+	{"DarkCyan", RGB{0, 139, 139}},
+	{"dark magenta", RGB{139, 0, 139}},
+	{"DarkMagenta", RGB{139, 0, 139}},
+	{"dark red", RGB{139, 0, 0}},
+	{"DarkRed", RGB{139, 0, 0}},
+	{"light green", RGB{144, 238, 144}},
+	{"LightGreen", RGB{144, 238, 144}},
+}
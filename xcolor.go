@@ -0,0 +1,397 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// xcolorValue is a color in one of the xcolor core models, rgb, cmyk or
+// gray, with 0-1 components
+type xcolorValue struct {
+	model string
+	c     []float64
+}
+
+// Xcolors is a set of LaTeX xcolor color definitions used to evaluate color
+// expressions such as "red!30!blue"
+type Xcolors struct {
+	colors map[string]xcolorValue
+}
+
+// xcolorBase are the colors xcolor always defines, in their original models
+var xcolorBase = []struct {
+	name, model, spec string
+}{
+	{"red", "rgb", "1,0,0"}, {"green", "rgb", "0,1,0"}, {"blue", "rgb", "0,0,1"},
+	{"brown", "rgb", ".75,.5,.25"}, {"lime", "rgb", ".75,1,0"}, {"orange", "rgb", "1,.5,0"},
+	{"pink", "rgb", "1,.75,.75"}, {"purple", "rgb", ".75,0,.25"}, {"teal", "rgb", "0,.5,.5"},
+	{"violet", "rgb", ".5,0,.5"}, {"olive", "rgb", ".5,.5,0"},
+	{"cyan", "cmyk", "1,0,0,0"}, {"magenta", "cmyk", "0,1,0,0"}, {"yellow", "cmyk", "0,0,1,0"},
+	{"black", "gray", "0"}, {"darkgray", "gray", ".25"}, {"gray", "gray", ".5"},
+	{"lightgray", "gray", ".75"}, {"white", "gray", "1"},
+}
+
+// NewXcolors returns a set holding the xcolor base colors
+// Returns:
+//   *Xcolors: set with red, green, blue, cyan, ..., white defined
+// Example:
+//   set := NewXcolors()
+//   set.Define("brand", "HTML", "FF8800")
+//   c, err := set.Eval("brand!50!black")
+func NewXcolors() *Xcolors {
+	s := &Xcolors{colors: map[string]xcolorValue{}}
+	for _, b := range xcolorBase {
+		s.Define(b.name, b.model, b.spec)
+	}
+	return s
+}
+
+// DefaultXcolors is the set of xcolor base colors used by ParseXcolor
+var DefaultXcolors = NewXcolors()
+
+// Define adds or replaces a color like \definecolor{name}{model}{spec}
+// Parameters:
+//   name: color name
+//   model: rgb, RGB, HTML, cmyk, cmy, gray, Gray, hsb, Hsb, HSB or wave
+//   spec: comma separated components, hex digits for HTML
+// Returns:
+//   error: unknown model or malformed spec
+func (s *Xcolors) Define(name, model, spec string) error {
+	v, err := parseXcolorSpec(model, spec)
+	if err != nil {
+		return err
+	}
+	s.colors[name] = v
+	return nil
+}
+
+// Eval evaluates an xcolor expression to sRGB
+// Parameters:
+//   expr: a color name, a mix expression such as "-red!30!blue!50" where
+//         "c1!p!c2" takes p% of c1 and the rest of c2 (white when omitted),
+//         a leading "-" takes the complement of the whole mix, or an explicit "{model}{spec}"
+//         or "[model]{spec}" color
+// Returns:
+//   RGB: resulting color
+//   error: unknown color, model or malformed expression
+// Example:
+//   c, err := NewXcolors().Eval("red!30!blue") // returns RGB{77,0,179}
+func (s *Xcolors) Eval(expr string) (RGB, error) {
+	v, err := s.eval(expr)
+	if err != nil {
+		return RGB{}, err
+	}
+	c := v.rgb()
+	return RGB{unitToUint8(c[0]), unitToUint8(c[1]), unitToUint8(c[2])}, nil
+}
+
+// EvalCmyk evaluates an xcolor expression to CMYK
+// The conversion from rgb uses xcolor's full undercolor removal.
+// Parameters:
+//   expr: expression as accepted by Eval
+// Returns:
+//   CMYK: resulting color in percent
+//   error: unknown color, model or malformed expression
+// Example:
+//   c, err := NewXcolors().EvalCmyk("cyan!50") // returns CMYK{50,0,0,0}
+func (s *Xcolors) EvalCmyk(expr string) (CMYK, error) {
+	v, err := s.eval(expr)
+	if err != nil {
+		return CMYK{}, err
+	}
+	c := v.cmyk()
+	percent := func(f float64) uint8 { return uint8(math.Round(math.Max(0, math.Min(1, f)) * 100)) }
+	return CMYK{percent(c[0]), percent(c[1]), percent(c[2]), percent(c[3])}, nil
+}
+
+// ParseXcolor evaluates an xcolor expression with the base colors
+// Parameters:
+//   expr: expression as accepted by Xcolors.Eval
+// Returns:
+//   RGB: resulting color
+//   error: unknown color, model or malformed expression
+// Example:
+//   c, err := ParseXcolor("{HTML}{FF8800}") // returns RGB{255,136,0}
+func ParseXcolor(expr string) (RGB, error) {
+	return DefaultXcolors.Eval(expr)
+}
+
+func (s *Xcolors) eval(expr string) (xcolorValue, error) {
+	e := strings.TrimSpace(expr)
+	if strings.HasPrefix(e, "[") || strings.HasPrefix(e, "{") && strings.Count(e, "{") == 2 {
+		return parseXcolorModelSpec(e)
+	}
+	e = strings.TrimSuffix(strings.TrimPrefix(e, "{"), "}")
+
+	// each leading minus complements the result of the whole mix
+	complement := false
+	for e = strings.TrimSpace(e); strings.HasPrefix(e, "-"); e = strings.TrimSpace(e[1:]) {
+		complement = !complement
+	}
+	parts := strings.Split(e, "!")
+	cur, err := s.named(parts[0])
+	if err != nil {
+		return xcolorValue{}, err
+	}
+	for i := 1; i < len(parts); i += 2 {
+		p, err := strconv.ParseFloat(strings.TrimSpace(parts[i]), 64)
+		if err != nil || p < 0 || p > 100 {
+			return xcolorValue{}, fmt.Errorf("invalid xcolor percentage %q in %s", parts[i], expr)
+		}
+		other := s.colors["white"]
+		if i+1 < len(parts) && strings.TrimSpace(parts[i+1]) != "" {
+			if other, err = s.named(parts[i+1]); err != nil {
+				return xcolorValue{}, err
+			}
+		}
+		cur = cur.mix(other, p/100)
+	}
+	if complement {
+		cur = cur.complement()
+	}
+	return cur, nil
+}
+
+// named looks up a color name
+func (s *Xcolors) named(name string) (xcolorValue, error) {
+	name = strings.TrimSpace(name)
+	v, ok := s.colors[name]
+	if !ok {
+		return xcolorValue{}, fmt.Errorf("undefined xcolor: %q", name)
+	}
+	return v, nil
+}
+
+// parseXcolorModelSpec parses "{model}{spec}" or "[model]{spec}"
+func parseXcolorModelSpec(e string) (xcolorValue, error) {
+	closer := "}"
+	if e[0] == '[' {
+		closer = "]"
+	}
+	end := strings.Index(e, closer)
+	if end < 0 || !strings.HasPrefix(e[end+1:], "{") || !strings.HasSuffix(e, "}") {
+		return xcolorValue{}, fmt.Errorf("invalid xcolor expression: %s", e)
+	}
+	return parseXcolorSpec(e[1:end], e[end+2:len(e)-1])
+}
+
+// parseXcolorSpec converts a model and its spec to a core model value
+func parseXcolorSpec(model, spec string) (xcolorValue, error) {
+	model, spec = strings.TrimSpace(model), strings.TrimSpace(spec)
+	if model == "HTML" {
+		r, g, b, _, err := hexToRGBA(spec)
+		if err != nil || len(spec) != 6 {
+			return xcolorValue{}, fmt.Errorf("invalid xcolor HTML spec: %s", spec)
+		}
+		return xcolorValue{"rgb", []float64{float64(r) / 255, float64(g) / 255, float64(b) / 255}}, nil
+	}
+
+	var c []float64
+	for _, f := range strings.FieldsFunc(spec, func(r rune) bool { return r == ',' || r == ' ' }) {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return xcolorValue{}, fmt.Errorf("invalid xcolor %s spec: %s", model, spec)
+		}
+		c = append(c, v)
+	}
+	sizes := map[string]int{"rgb": 3, "RGB": 3, "cmy": 3, "cmyk": 4, "gray": 1, "Gray": 1, "hsb": 3, "Hsb": 3, "HSB": 3, "wave": 1}
+	n, ok := sizes[model]
+	if !ok {
+		return xcolorValue{}, fmt.Errorf("unknown xcolor model: %s", model)
+	}
+	if len(c) != n {
+		return xcolorValue{}, fmt.Errorf("xcolor %s needs %d components, got %s", model, n, spec)
+	}
+
+	switch model {
+	case "RGB":
+		return xcolorValue{"rgb", []float64{c[0] / 255, c[1] / 255, c[2] / 255}}, nil
+	case "cmy":
+		return xcolorValue{"cmyk", []float64{c[0], c[1], c[2], 0}}, nil
+	case "Gray":
+		return xcolorValue{"gray", []float64{c[0] / 15}}, nil
+	case "hsb":
+		return xcolorValue{"rgb", hsbToRgbUnit(c[0], c[1], c[2])}, nil
+	case "Hsb":
+		return xcolorValue{"rgb", hsbToRgbUnit(c[0]/360, c[1], c[2])}, nil
+	case "HSB":
+		return xcolorValue{"rgb", hsbToRgbUnit(c[0]/240, c[1]/240, c[2]/240)}, nil
+	case "wave":
+		return xcolorValue{"rgb", waveToRgbUnit(c[0])}, nil
+	}
+	return xcolorValue{model, c}, nil
+}
+
+// hsbToRgbUnit converts 0-1 hue, saturation and brightness to 0-1 rgb
+func hsbToRgbUnit(h, s, b float64) []float64 {
+	h = math.Mod(h, 1) * 6
+	if h < 0 {
+		h += 6
+	}
+	i := math.Floor(h)
+	f := h - i
+	p, q, t := b*(1-s), b*(1-s*f), b*(1-s*(1-f))
+	switch int(i) {
+	case 0:
+		return []float64{b, t, p}
+	case 1:
+		return []float64{q, b, p}
+	case 2:
+		return []float64{p, b, t}
+	case 3:
+		return []float64{p, q, b}
+	case 4:
+		return []float64{t, p, b}
+	}
+	return []float64{b, p, q}
+}
+
+// waveToRgbUnit approximates the color of a 380-780 nm wavelength with
+// Dan Bruton's piecewise model and a 0.8 gamma, as xcolor's wave model does
+func waveToRgbUnit(nm float64) []float64 {
+	var r, g, b float64
+	switch {
+	case nm < 440:
+		r, b = (440-nm)/(440-380), 1
+	case nm < 490:
+		g, b = (nm-440)/(490-440), 1
+	case nm < 510:
+		g, b = 1, (510-nm)/(510-490)
+	case nm < 580:
+		r, g = (nm-510)/(580-510), 1
+	case nm < 645:
+		r, g = 1, (645-nm)/(645-580)
+	default:
+		r = 1
+	}
+	f := 1.0
+	switch {
+	case nm < 380 || nm > 780:
+		f = 0
+	case nm < 420:
+		f = 0.3 + 0.7*(nm-380)/(420-380)
+	case nm > 700:
+		f = 0.3 + 0.7*(780-nm)/(780-700)
+	}
+	gamma := func(v float64) float64 { return math.Pow(math.Max(0, math.Min(1, f*v)), 0.8) }
+	return []float64{gamma(r), gamma(g), gamma(b)}
+}
+
+func (v xcolorValue) rgb() []float64 {
+	switch v.model {
+	case "cmyk":
+		return []float64{
+			1 - math.Min(1, v.c[0]+v.c[3]),
+			1 - math.Min(1, v.c[1]+v.c[3]),
+			1 - math.Min(1, v.c[2]+v.c[3]),
+		}
+	case "gray":
+		return []float64{v.c[0], v.c[0], v.c[0]}
+	}
+	return v.c
+}
+
+func (v xcolorValue) cmyk() []float64 {
+	switch v.model {
+	case "cmyk":
+		return v.c
+	case "gray":
+		return []float64{0, 0, 0, 1 - v.c[0]}
+	}
+	c, m, y := 1-v.c[0], 1-v.c[1], 1-v.c[2]
+	k := math.Min(c, math.Min(m, y))
+	return []float64{c - k, m - k, y - k, k}
+}
+
+func (v xcolorValue) gray() []float64 {
+	switch v.model {
+	case "gray":
+		return v.c
+	case "cmyk":
+		return []float64{1 - math.Min(1, 0.3*v.c[0]+0.59*v.c[1]+0.11*v.c[2]+v.c[3])}
+	}
+	return []float64{0.3*v.c[0] + 0.59*v.c[1] + 0.11*v.c[2]}
+}
+
+// in converts the value to another core model
+func (v xcolorValue) in(model string) xcolorValue {
+	switch model {
+	case "cmyk":
+		return xcolorValue{model, v.cmyk()}
+	case "gray":
+		return xcolorValue{model, v.gray()}
+	}
+	return xcolorValue{"rgb", v.rgb()}
+}
+
+// mix blends p of v with 1-p of other in the model of v
+func (v xcolorValue) mix(other xcolorValue, p float64) xcolorValue {
+	o := other.in(v.model)
+	c := make([]float64, len(v.c))
+	for i := range c {
+		c[i] = p*v.c[i] + (1-p)*o.c[i]
+	}
+	return xcolorValue{v.model, c}
+}
+
+// complement returns the xcolor complement, computed in rgb and converted
+// back to the model of v
+func (v xcolorValue) complement() xcolorValue {
+	if v.model == "gray" {
+		return xcolorValue{"gray", []float64{1 - v.c[0]}}
+	}
+	rgb := v.rgb()
+	return xcolorValue{"rgb", []float64{1 - rgb[0], 1 - rgb[1], 1 - rgb[2]}}.in(v.model)
+}
+
+// FormatDefinecolor writes a \definecolor line for a color
+// Parameters:
+//   name: LaTeX color name
+//   model: rgb, RGB, HTML, cmyk or gray
+//   c: RGB, CMYK or any type with ToRgb; CMYK values in the cmyk model are written as is
+// Returns:
+//   string: "\definecolor{name}{model}{spec}" line
+//   error: unknown model or unsupported color
+// Example:
+//   FormatDefinecolor("brand", "HTML", RGB{255,136,0}) // returns "\definecolor{brand}{HTML}{FF8800}"
+//   FormatDefinecolor("brand", "rgb", RGB{255,136,0}) // returns "\definecolor{brand}{rgb}{1,0.5333,0}"
+func FormatDefinecolor(name, model string, c any) (string, error) {
+	var v xcolorValue
+	switch c := c.(type) {
+	case CMYK:
+		v = xcolorValue{"cmyk", []float64{float64(c.C) / 100, float64(c.M) / 100, float64(c.Y) / 100, float64(c.K) / 100}}
+	case RGB:
+		v = xcolorValue{"rgb", []float64{float64(c.R) / 255, float64(c.G) / 255, float64(c.B) / 255}}
+	case ToRgb:
+		rgb := c.ToRgb()
+		v = xcolorValue{"rgb", []float64{float64(rgb.R) / 255, float64(rgb.G) / 255, float64(rgb.B) / 255}}
+	default:
+		return "", fmt.Errorf("unsupported xcolor color: %T", c)
+	}
+
+	num := func(f float64) string { return strconv.FormatFloat(math.Round(f*10000)/10000, 'f', -1, 64) }
+	join := func(c []float64) string {
+		s := make([]string, len(c))
+		for i, f := range c {
+			s[i] = num(f)
+		}
+		return strings.Join(s, ",")
+	}
+	var spec string
+	switch model {
+	case "rgb", "cmyk", "gray":
+		spec = join(v.in(model).c)
+	case "RGB", "HTML":
+		rgb := v.rgb()
+		b := [3]uint8{unitToUint8(rgb[0]), unitToUint8(rgb[1]), unitToUint8(rgb[2])}
+		spec = fmt.Sprintf("%d,%d,%d", b[0], b[1], b[2])
+		if model == "HTML" {
+			spec = fmt.Sprintf("%02X%02X%02X", b[0], b[1], b[2])
+		}
+	default:
+		return "", fmt.Errorf("unsupported xcolor model: %s", model)
+	}
+	return fmt.Sprintf("\\definecolor{%s}{%s}{%s}", name, model, spec), nil
+}
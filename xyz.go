@@ -0,0 +1,298 @@
+package color
+
+import (
+	"fmt"
+	"math"
+)
+
+// XYZ is a CIE 1931 XYZ tristimulus value relative to a D65 white
+// normalized so that Y=1.0 for the reference white.
+type XYZ struct {
+	X, Y, Z float64
+}
+
+// StrToXyz converts an xyz() format string to XYZ object
+// Parameters:
+//   str: string in "xyz(x,y,z)" format
+// Returns:
+//   *XYZ: pointer to XYZ object
+//   error: parsing error if format is invalid
+// Example:
+//   c, err := StrToXyz("xyz(0.9505,1.0000,1.0888)") // D65 white
+func StrToXyz(str string) (*XYZ, error) {
+	var x, y, z float64
+	_, err := fmt.Sscanf(RemoveSpace(str), "xyz(%f,%f,%f)", &x, &y, &z)
+	if err != nil {
+		return nil, err
+	}
+	return &XYZ{X: x, Y: y, Z: z}, nil
+}
+
+// String converts XYZ object to xyz() format string
+// Returns:
+//   string: "xyz(x,y,z)" formatted string
+// Example:
+//   c := XYZ{0.4124, 0.2126, 0.0193}
+//   fmt.Println(c.String()) // outputs "xyz(0.4124,0.2126,0.0193)"
+func (c *XYZ) String() string {
+	return fmt.Sprintf("xyz(%.4f,%.4f,%.4f)", c.X, c.Y, c.Z)
+}
+
+// ToRgb converts XYZ to sRGB representation, clipping out-of-gamut values
+// Returns:
+//   RGB: corresponding RGB color object
+// Example:
+//   c := XYZ{0.95047, 1.0, 1.08883} // D65 white
+//   rgb := c.ToRgb() // returns RGB{255,255,255}
+func (c *XYZ) ToRgb() RGB {
+	r, g, b := xyzToRgb(c.X, c.Y, c.Z)
+	return RGB{R: r, G: g, B: b}
+}
+
+// ToRgba converts XYZ to RGBA with full opacity
+// Returns:
+//   RGBA: RGBA object with alpha=1.0
+// Example:
+//   c := XYZ{0.4124, 0.2126, 0.0193} // red
+//   rgba := c.ToRgba() // returns RGBA{RGB{255,0,0},1.0}
+func (c *XYZ) ToRgba() RGBA {
+	return RGBA{c.ToRgb(), 1.0}
+}
+
+// ToHex converts XYZ to hexadecimal string
+// Returns:
+//   string: "#RRGGBB" format string
+// Example:
+//   c := XYZ{0.1805, 0.0722, 0.9505} // blue
+//   hex := c.ToHex() // returns "#0000ff"
+func (c *XYZ) ToHex() string {
+	rgb := c.ToRgb()
+	return rgb.ToHex()
+}
+
+// ToHsl converts XYZ to HSL representation
+// Returns:
+//   HSL: corresponding HSL color object
+// Example:
+//   c := XYZ{0.4124, 0.2126, 0.0193} // red
+//   hsl := c.ToHsl() // returns HSL{0,100,50}
+func (c *XYZ) ToHsl() HSL {
+	rgb := c.ToRgb()
+	return rgb.ToHsl()
+}
+
+// ToHsla converts XYZ to HSLA with full opacity
+// Returns:
+//   HSLA: HSLA object with alpha=1.0
+// Example:
+//   c := XYZ{0.4124, 0.2126, 0.0193} // red
+//   hsla := c.ToHsla() // returns HSLA{HSL{0,100,50},1.0}
+func (c *XYZ) ToHsla() HSLA {
+	rgb := c.ToRgb()
+	return rgb.ToHsla()
+}
+
+// ToHsv converts XYZ to HSV representation
+// Returns:
+//   HSV: corresponding HSV color object
+// Example:
+//   c := XYZ{0.3576, 0.7152, 0.1192} // green
+//   hsv := c.ToHsv() // returns HSV{120,100,100}
+func (c *XYZ) ToHsv() HSV {
+	rgb := c.ToRgb()
+	return rgb.ToHsv()
+}
+
+// ToCmyk converts XYZ to CMYK representation
+// Returns:
+//   CMYK: corresponding CMYK color object
+// Example:
+//   c := XYZ{0, 0, 0} // black
+//   cmyk := c.ToCmyk() // returns CMYK{0,0,0,100}
+func (c *XYZ) ToCmyk() CMYK {
+	rgb := c.ToRgb()
+	return rgb.ToCmyk()
+}
+
+// ToLab converts XYZ to CIE L*a*b* (D50) representation
+// Returns:
+//   LAB: corresponding LAB color object
+// Example:
+//   c := XYZ{0.95047, 1.0, 1.08883} // D65 white
+//   lab := c.ToLab() // returns LAB{100,0,0}
+func (c *XYZ) ToLab() LAB {
+	return xyzToLabD50(*c)
+}
+
+// ToOklab converts XYZ to Oklab representation
+// Returns:
+//   OKLAB: corresponding OKLAB color object
+// Example:
+//   c := XYZ{0.95047, 1.0, 1.08883} // D65 white
+//   lab := c.ToOklab() // returns OKLAB{1.0000,0.0000,0.0000}
+func (c *XYZ) ToOklab() OKLAB {
+	return xyzToOklab(*c)
+}
+
+// ToOklch converts XYZ to OKLCH representation
+// Returns:
+//   OKLCH: corresponding OKLCH color object
+// Example:
+//   c := XYZ{0.4124, 0.2126, 0.0193} // red
+//   lch := c.ToOklch() // returns OKLCH{0.6280,0.2577,29.23}
+func (c *XYZ) ToOklch() OKLCH {
+	lab := c.ToOklab()
+	return lab.ToOklch()
+}
+
+// ToHct converts XYZ to HCT representation
+// Returns:
+//   HCT: corresponding HCT color object
+// Example:
+//   c := XYZ{0.95047, 1.0, 1.08883} // D65 white
+//   hct := c.ToHct() // returns HCT{209.49,2.87,100.00}
+func (c *XYZ) ToHct() HCT {
+	return xyzToHct(*c)
+}
+
+// ToHsluv converts XYZ to HSLuv representation
+// Returns:
+//   HSLUV: corresponding HSLUV color object
+// Example:
+//   c := XYZ{0.4124, 0.2126, 0.0193} // red
+//   hsl := c.ToHsluv() // returns HSLUV{12.17,100.04,53.23}
+func (c *XYZ) ToHsluv() HSLUV {
+	return xyzToHsluv(*c)
+}
+
+// ToHpluv converts XYZ to HPLuv representation
+// Returns:
+//   HPLUV: corresponding HPLUV color object, P may exceed 100 for saturated colors
+// Example:
+//   c := XYZ{0.4124, 0.2126, 0.0193} // red
+//   hpl := c.ToHpluv() // returns HPLUV{12.17,426.90,53.23}
+func (c *XYZ) ToHpluv() HPLUV {
+	return xyzToHpluv(*c)
+}
+
+// sRGB (D65) primaries to XYZ and back
+var (
+	srgbToXyzMatrix = [3][3]float64{
+		{0.4124564, 0.3575761, 0.1804375},
+		{0.2126729, 0.7151522, 0.0721750},
+		{0.0193339, 0.1191920, 0.9503041},
+	}
+	xyzToSrgbMatrix = [3][3]float64{
+		{3.2404542, -1.5371385, -0.4985314},
+		{-0.9692660, 1.8760108, 0.0415560},
+		{0.0556434, -0.2040259, 1.0572252},
+	}
+)
+
+// srgbToLinear removes the sRGB transfer curve from a 0-1 channel value
+func srgbToLinear(v float64) float64 {
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+// linearToSrgb applies the sRGB transfer curve to a 0-1 linear channel value
+func linearToSrgb(v float64) float64 {
+	if v <= 0.0031308 {
+		return v * 12.92
+	}
+	return 1.055*math.Pow(v, 1/2.4) - 0.055
+}
+
+// unitToUint8 rounds a 0-1 channel value into 0-255, clipping out-of-range input
+func unitToUint8(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
+}
+
+func rgbToXyz(r, g, b uint8) (float64, float64, float64) {
+	lr := srgbToLinear(float64(r) / 255.0)
+	lg := srgbToLinear(float64(g) / 255.0)
+	lb := srgbToLinear(float64(b) / 255.0)
+	return mulMat3Vec(srgbToXyzMatrix, lr, lg, lb)
+}
+
+func xyzToRgb(x, y, z float64) (uint8, uint8, uint8) {
+	lr, lg, lb := mulMat3Vec(xyzToSrgbMatrix, x, y, z)
+	return unitToUint8(linearToSrgb(lr)), unitToUint8(linearToSrgb(lg)), unitToUint8(linearToSrgb(lb))
+}
+
+func mulMat3Vec(m [3][3]float64, a, b, c float64) (float64, float64, float64) {
+	return m[0][0]*a + m[0][1]*b + m[0][2]*c,
+		m[1][0]*a + m[1][1]*b + m[1][2]*c,
+		m[2][0]*a + m[2][1]*b + m[2][2]*c
+}
+
+func mulMat3(a, b [3][3]float64) [3][3]float64 {
+	var m [3][3]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			m[i][j] = a[i][0]*b[0][j] + a[i][1]*b[1][j] + a[i][2]*b[2][j]
+		}
+	}
+	return m
+}
+
+func invertMat3(m [3][3]float64) [3][3]float64 {
+	det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+	return [3][3]float64{
+		{
+			(m[1][1]*m[2][2] - m[1][2]*m[2][1]) / det,
+			(m[0][2]*m[2][1] - m[0][1]*m[2][2]) / det,
+			(m[0][1]*m[1][2] - m[0][2]*m[1][1]) / det,
+		},
+		{
+			(m[1][2]*m[2][0] - m[1][0]*m[2][2]) / det,
+			(m[0][0]*m[2][2] - m[0][2]*m[2][0]) / det,
+			(m[0][2]*m[1][0] - m[0][0]*m[1][2]) / det,
+		},
+		{
+			(m[1][0]*m[2][1] - m[1][1]*m[2][0]) / det,
+			(m[0][1]*m[2][0] - m[0][0]*m[2][1]) / det,
+			(m[0][0]*m[1][1] - m[0][1]*m[1][0]) / det,
+		},
+	}
+}
+
+// xyzToLab converts XYZ to CIE L*a*b* relative to the given white
+func xyzToLab(x, y, z float64, white XYZ) (float64, float64, float64) {
+	fx := labF(x / white.X)
+	fy := labF(y / white.Y)
+	fz := labF(z / white.Z)
+	return 116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)
+}
+
+// labToXyz converts CIE L*a*b* relative to the given white back to XYZ
+func labToXyz(l, a, b float64, white XYZ) (float64, float64, float64) {
+	fy := (l + 16) / 116
+	fx := fy + a/500
+	fz := fy - b/200
+	return white.X * labFInv(fx), white.Y * labFInv(fy), white.Z * labFInv(fz)
+}
+
+func labF(t float64) float64 {
+	if t > 216.0/24389.0 {
+		return math.Cbrt(t)
+	}
+	return (24389.0/27.0*t + 16) / 116
+}
+
+func labFInv(t float64) float64 {
+	if t3 := t * t * t; t3 > 216.0/24389.0 {
+		return t3
+	}
+	return (116*t - 16) / (24389.0 / 27.0)
+}
+
+// rgbToLab converts sRGB to CIE L*a*b* under D65
+func rgbToLab(r, g, b uint8) (float64, float64, float64) {
+	x, y, z := rgbToXyz(r, g, b)
+	return xyzToLab(x, y, z, IlluminantD65)
+}